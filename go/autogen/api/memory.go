@@ -16,3 +16,26 @@ func (c *PineconeMemoryConfig) ToConfig() (map[string]interface{}, error) {
 func (c *PineconeMemoryConfig) FromConfig(config map[string]interface{}) error {
 	return fromConfig(c, config)
 }
+
+type McpContextMemoryConfig struct {
+	// can be StdioServerParams | SseServerParams
+	ServerParams map[string]interface{} `json:"server_params"`
+	Resources    []string               `json:"resources,omitempty"`
+	Prompts      []McpPromptConfig      `json:"prompts,omitempty"`
+	TLS          *McpTLSConfig          `json:"tls,omitempty"`
+	// HeadersFrom are the headers whose values are read from Secrets when connecting to the server
+	HeadersFrom map[string]McpSecretKeyRef `json:"headers_from,omitempty"`
+}
+
+type McpPromptConfig struct {
+	Name      string            `json:"name"`
+	Arguments map[string]string `json:"arguments,omitempty"`
+}
+
+func (c *McpContextMemoryConfig) ToConfig() (map[string]interface{}, error) {
+	return toConfig(c)
+}
+
+func (c *McpContextMemoryConfig) FromConfig(config map[string]interface{}) error {
+	return fromConfig(c, config)
+}
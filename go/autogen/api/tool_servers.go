@@ -21,10 +21,30 @@ type StdioMcpServerConfig struct {
 }
 
 type SseMcpServerConfig struct {
-	URL            string                 `json:"url"`
-	Headers        map[string]interface{} `json:"headers,omitempty"`
-	Timeout        int                    `json:"timeout,omitempty"`
-	SseReadTimeout int                    `json:"sse_read_timeout,omitempty"`
+	URL     string                 `json:"url"`
+	Headers map[string]interface{} `json:"headers,omitempty"`
+	// HeadersFrom are the headers whose values are read from Secrets when connecting to the server
+	HeadersFrom    map[string]McpSecretKeyRef `json:"headers_from,omitempty"`
+	Timeout        int                        `json:"timeout,omitempty"`
+	SseReadTimeout int                        `json:"sse_read_timeout,omitempty"`
+	TLS            *McpTLSConfig              `json:"tls,omitempty"`
+}
+
+// McpSecretKeyRef references a key of a Secret, which is read when connecting to an MCP server
+type McpSecretKeyRef struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Key       string `json:"key"`
+}
+
+// McpTLSConfig references the Secret holding the PEM encoded certificates used to connect to an MCP server
+// with mutual TLS, which is read when connecting so that the private key isn't part of the configuration
+type McpTLSConfig struct {
+	SecretNamespace string `json:"secret_namespace"`
+	SecretName      string `json:"secret_name"`
+	CertKey         string `json:"cert_key"`
+	KeyKey          string `json:"key_key"`
+	CAKey           string `json:"ca_key,omitempty"`
 }
 
 type MCPToolConfig struct {
@@ -44,3 +44,62 @@ func (c *TeamToolConfig) ToConfig() (map[string]interface{}, error) {
 func (c *TeamToolConfig) FromConfig(config map[string]interface{}) error {
 	return fromConfig(c, config)
 }
+
+// ApprovalToolConfig requires a human approval before every call of a wrapped tool
+type ApprovalToolConfig struct {
+	Tool           *Component `json:"tool"`
+	Agent          string     `json:"agent"`
+	TimeoutSeconds int        `json:"timeout_seconds,omitempty"`
+}
+
+func (c *ApprovalToolConfig) ToConfig() (map[string]interface{}, error) {
+	return toConfig(c)
+}
+
+func (c *ApprovalToolConfig) FromConfig(config map[string]interface{}) error {
+	return fromConfig(c, config)
+}
+
+// RenamedToolConfig exposes a wrapped tool to the model under a different name and description
+type RenamedToolConfig struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Tool        *Component `json:"tool"`
+}
+
+func (c *RenamedToolConfig) ToConfig() (map[string]interface{}, error) {
+	return toConfig(c)
+}
+
+func (c *RenamedToolConfig) FromConfig(config map[string]interface{}) error {
+	return fromConfig(c, config)
+}
+
+// DryRunToolConfig exposes a wrapped tool to the model, but reports its calls instead of running them
+type DryRunToolConfig struct {
+	Tool *Component `json:"tool"`
+}
+
+func (c *DryRunToolConfig) ToConfig() (map[string]interface{}, error) {
+	return toConfig(c)
+}
+
+func (c *DryRunToolConfig) FromConfig(config map[string]interface{}) error {
+	return fromConfig(c, config)
+}
+
+// RemoteAgentToolConfig delegates tasks to a remote agent over the A2A protocol
+type RemoteAgentToolConfig struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	URL         string            `json:"url"`
+	Headers     map[string]string `json:"headers,omitempty"`
+}
+
+func (c *RemoteAgentToolConfig) ToConfig() (map[string]interface{}, error) {
+	return toConfig(c)
+}
+
+func (c *RemoteAgentToolConfig) FromConfig(config map[string]interface{}) error {
+	return fromConfig(c, config)
+}
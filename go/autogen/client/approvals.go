@@ -0,0 +1,44 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// ListApprovals lists the approval requests of tool calls, optionally only the ones with the given status
+func (c *client) ListApprovals(status ApprovalStatus) ([]*Approval, error) {
+	path := "/approvals/"
+	if status != "" {
+		path += "?status=" + url.QueryEscape(string(status))
+	}
+
+	var approvals []*Approval
+	err := c.doRequest("GET", path, nil, &approvals)
+	if err != nil {
+		return nil, err
+	}
+
+	return approvals, nil
+}
+
+// ApproveToolCall approves a pending tool call, which then runs
+func (c *client) ApproveToolCall(approvalID string, decision *ApprovalDecision) (*Approval, error) {
+	var approval Approval
+	err := c.doRequest("POST", fmt.Sprintf("/approvals/%s/approve", url.PathEscape(approvalID)), decision, &approval)
+	if err != nil {
+		return nil, err
+	}
+
+	return &approval, nil
+}
+
+// DenyToolCall denies a pending tool call, which then fails
+func (c *client) DenyToolCall(approvalID string, decision *ApprovalDecision) (*Approval, error) {
+	var approval Approval
+	err := c.doRequest("POST", fmt.Sprintf("/approvals/%s/deny", url.PathEscape(approvalID)), decision, &approval)
+	if err != nil {
+		return nil, err
+	}
+
+	return &approval, nil
+}
@@ -18,6 +18,8 @@ type client struct {
 }
 
 type Client interface {
+	ApproveToolCall(approvalID string, decision *ApprovalDecision) (*Approval, error)
+	CallTool(serverID int, toolName string, arguments map[string]interface{}, userID string) (*ToolCallResult, error)
 	CreateFeedback(feedback *FeedbackSubmission) error
 	CreateRun(req *CreateRunRequest) (*CreateRunResult, error)
 	CreateSession(session *CreateSession) (*Session, error)
@@ -27,6 +29,7 @@ type Client interface {
 	DeleteSession(sessionID int, userID string) error
 	DeleteTeam(teamID int, userID string) error
 	DeleteToolServer(serverID *int, userID string) error
+	DenyToolCall(approvalID string, decision *ApprovalDecision) (*Approval, error)
 	GetRun(runID int) (*Run, error)
 	GetRunMessages(runID uuid.UUID) ([]*RunMessage, error)
 	GetSession(sessionLabel string, userID string) (*Session, error)
@@ -41,7 +44,10 @@ type Client interface {
 	InvokeSessionStream(sessionID int, userID string, task string) (<-chan *SseEvent, error)
 	InvokeTask(req *InvokeTaskRequest) (*InvokeTaskResult, error)
 	InvokeTaskStream(req *InvokeTaskRequest) (<-chan *SseEvent, error)
+	ListApprovals(status ApprovalStatus) ([]*Approval, error)
 	ListFeedback(userID string) ([]*FeedbackSubmission, error)
+	ListPromptsForServer(serverID int, userID string) ([]*Prompt, error)
+	ListResourcesForServer(serverID int, userID string) ([]*Resource, error)
 	ListRuns(userID string) ([]*Run, error)
 	ListSessionRuns(sessionID int, userID string) ([]*Run, error)
 	ListSessions(userID string) ([]*Session, error)
@@ -1,6 +1,7 @@
 package fake
 
 import (
+	"encoding/json"
 	"fmt"
 	"sync"
 
@@ -22,7 +23,10 @@ type InMemoryAutogenClient struct {
 	toolServersByLabel map[string]*autogen_client.ToolServer
 	tools              map[string]*autogen_client.Tool
 	toolsByServer      map[int][]*autogen_client.Tool
+	resourcesByServer  map[int][]*autogen_client.Resource
+	promptsByServer    map[int][]*autogen_client.Prompt
 	feedback           []*autogen_client.FeedbackSubmission
+	approvals          []*autogen_client.Approval
 	runMessages        map[uuid.UUID][]*autogen_client.RunMessage
 
 	// ID counters
@@ -44,6 +48,8 @@ func NewInMemoryAutogenClient() *InMemoryAutogenClient {
 		toolServersByLabel: make(map[string]*autogen_client.ToolServer),
 		tools:              make(map[string]*autogen_client.Tool),
 		toolsByServer:      make(map[int][]*autogen_client.Tool),
+		resourcesByServer:  make(map[int][]*autogen_client.Resource),
+		promptsByServer:    make(map[int][]*autogen_client.Prompt),
 		feedback:           make([]*autogen_client.FeedbackSubmission, 0),
 		runMessages:        make(map[uuid.UUID][]*autogen_client.RunMessage),
 		nextSessionID:      1,
@@ -154,6 +160,53 @@ func (m *InMemoryAutogenClient) InvokeSession(sessionID int, userID string, task
 	}, nil
 }
 
+// AddApproval adds an approval request, as a call of a tool requiring an approval would
+func (m *InMemoryAutogenClient) AddApproval(approval *autogen_client.Approval) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.approvals = append(m.approvals, approval)
+}
+
+// AddTool adds a tool, as the discovery of the tools of its server would
+func (m *InMemoryAutogenClient) AddTool(tool *autogen_client.Tool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.tools[tool.Component.Provider] = tool
+	if tool.ServerID != nil {
+		m.toolsByServer[*tool.ServerID] = append(m.toolsByServer[*tool.ServerID], tool)
+	}
+}
+
+func (m *InMemoryAutogenClient) ApproveToolCall(approvalID string, decision *autogen_client.ApprovalDecision) (*autogen_client.Approval, error) {
+	return m.decideToolCall(approvalID, autogen_client.ApprovalStatusApproved, decision)
+}
+
+func (m *InMemoryAutogenClient) DenyToolCall(approvalID string, decision *autogen_client.ApprovalDecision) (*autogen_client.Approval, error) {
+	return m.decideToolCall(approvalID, autogen_client.ApprovalStatusDenied, decision)
+}
+
+func (m *InMemoryAutogenClient) decideToolCall(
+	approvalID string,
+	status autogen_client.ApprovalStatus,
+	decision *autogen_client.ApprovalDecision,
+) (*autogen_client.Approval, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, approval := range m.approvals {
+		if approval.ApprovalID == approvalID && approval.Status == autogen_client.ApprovalStatusPending {
+			approval.Status = status
+			approval.DecidedBy = decision.UserID
+			approval.Reason = decision.Reason
+			return approval, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no pending approval with ID %s", approvalID)
+}
+
 func (m *InMemoryAutogenClient) CreateFeedback(feedback *autogen_client.FeedbackSubmission) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -179,6 +232,25 @@ func (m *InMemoryAutogenClient) CreateTeam(team *autogen_client.Team) error {
 	return nil
 }
 
+// CallTool returns the arguments of the call as text content
+func (m *InMemoryAutogenClient) CallTool(serverID int, toolName string, arguments map[string]interface{}, userID string) (*autogen_client.ToolCallResult, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if _, exists := m.toolServers[serverID]; !exists {
+		return nil, fmt.Errorf("tool server with ID %d not found", serverID)
+	}
+
+	text, err := json.Marshal(arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	return &autogen_client.ToolCallResult{
+		Content: []map[string]interface{}{{"type": "text", "text": string(text)}},
+	}, nil
+}
+
 func (m *InMemoryAutogenClient) CreateToolServer(toolServer *autogen_client.ToolServer, userID string) (*autogen_client.ToolServer, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -264,6 +336,8 @@ func (m *InMemoryAutogenClient) DeleteToolServer(serverID *int, userID string) e
 		delete(m.toolServersByLabel, toolServer.Component.Label)
 	}
 	delete(m.toolsByServer, *serverID)
+	delete(m.resourcesByServer, *serverID)
+	delete(m.promptsByServer, *serverID)
 
 	return nil
 }
@@ -308,12 +382,8 @@ func (m *InMemoryAutogenClient) GetTeam(teamLabel string, userID string) (*autog
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	team, exists := m.teamsByLabel[teamLabel]
-	if !exists {
-		return nil, fmt.Errorf("team with label %s not found", teamLabel)
-	}
-
-	return team, nil
+	// like the real client, a missing team is not an error
+	return m.teamsByLabel[teamLabel], nil
 }
 
 func (m *InMemoryAutogenClient) GetTool(provider string, userID string) (*autogen_client.Tool, error) {
@@ -390,6 +460,19 @@ func (m *InMemoryAutogenClient) InvokeTaskStream(req *autogen_client.InvokeTaskR
 	return ch, nil
 }
 
+func (m *InMemoryAutogenClient) ListApprovals(status autogen_client.ApprovalStatus) ([]*autogen_client.Approval, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var approvals []*autogen_client.Approval
+	for _, approval := range m.approvals {
+		if status == "" || approval.Status == status {
+			approvals = append(approvals, approval)
+		}
+	}
+	return approvals, nil
+}
+
 func (m *InMemoryAutogenClient) ListFeedback(userID string) ([]*autogen_client.FeedbackSubmission, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -397,6 +480,30 @@ func (m *InMemoryAutogenClient) ListFeedback(userID string) ([]*autogen_client.F
 	return m.feedback, nil
 }
 
+func (m *InMemoryAutogenClient) ListPromptsForServer(serverID int, userID string) ([]*autogen_client.Prompt, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	prompts, exists := m.promptsByServer[serverID]
+	if !exists {
+		return []*autogen_client.Prompt{}, nil
+	}
+
+	return prompts, nil
+}
+
+func (m *InMemoryAutogenClient) ListResourcesForServer(serverID int, userID string) ([]*autogen_client.Resource, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	resources, exists := m.resourcesByServer[serverID]
+	if !exists {
+		return []*autogen_client.Resource{}, nil
+	}
+
+	return resources, nil
+}
+
 func (m *InMemoryAutogenClient) ListRuns(userID string) ([]*autogen_client.Run, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -2,6 +2,7 @@ package client
 
 import (
 	"fmt"
+	"net/url"
 )
 
 func (c *client) CreateToolServer(toolServer *ToolServer, userID string) (*ToolServer, error) {
@@ -56,6 +57,32 @@ func (c *client) ListToolsForServer(serverID *int, userID string) ([]*Tool, erro
 	return tools, err
 }
 
+// ListResourcesForServer discovers the resources exposed by a specific server
+func (c *client) ListResourcesForServer(serverID int, userID string) ([]*Resource, error) {
+	var resources []*Resource
+	err := c.doRequest("GET", fmt.Sprintf("/toolservers/%d/resources?user_id=%s", serverID, userID), nil, &resources)
+	return resources, err
+}
+
+// ListPromptsForServer discovers the prompts exposed by a specific server
+func (c *client) ListPromptsForServer(serverID int, userID string) ([]*Prompt, error) {
+	var prompts []*Prompt
+	err := c.doRequest("GET", fmt.Sprintf("/toolservers/%d/prompts?user_id=%s", serverID, userID), nil, &prompts)
+	return prompts, err
+}
+
+// CallTool calls a tool on a specific server
+func (c *client) CallTool(serverID int, toolName string, arguments map[string]interface{}, userID string) (*ToolCallResult, error) {
+	var result ToolCallResult
+	err := c.doRequest(
+		"POST",
+		fmt.Sprintf("/toolservers/%d/tools/%s/call?user_id=%s", serverID, url.PathEscape(toolName), userID),
+		&ToolCallRequest{Arguments: arguments},
+		&result,
+	)
+	return &result, err
+}
+
 // RefreshToolServer refreshes tools for a specific server
 func (c *client) RefreshToolServer(serverID int, userID string) error {
 	return c.doRequest(
@@ -30,6 +30,38 @@ type Tool struct {
 	ServerID  *int           `json:"server_id,omitempty"`
 }
 
+// Resource is a resource exposed by an MCP tool server
+type Resource struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+// Prompt is a prompt template exposed by an MCP tool server
+type Prompt struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Arguments   []*PromptArgument `json:"arguments,omitempty"`
+}
+
+// ToolCallRequest is the request to call a tool on an MCP tool server
+type ToolCallRequest struct {
+	Arguments map[string]interface{} `json:"arguments"`
+}
+
+// ToolCallResult is the result of a tool call, with the content returned by the MCP tool server
+type ToolCallResult struct {
+	Content []map[string]interface{} `json:"content"`
+	IsError bool                     `json:"is_error"`
+}
+
+type PromptArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
 type StdioMcpServerConfig struct {
 	Command string            `json:"command"`
 	Args    []string          `json:"args,omitempty"`
@@ -221,3 +253,40 @@ type FeedbackSubmission struct {
 	// MessageID is the ID of the message this feedback pertains to.
 	MessageID int `json:"message_id,omitempty"`
 }
+
+// ApprovalStatus is the status of the approval request of a tool call
+type ApprovalStatus string
+
+const (
+	ApprovalStatusPending   ApprovalStatus = "pending"
+	ApprovalStatusApproved  ApprovalStatus = "approved"
+	ApprovalStatusDenied    ApprovalStatus = "denied"
+	ApprovalStatusExpired   ApprovalStatus = "expired"
+	ApprovalStatusCancelled ApprovalStatus = "cancelled"
+)
+
+// Approval is the request of an agent to call a tool which requires a human approval.
+// Decided requests are kept as an audit trail.
+type Approval struct {
+	ID        int    `json:"id,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+
+	// ApprovalID identifies the request when approving or denying it
+	ApprovalID string                 `json:"approval_id"`
+	Agent      string                 `json:"agent"`
+	Tool       string                 `json:"tool"`
+	Arguments  map[string]interface{} `json:"arguments,omitempty"`
+	Status     ApprovalStatus         `json:"status"`
+	ExpiresAt  string                 `json:"expires_at,omitempty"`
+	DecidedAt  string                 `json:"decided_at,omitempty"`
+	// DecidedBy is the user who approved or denied the call
+	DecidedBy string `json:"decided_by,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// ApprovalDecision is the payload for approving or denying a tool call
+type ApprovalDecision struct {
+	UserID string `json:"user_id"`
+	Reason string `json:"reason,omitempty"`
+}
@@ -35,6 +35,7 @@ func main() {
 	rootCmd.PersistentFlags().StringVar(&cfg.UserID, "user-id", "admin@kagent.dev", "User ID")
 	rootCmd.PersistentFlags().StringVarP(&cfg.Namespace, "namespace", "n", "kagent", "Namespace")
 	rootCmd.PersistentFlags().StringVar(&cfg.A2AURL, "a2a-url", "http://localhost:8083/api/a2a", "A2A URL")
+	rootCmd.PersistentFlags().StringVar(&cfg.ControllerURL, "controller-url", "http://localhost:8083/api", "Controller API URL")
 	rootCmd.PersistentFlags().StringVarP(&cfg.OutputFormat, "output-format", "o", "table", "Output format")
 	rootCmd.PersistentFlags().BoolVarP(&cfg.Verbose, "verbose", "v", false, "Verbose output")
 	installCmd := &cobra.Command{
@@ -166,7 +167,79 @@ func main() {
 		},
 	}
 
-	rootCmd.AddCommand(installCmd, uninstallCmd, invokeCmd, bugReportCmd, versionCmd, dashboardCmd, getCmd)
+	toolCallCfg := &cli.ToolCallCfg{
+		Config: cfg,
+	}
+
+	toolCmd := &cobra.Command{
+		Use:   "tool",
+		Short: "Interact with the tools of a tool server",
+		Long:  `Interact with the tools of a tool server`,
+	}
+
+	toolCallCmd := &cobra.Command{
+		Use:   "call <tool-server> <tool>",
+		Short: "Call a single tool",
+		Long:  `Call a single tool of a tool server with the given JSON arguments, and print the raw MCP result`,
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			toolCallCfg.ToolServer = args[0]
+			toolCallCfg.Tool = args[1]
+			cli.ToolCallCmd(cmd.Context(), toolCallCfg)
+		},
+	}
+
+	toolCallCmd.Flags().StringVarP(&toolCallCfg.Args, "args", "a", "{}", "The arguments of the tool as a JSON object")
+	toolCallCmd.Flags().StringVar(&toolCallCfg.Mode, "mode", "", "Restrict the call: readOnly refuses mutating tools, dryRun only reports the call")
+	toolCmd.AddCommand(toolCallCmd)
+
+	approvalsCmd := &cobra.Command{
+		Use:   "approvals",
+		Short: "Review the tool calls waiting for an approval",
+		Long:  `Review the tool calls waiting for an approval`,
+	}
+
+	approvalsCfg := &cli.ApprovalsCfg{
+		Config: cfg,
+	}
+
+	approvalsListCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the pending approvals",
+		Long:  `List the pending approvals, or all the approvals with --all, including the decided and expired ones`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cli.ListApprovalsCmd(cmd.Context(), approvalsCfg)
+		},
+	}
+	approvalsListCmd.Flags().BoolVar(&approvalsCfg.All, "all", false, "List all the approvals instead of only the pending ones")
+
+	approvalsApproveCmd := &cobra.Command{
+		Use:   "approve <approval-id>",
+		Short: "Approve a pending tool call",
+		Long:  `Approve a pending tool call, which then runs`,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cli.DecideApprovalCmd(cmd.Context(), approvalsCfg, args[0], true)
+		},
+	}
+
+	approvalsDenyCmd := &cobra.Command{
+		Use:   "deny <approval-id>",
+		Short: "Deny a pending tool call",
+		Long:  `Deny a pending tool call, which then fails with the given reason`,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cli.DecideApprovalCmd(cmd.Context(), approvalsCfg, args[0], false)
+		},
+	}
+
+	for _, decisionCmd := range []*cobra.Command{approvalsApproveCmd, approvalsDenyCmd} {
+		decisionCmd.Flags().StringVar(&approvalsCfg.Reason, "reason", "", "The reason for the decision, recorded in the audit trail")
+		decisionCmd.Flags().StringVar(&approvalsCfg.Token, "token", "", "The token the approver is authenticated with, defaults to the bearer token of the current kubeconfig context")
+	}
+	approvalsCmd.AddCommand(approvalsListCmd, approvalsApproveCmd, approvalsDenyCmd)
+
+	rootCmd.AddCommand(installCmd, uninstallCmd, invokeCmd, bugReportCmd, versionCmd, dashboardCmd, getCmd, toolCmd, approvalsCmd)
 
 	// Initialize config
 	if err := config.Init(); err != nil {
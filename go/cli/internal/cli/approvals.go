@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	autogen_client "github.com/kagent-dev/kagent/go/autogen/client"
+	"github.com/kagent-dev/kagent/go/cli/internal/config"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+type ApprovalsCfg struct {
+	All    bool
+	Reason string
+	// Token authenticates the approver, it defaults to the bearer token of the current kubeconfig context
+	Token  string
+	Config *config.Config
+}
+
+// ListApprovalsCmd lists the approvals through the controller
+func ListApprovalsCmd(ctx context.Context, cfg *ApprovalsCfg) {
+	cancel := startPortForward(ctx)
+	defer cancel()
+
+	path := "/approvals"
+	if !cfg.All {
+		path += "?status=" + url.QueryEscape(string(autogen_client.ApprovalStatusPending))
+	}
+
+	var approvals []*autogen_client.Approval
+	if err := doControllerRequest(ctx, cfg.Config.ControllerURL, http.MethodGet, path, "", nil, &approvals); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to list approvals: %v\n", err)
+		return
+	}
+
+	if len(approvals) == 0 {
+		fmt.Println("No approvals found")
+		return
+	}
+
+	if err := printApprovals(approvals); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to print approvals: %v\n", err)
+		return
+	}
+}
+
+// DecideApprovalCmd approves or denies a pending tool call through the controller,
+// which records the user the token was issued to as the approver
+func DecideApprovalCmd(ctx context.Context, cfg *ApprovalsCfg, approvalID string, approve bool) {
+	token, err := approverToken(cfg.Token)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to get a token to authenticate with: %v\n", err)
+		return
+	}
+
+	cancel := startPortForward(ctx)
+	defer cancel()
+
+	decision := "deny"
+	if approve {
+		decision = "approve"
+	}
+	path := fmt.Sprintf("/approvals/%s/%s", url.PathEscape(approvalID), decision)
+
+	var approval autogen_client.Approval
+	if err := doControllerRequest(ctx, cfg.Config.ControllerURL, http.MethodPost, path, token, map[string]string{"reason": cfg.Reason}, &approval); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to decide on approval %s: %v\n", approvalID, err)
+		return
+	}
+
+	fmt.Printf("Call of %s by %s %s by %s\n", approval.Tool, approval.Agent, approval.Status, approval.DecidedBy)
+}
+
+// approverToken returns the given token, or else the bearer token of the current kubeconfig context
+func approverToken(token string) (string, error) {
+	if token != "" {
+		return token, nil
+	}
+	restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		clientcmd.NewDefaultClientConfigLoadingRules(),
+		&clientcmd.ConfigOverrides{},
+	).ClientConfig()
+	if err != nil {
+		return "", err
+	}
+	if restConfig.BearerToken != "" {
+		return restConfig.BearerToken, nil
+	}
+	if restConfig.BearerTokenFile != "" {
+		content, err := os.ReadFile(restConfig.BearerTokenFile)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(content)), nil
+	}
+	return "", fmt.Errorf("the current kubeconfig context has no bearer token, pass one with --token, e.g. created with `kubectl create token`")
+}
+
+func doControllerRequest(
+	ctx context.Context,
+	controllerURL, method, path, token string,
+	body interface{},
+	result interface{},
+) error {
+	var reqBody io.Reader
+	if body != nil {
+		byt, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(byt)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, controllerURL+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("request failed with status %s: %s", resp.Status, bytes.TrimSpace(respBody))
+	}
+
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return fmt.Errorf("failed to decode response: %v", err)
+	}
+	return nil
+}
+
+func printApprovals(approvals []*autogen_client.Approval) error {
+	headers := []string{"#", "ID", "AGENT", "TOOL", "ARGUMENTS", "STATUS", "DECIDED BY", "CREATED", "EXPIRES"}
+	rows := make([][]string, len(approvals))
+	for i, approval := range approvals {
+		arguments, _ := json.Marshal(approval.Arguments)
+		rows[i] = []string{
+			strconv.Itoa(i + 1),
+			approval.ApprovalID,
+			approval.Agent,
+			approval.Tool,
+			string(arguments),
+			string(approval.Status),
+			approval.DecidedBy,
+			approval.CreatedAt,
+			approval.ExpiresAt,
+		}
+	}
+
+	return printOutput(approvals, headers, rows)
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/kagent-dev/kagent/go/cli/internal/config"
+)
+
+type ToolCallCfg struct {
+	ToolServer string
+	Tool       string
+	Args       string
+	Mode       string
+	Config     *config.Config
+}
+
+func ToolCallCmd(ctx context.Context, cfg *ToolCallCfg) {
+	var arguments map[string]interface{}
+	if err := json.Unmarshal([]byte(cfg.Args), &arguments); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid arguments, expected a JSON object: %v\n", err)
+		os.Exit(1)
+	}
+
+	cancel := startPortForward(ctx)
+	defer cancel()
+
+	result, err := callTool(ctx, cfg.Config.ControllerURL, cfg.ToolServer, cfg.Tool, arguments, cfg.Mode)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error calling tool: %v\n", err)
+		cancel()
+		os.Exit(1)
+	}
+
+	byt, _ := json.MarshalIndent(result, "", "  ")
+	fmt.Fprintln(os.Stdout, string(byt))
+
+	if isError, _ := result["isError"].(bool); isError {
+		cancel()
+		os.Exit(1)
+	}
+}
+
+// callTool calls the tool through the controller, which validates the arguments against the input schema of the tool
+// and refuses the tools which require an approval or which the mode doesn't allow
+func callTool(
+	ctx context.Context,
+	controllerURL, toolServer, tool string,
+	arguments map[string]interface{},
+	mode string,
+) (map[string]interface{}, error) {
+	body, err := json.Marshal(map[string]interface{}{"arguments": arguments, "mode": mode})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("%s/toolservers/%s/tools/%s/call", controllerURL, url.PathEscape(toolServer), url.PathEscape(tool)),
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("request failed with status %s: %s", resp.Status, bytes.TrimSpace(respBody))
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
+	return result, nil
+}
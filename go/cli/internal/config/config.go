@@ -10,12 +10,13 @@ import (
 )
 
 type Config struct {
-	APIURL       string `mapstructure:"api_url"`
-	UserID       string `mapstructure:"user_id"`
-	Namespace    string `mapstructure:"namespace"`
-	A2AURL       string `mapstructure:"a2a_url"`
-	OutputFormat string `mapstructure:"output_format"`
-	Verbose      bool   `mapstructure:"verbose"`
+	APIURL        string `mapstructure:"api_url"`
+	UserID        string `mapstructure:"user_id"`
+	Namespace     string `mapstructure:"namespace"`
+	A2AURL        string `mapstructure:"a2a_url"`
+	ControllerURL string `mapstructure:"controller_url"`
+	OutputFormat  string `mapstructure:"output_format"`
+	Verbose       bool   `mapstructure:"verbose"`
 }
 
 func Init() error {
@@ -42,6 +43,7 @@ func Init() error {
 	viper.SetDefault("output_format", "table")
 	viper.SetDefault("namespace", "kagent")
 	viper.SetDefault("a2a_url", "http://localhost:8083/api/a2a")
+	viper.SetDefault("controller_url", "http://localhost:8083/api")
 
 	viper.MustBindEnv("USER_ID")
 
@@ -26,6 +26,18 @@ import (
 
 const (
 	AgentConditionTypeAccepted = "Accepted"
+	// AgentConditionTypeServiceAccountReady reports whether the ServiceAccount of the agent exists
+	AgentConditionTypeServiceAccountReady = "ServiceAccountReady"
+	// AgentConditionTypeDrifted reports whether the autogen team of the agent was changed outside of kagent and repaired
+	AgentConditionTypeDrifted = "Drifted"
+	// AgentConditionTypeResolvedRefs reports whether the resources referenced by the agent exist
+	AgentConditionTypeResolvedRefs = "ResolvedRefs"
+	// AgentConditionTypeTranslated reports whether the agent could be translated to an autogen team
+	AgentConditionTypeTranslated = "Translated"
+	// AgentConditionTypeSynced reports whether the autogen team of the agent is up to date in autogen
+	AgentConditionTypeSynced = "Synced"
+	// AgentConditionTypeReady reports whether the agent can be used, i.e. all the conditions above are true
+	AgentConditionTypeReady = "Ready"
 )
 
 // AgentSpec defines the desired state of Agent.
@@ -43,6 +55,29 @@ type AgentSpec struct {
 	Tools []*Tool `json:"tools,omitempty"`
 	// +optional
 	Memory []string `json:"memory,omitempty"`
+	// Resources and prompts discovered on ToolServers which are provided to the agent.
+	// Resources are added to the context of the agent, prompts are added as system message fragments.
+	// +optional
+	McpContext []*McpServerContext `json:"mcpContext,omitempty"`
+	// The name of a ServiceAccount in the namespace of the agent.
+	// If specified, the builtin tools which access the cluster use a token of this ServiceAccount,
+	// so the agent is constrained by its RBAC instead of the permissions of kagent.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// Whether the agent may call the tools which change the state of the systems they access.
+	// readOnly removes the mutating tools, dryRun keeps them but only reports the calls instead of running them.
+	// Builtin tools are mutating if the tool catalog marks them as such, MCP tools unless the ToolServer
+	// annotates them as read-only, and Http tools unless they use the GET method.
+	// Invocations through the API can restrict the mode further.
+	// +optional
+	// +kubebuilder:default=readWrite
+	Mode AgentMode `json:"mode,omitempty"`
+	// How deeply agents may be nested as tools below this agent, e.g. 1 allows the agents used as tools
+	// of this agent but not their own agent tools. Cycles of agent tools are always rejected.
+	// If not specified, the limit configured on the controller is used.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxToolDepth *int32 `json:"maxToolDepth,omitempty"`
 	// A2AConfig instantiates an A2A server for this agent,
 	// served on the HTTP port of the kagent kubernetes
 	// controller (default 8083).
@@ -53,14 +88,26 @@ type AgentSpec struct {
 	A2AConfig *A2AConfig `json:"a2aConfig,omitempty"`
 }
 
+// AgentMode restricts the tools an agent may call
+// +kubebuilder:validation:Enum=readWrite;readOnly;dryRun
+type AgentMode string
+
+const (
+	AgentMode_ReadWrite AgentMode = "readWrite"
+	AgentMode_ReadOnly  AgentMode = "readOnly"
+	AgentMode_DryRun    AgentMode = "dryRun"
+)
+
 // ToolProviderType represents the tool provider type
-// +kubebuilder:validation:Enum=Builtin;McpServer;Agent
+// +kubebuilder:validation:Enum=Builtin;McpServer;Agent;Http;RemoteAgent
 type ToolProviderType string
 
 const (
-	ToolProviderType_Builtin   ToolProviderType = "Builtin"
-	ToolProviderType_McpServer ToolProviderType = "McpServer"
-	ToolProviderType_Agent     ToolProviderType = "Agent"
+	ToolProviderType_Builtin     ToolProviderType = "Builtin"
+	ToolProviderType_McpServer   ToolProviderType = "McpServer"
+	ToolProviderType_Agent       ToolProviderType = "Agent"
+	ToolProviderType_Http        ToolProviderType = "Http"
+	ToolProviderType_RemoteAgent ToolProviderType = "RemoteAgent"
 )
 
 // +kubebuilder:validation:XValidation:message="type.builtin must be nil if the type is not Builtin",rule="!(has(self.builtin) && self.type != 'Builtin')"
@@ -69,8 +116,14 @@ const (
 // +kubebuilder:validation:XValidation:message="type.mcpServer must be specified for McpServer filter.type",rule="!(!has(self.mcpServer) && self.type == 'McpServer')"
 // +kubebuilder:validation:XValidation:message="type.agent must be nil if the type is not Agent",rule="!(has(self.agent) && self.type != 'Agent')"
 // +kubebuilder:validation:XValidation:message="type.agent must be specified for Agent filter.type",rule="!(!has(self.agent) && self.type == 'Agent')"
+// +kubebuilder:validation:XValidation:message="type.http must be nil if the type is not Http",rule="!(has(self.http) && self.type != 'Http')"
+// +kubebuilder:validation:XValidation:message="type.http must be specified for Http filter.type",rule="!(!has(self.http) && self.type == 'Http')"
+// +kubebuilder:validation:XValidation:message="type.remoteAgent must be nil if the type is not RemoteAgent",rule="!(has(self.remoteAgent) && self.type != 'RemoteAgent')"
+// +kubebuilder:validation:XValidation:message="type.remoteAgent must be specified for RemoteAgent filter.type",rule="!(!has(self.remoteAgent) && self.type == 'RemoteAgent')"
+// +kubebuilder:validation:XValidation:message="requireApproval is only supported for Builtin, McpServer, Http and RemoteAgent tools",rule="!(has(self.requireApproval) && self.requireApproval && self.type == 'Agent')"
+// +kubebuilder:validation:XValidation:message="approvalTimeout requires requireApproval",rule="!(has(self.approvalTimeout) && !(has(self.requireApproval) && self.requireApproval))"
 type Tool struct {
-	// +kubebuilder:validation:Enum=Builtin;McpServer;Agent
+	// +kubebuilder:validation:Enum=Builtin;McpServer;Agent;Http;RemoteAgent
 	Type ToolProviderType `json:"type,omitempty"`
 	// +optional
 	Builtin *BuiltinTool `json:"builtin,omitempty"`
@@ -78,6 +131,17 @@ type Tool struct {
 	McpServer *McpServerTool `json:"mcpServer,omitempty"`
 	// +optional
 	Agent *AgentTool `json:"agent,omitempty"`
+	// +optional
+	Http *HttpTool `json:"http,omitempty"`
+	// +optional
+	RemoteAgent *RemoteAgentTool `json:"remoteAgent,omitempty"`
+	// Whether every call of the tool must be approved by a human before it runs.
+	// Pending calls are listed on /api/approvals, and fail if they are denied or not approved in time.
+	// +optional
+	RequireApproval bool `json:"requireApproval,omitempty"`
+	// How long a call waits for an approval, as a duration string, e.g. 5m. Defaults to 10m.
+	// +optional
+	ApprovalTimeout string `json:"approvalTimeout,omitempty"`
 }
 
 type AgentTool struct {
@@ -87,6 +151,23 @@ type AgentTool struct {
 	Ref string `json:"ref,omitempty"`
 }
 
+type RemoteAgentTool struct {
+	// Reference to the RemoteAgent resource the tasks are delegated to over A2A.
+	// Can either be a reference to the name of a RemoteAgent in the same namespace as the referencing Agent, or a reference to the name of a RemoteAgent in a different namespace in the form <namespace>/<name>
+	// +kubebuilder:validation:MinLength=1
+	Ref string `json:"ref"`
+}
+
+type HttpTool struct {
+	// the name of the HttpToolSet that provides the tools. can either be a reference to the name of an HttpToolSet in the same namespace as the referencing Agent, or a reference to the name of an HttpToolSet in a different namespace in the form <namespace>/<name>
+	// +kubebuilder:validation:MinLength=1
+	ToolSet string `json:"toolSet"`
+	// The operation IDs of the operations of the HttpToolSet to provide.
+	// If not specified, all the tools of the HttpToolSet are provided.
+	// +optional
+	Operations []string `json:"operations,omitempty"`
+}
+
 type BuiltinTool struct {
 	// the name of the builtin tool
 	Name string `json:"name,omitempty"`
@@ -103,8 +184,58 @@ type McpServerTool struct {
 	// For a list of all the tools provided by the server,
 	// the client can query the status of the ToolServer object after it has been created
 	ToolNames []string `json:"toolNames,omitempty"`
+	// Patterns matched against the names of the tools discovered on the ToolServer.
+	// Every discovered tool matching at least one pattern is provided in addition to the tools listed in toolNames.
+	// +optional
+	IncludePatterns []string `json:"includePatterns,omitempty"`
+	// Patterns matched against the names of the selected tools.
+	// Tools matching any of these patterns are removed, even if they are listed in toolNames.
+	// +optional
+	ExcludePatterns []string `json:"excludePatterns,omitempty"`
+	// The syntax used by includePatterns and excludePatterns.
+	// Regex patterns must match the whole tool name.
+	// +optional
+	// +kubebuilder:default=Glob
+	PatternType ToolPatternType `json:"patternType,omitempty"`
+	// A prefix added to the name of every tool provided by this reference, as seen by the model.
+	// Use it to avoid collisions between tools with the same name on different ToolServers.
+	// +optional
+	NamePrefix string `json:"namePrefix,omitempty"`
+	// Descriptions to use instead of the ones advertised by the ToolServer, keyed by the discovered tool name.
+	// +optional
+	DescriptionOverrides map[string]string `json:"descriptionOverrides,omitempty"`
 }
 
+type McpServerContext struct {
+	// The reference to the ToolServer that provides the resources and prompts.
+	ToolServer string `json:"toolServer"`
+	// The URIs of the resources to add to the context of the agent.
+	// For a list of all the resources provided by the server,
+	// the client can query the status of the ToolServer object after it has been created
+	// +optional
+	Resources []string `json:"resources,omitempty"`
+	// The prompts to add to the system message of the agent, in order.
+	// +optional
+	Prompts []McpPromptRef `json:"prompts,omitempty"`
+}
+
+type McpPromptRef struct {
+	// The name of the prompt discovered on the ToolServer.
+	Name string `json:"name"`
+	// The arguments used to render the prompt.
+	// +optional
+	Arguments map[string]string `json:"arguments,omitempty"`
+}
+
+// ToolPatternType represents the syntax of the patterns used to select MCP tools
+// +kubebuilder:validation:Enum=Glob;Regex
+type ToolPatternType string
+
+const (
+	ToolPatternType_Glob  ToolPatternType = "Glob"
+	ToolPatternType_Regex ToolPatternType = "Regex"
+)
+
 type AnyType struct {
 	json.RawMessage `json:",inline"`
 }
@@ -120,12 +251,48 @@ type AgentSkill server.AgentSkill
 type AgentStatus struct {
 	ObservedGeneration int64              `json:"observedGeneration,omitempty"`
 	Conditions         []metav1.Condition `json:"conditions,omitempty"`
+	// The names of the tools provided to the agent, after all tool references have been resolved.
+	// +optional
+	ResolvedTools []string `json:"resolvedTools,omitempty"`
+	// The hash of the autogen component last sent for the agent, which is only sent again once it changes.
+	// +optional
+	ComponentHash string `json:"componentHash,omitempty"`
+	// The namespace/name of the ModelConfig used by the agent, which is the default one unless the agent sets one.
+	// +optional
+	ModelConfig string `json:"modelConfig,omitempty"`
+	// The namespace/name of the Memory resources used by the agent.
+	// +optional
+	Memory []string `json:"memory,omitempty"`
+	// The ID of the team of the agent in autogen.
+	// +optional
+	TeamID int `json:"teamID,omitempty"`
+	// The URL the A2A server of the agent is advertised at, if A2A is enabled for the agent.
+	// +optional
+	A2AURL string `json:"a2aURL,omitempty"`
+	// The resources referenced by the agent, with the generation of each which was last translated.
+	// +optional
+	References []ObservedReference `json:"references,omitempty"`
+}
+
+// ObservedReference is a resource referenced by an agent or a team
+type ObservedReference struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// The generation of the resource which was last translated.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
-// +kubebuilder:printcolumn:name="Accepted",type="string",JSONPath=".status.conditions[0].status",description="Whether or not the agent has been accepted by the system."
-// +kubebuilder:printcolumn:name="ModelConfig",type="string",JSONPath=".spec.modelConfig",description="The ModelConfig resource referenced by this agent."
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="Whether or not the agent can be used."
+// +kubebuilder:printcolumn:name="Accepted",type="string",JSONPath=".status.conditions[?(@.type=='Accepted')].status",description="Whether or not the agent has been accepted by the system."
+// +kubebuilder:printcolumn:name="ModelConfig",type="string",JSONPath=".status.modelConfig",description="The ModelConfig used by this agent."
+// +kubebuilder:printcolumn:name="Tools",type="string",JSONPath=".status.resolvedTools",priority=1,description="The tools provided to this agent."
+// +kubebuilder:printcolumn:name="A2A",type="string",JSONPath=".status.a2aURL",priority=1,description="The URL of the A2A server of this agent."
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Agent is the Schema for the agents API.
 type Agent struct {
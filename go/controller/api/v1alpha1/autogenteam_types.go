@@ -22,6 +22,16 @@ import (
 
 const (
 	TeamConditionTypeAccepted = "Accepted"
+	// TeamConditionTypeDrifted reports whether the autogen team was changed outside of kagent and repaired
+	TeamConditionTypeDrifted = "Drifted"
+	// TeamConditionTypeResolvedRefs reports whether the resources referenced by the team exist
+	TeamConditionTypeResolvedRefs = "ResolvedRefs"
+	// TeamConditionTypeTranslated reports whether the team could be translated to an autogen team
+	TeamConditionTypeTranslated = "Translated"
+	// TeamConditionTypeSynced reports whether the autogen team is up to date in autogen
+	TeamConditionTypeSynced = "Synced"
+	// TeamConditionTypeReady reports whether the team can be used, i.e. all the conditions above are true
+	TeamConditionTypeReady = "Ready"
 )
 
 // TeamSpec defines the desired state of Team.
@@ -91,10 +101,27 @@ type OrTerminationCondition struct {
 type TeamStatus struct {
 	Conditions         []metav1.Condition `json:"conditions"`
 	ObservedGeneration int64              `json:"observedGeneration"`
+	// The hash of the autogen component last sent for the team, which is only sent again once it changes.
+	// +optional
+	ComponentHash string `json:"componentHash,omitempty"`
+	// The namespace/name of the ModelConfig used by the team, which is the default one unless the team sets one.
+	// +optional
+	ModelConfig string `json:"modelConfig,omitempty"`
+	// The ID of the team in autogen.
+	// +optional
+	TeamID int `json:"teamID,omitempty"`
+	// The resources referenced by the team, with the generation of each which was last translated.
+	// +optional
+	References []ObservedReference `json:"references,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="Whether or not the team can be used."
+// +kubebuilder:printcolumn:name="ModelConfig",type="string",JSONPath=".status.modelConfig",description="The ModelConfig used by this team."
+// +kubebuilder:printcolumn:name="Participants",type="string",JSONPath=".spec.participants",priority=1,description="The agents participating in this team."
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Team is the Schema for the teams API.
 type Team struct {
@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// HttpToolSetConditionTypeResolved reports whether the OpenAPI document of the tool set could be loaded and parsed
+	HttpToolSetConditionTypeResolved = "Resolved"
+)
+
+// HttpToolSetSpec defines the desired state of HttpToolSet.
+type HttpToolSetSpec struct {
+	// +optional
+	Description string `json:"description,omitempty"`
+	// The OpenAPI 3 document describing the API, in JSON or YAML.
+	// One HTTP tool is generated for each operation of the document.
+	OpenAPI OpenAPISource `json:"openAPI"`
+	// The URL the operations are sent to, e.g. https://api.example.com/v1.
+	// If not specified, the first server of the OpenAPI document is used.
+	// +optional
+	BaseURL string `json:"baseURL,omitempty"`
+	// The operation IDs of the operations to generate tools for.
+	// If not specified, a tool is generated for every operation with an operation ID.
+	// +optional
+	Operations []string `json:"operations,omitempty"`
+	// Headers sent with every request, e.g. to authenticate to the API.
+	// +optional
+	HeadersFrom []ValueRef `json:"headersFrom,omitempty"`
+}
+
+// OpenAPISource defines where an OpenAPI document is loaded from
+// +kubebuilder:validation:XValidation:rule="(has(self.url) && !has(self.valueFrom)) || (!has(self.url) && has(self.valueFrom))",message="Exactly one of url or valueFrom must be specified"
+type OpenAPISource struct {
+	// The URL the document is downloaded from.
+	// +optional
+	URL string `json:"url,omitempty"`
+	// The key of a ConfigMap or Secret containing the document.
+	// +optional
+	ValueFrom *ValueSource `json:"valueFrom,omitempty"`
+}
+
+// HttpToolSetStatus defines the observed state of HttpToolSet.
+type HttpToolSetStatus struct {
+	ObservedGeneration int64              `json:"observedGeneration"`
+	Conditions         []metav1.Condition `json:"conditions"`
+	// The names of the tools generated from the operations of the document.
+	// +optional
+	Tools []string `json:"tools,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=hts
+// +kubebuilder:printcolumn:name="Resolved",type="string",JSONPath=".status.conditions[?(@.type=='Resolved')].status",description="Whether or not the OpenAPI document could be loaded."
+
+// HttpToolSet is the Schema for the httptoolsets API.
+type HttpToolSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HttpToolSetSpec   `json:"spec,omitempty"`
+	Status HttpToolSetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// HttpToolSetList contains a list of HttpToolSet.
+type HttpToolSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HttpToolSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&HttpToolSet{}, &HttpToolSetList{})
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// RemoteAgentConditionTypeReady reports whether the agent card of the remote agent could be fetched the last time it was refreshed
+	RemoteAgentConditionTypeReady = "Ready"
+)
+
+// RemoteAgentSpec defines the desired state of RemoteAgent.
+type RemoteAgentSpec struct {
+	// The description of the agent used by the agents delegating tasks to it.
+	// If not specified, the description of the agent card is used.
+	// +optional
+	Description string `json:"description,omitempty"`
+	// The base URL of the A2A server of the agent.
+	// The agent card is fetched from <url>/.well-known/agent.json.
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url"`
+	// Headers sent with every request to the agent, e.g. to authenticate to it.
+	// +optional
+	HeadersFrom []ValueRef `json:"headersFrom,omitempty"`
+	// How often the agent card is fetched again, which also checks the health of the agent.
+	// If not specified, the default value is 5m.
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+// RemoteAgentCard is the part of the A2A agent card of a remote agent used by kagent
+type RemoteAgentCard struct {
+	Name string `json:"name"`
+	// +optional
+	Description string `json:"description,omitempty"`
+	// The URL the tasks are sent to.
+	URL string `json:"url"`
+	// +optional
+	Version string `json:"version,omitempty"`
+	// +optional
+	Skills []AgentSkill `json:"skills,omitempty"`
+}
+
+// RemoteAgentStatus defines the observed state of RemoteAgent.
+type RemoteAgentStatus struct {
+	ObservedGeneration int64              `json:"observedGeneration"`
+	Conditions         []metav1.Condition `json:"conditions"`
+	// The agent card fetched from the agent the last time it could be reached.
+	// +optional
+	AgentCard *RemoteAgentCard `json:"agentCard,omitempty"`
+	// The last time the agent card was fetched successfully.
+	// +optional
+	LastFetchTime *metav1.Time `json:"lastFetchTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=ra
+// +kubebuilder:printcolumn:name="URL",type="string",JSONPath=".spec.url",description="The URL of the A2A server of the agent."
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="Whether or not the agent card could be fetched."
+// +kubebuilder:printcolumn:name="LastFetch",type="date",JSONPath=".status.lastFetchTime",description="The last time the agent card was fetched successfully."
+
+// RemoteAgent is the Schema for the remoteagents API.
+type RemoteAgent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RemoteAgentSpec   `json:"spec,omitempty"`
+	Status RemoteAgentStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RemoteAgentList contains a list of RemoteAgent.
+type RemoteAgentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RemoteAgent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RemoteAgent{}, &RemoteAgentList{})
+}
@@ -20,10 +20,24 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// ToolServerConditionTypeConnected reports whether the tool server could be reached
+	ToolServerConditionTypeConnected = "Connected"
+	// ToolServerConditionTypeToolsDiscovered reports whether the tools provided by the tool server could be discovered
+	ToolServerConditionTypeToolsDiscovered = "ToolsDiscovered"
+	// ToolServerConditionTypeDrifted reports whether the autogen tool server was changed outside of kagent and repaired
+	ToolServerConditionTypeDrifted = "Drifted"
+)
+
 // ToolServerSpec defines the desired state of ToolServer.
 type ToolServerSpec struct {
 	Description string           `json:"description"`
 	Config      ToolServerConfig `json:"config"`
+	// How often the tools provided by the server are discovered again.
+	// Failed discoveries are retried with an exponential backoff.
+	// If not specified, the default value is 60s.
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
 }
 
 type ToolServerConfig struct {
@@ -75,6 +89,54 @@ type SseMcpServerConfig struct {
 	HeadersFrom    []ValueRef         `json:"headersFrom,omitempty"`
 	Timeout        string             `json:"timeout,omitempty"`
 	SseReadTimeout string             `json:"sse_read_timeout,omitempty"`
+	// How to authenticate to the server, in addition to any headers.
+	// +optional
+	Auth *ToolServerAuth `json:"auth,omitempty"`
+}
+
+// ToolServerAuth defines how to authenticate to a remote tool server
+type ToolServerAuth struct {
+	// Obtains an access token using the OAuth2 client credentials flow and sends it as a bearer token.
+	// The token is refreshed automatically before it expires.
+	// +optional
+	OAuth2 *OAuth2ClientCredentials `json:"oauth2,omitempty"`
+	// Presents a client certificate to the server.
+	// +optional
+	TLS *ToolServerTLS `json:"tls,omitempty"`
+}
+
+type OAuth2ClientCredentials struct {
+	// The URL of the token endpoint of the authorization server.
+	TokenURL string `json:"tokenURL"`
+	// The source of the client ID.
+	ClientIDFrom ValueSource `json:"clientIDFrom"`
+	// The source of the client secret.
+	ClientSecretFrom ValueSource `json:"clientSecretFrom"`
+	// The scopes to request.
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+	// Additional parameters sent to the token endpoint, such as the audience.
+	// +optional
+	EndpointParams map[string]string `json:"endpointParams,omitempty"`
+}
+
+type ToolServerTLS struct {
+	// The reference to the Secret containing the client certificate. Can either be a reference to a resource in the same namespace,
+	// or a reference to a resource in a different namespace in the form "namespace/name".
+	SecretRef string `json:"secretRef"`
+	// The key of the client certificate in the Secret.
+	// +optional
+	// +kubebuilder:default=tls.crt
+	CertKey string `json:"certKey,omitempty"`
+	// The key of the client private key in the Secret.
+	// +optional
+	// +kubebuilder:default=tls.key
+	KeyKey string `json:"keyKey,omitempty"`
+	// The key of the CA certificate used to verify the server in the Secret.
+	// If the key is not present in the Secret, the system CAs are used.
+	// +optional
+	// +kubebuilder:default=ca.crt
+	CAKey string `json:"caKey,omitempty"`
 }
 
 // ToolServerStatus defines the observed state of ToolServer.
@@ -85,6 +147,24 @@ type ToolServerStatus struct {
 	Conditions         []metav1.Condition `json:"conditions"`
 	// +kubebuilder:validation:Optional
 	DiscoveredTools []*MCPTool `json:"discoveredTools"`
+	// +kubebuilder:validation:Optional
+	DiscoveredResources []*MCPResource `json:"discoveredResources,omitempty"`
+	// +kubebuilder:validation:Optional
+	DiscoveredPrompts []*MCPPrompt `json:"discoveredPrompts,omitempty"`
+	// The time the tool server was last connected, after it was created or unreachable.
+	// +optional
+	LastConnectedTime *metav1.Time `json:"lastConnectedTime,omitempty"`
+	// The time the tools, resources or prompts provided by the tool server last changed, or were discovered
+	// after a failure.
+	// +optional
+	LastDiscoveryTime *metav1.Time `json:"lastDiscoveryTime,omitempty"`
+	// The number of discoveries that failed since the last successful one, used to back off retries.
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+	// The id of the tool server in autogen, used to delete it, as tool servers of other namespaces
+	// may have the same label.
+	// +optional
+	ServerID int `json:"serverID,omitempty"`
 }
 
 type MCPTool struct {
@@ -92,6 +172,33 @@ type MCPTool struct {
 	Component Component `json:"component"`
 }
 
+// MCPResource is a resource exposed by an MCP server
+type MCPResource struct {
+	URI  string `json:"uri"`
+	Name string `json:"name"`
+	// +optional
+	Description string `json:"description,omitempty"`
+	// +optional
+	MimeType string `json:"mimeType,omitempty"`
+}
+
+// MCPPrompt is a prompt template exposed by an MCP server
+type MCPPrompt struct {
+	Name string `json:"name"`
+	// +optional
+	Description string `json:"description,omitempty"`
+	// +optional
+	Arguments []MCPPromptArgument `json:"arguments,omitempty"`
+}
+
+type MCPPromptArgument struct {
+	Name string `json:"name"`
+	// +optional
+	Description string `json:"description,omitempty"`
+	// +optional
+	Required bool `json:"required,omitempty"`
+}
+
 type Component struct {
 	Provider         string `json:"provider"`
 	ComponentType    string `json:"component_type"`
@@ -113,6 +220,9 @@ type MCPToolServerParams struct {
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:shortName=ts
+// +kubebuilder:printcolumn:name="Connected",type="string",JSONPath=".status.conditions[?(@.type=='Connected')].status",description="Whether or not the tool server could be reached."
+// +kubebuilder:printcolumn:name="ToolsDiscovered",type="string",JSONPath=".status.conditions[?(@.type=='ToolsDiscovered')].status",description="Whether or not the tools provided by the tool server could be discovered."
+// +kubebuilder:printcolumn:name="LastDiscovery",type="date",JSONPath=".status.lastDiscoveryTime",description="The last time the tools were discovered successfully."
 
 // ToolServer is the Schema for the toolservers API.
 type ToolServer struct {
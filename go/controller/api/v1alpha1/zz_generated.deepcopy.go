@@ -171,6 +171,22 @@ func (in *AgentSpec) DeepCopyInto(out *AgentSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.McpContext != nil {
+		in, out := &in.McpContext, &out.McpContext
+		*out = make([]*McpServerContext, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(McpServerContext)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.MaxToolDepth != nil {
+		in, out := &in.MaxToolDepth, &out.MaxToolDepth
+		*out = new(int32)
+		**out = **in
+	}
 	if in.A2AConfig != nil {
 		in, out := &in.A2AConfig, &out.A2AConfig
 		*out = new(A2AConfig)
@@ -198,6 +214,21 @@ func (in *AgentStatus) DeepCopyInto(out *AgentStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ResolvedTools != nil {
+		in, out := &in.ResolvedTools, &out.ResolvedTools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.References != nil {
+		in, out := &in.References, &out.References
+		*out = make([]ObservedReference, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentStatus.
@@ -376,6 +407,190 @@ func (in *GeminiVertexAIConfig) DeepCopy() *GeminiVertexAIConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HttpTool) DeepCopyInto(out *HttpTool) {
+	*out = *in
+	if in.Operations != nil {
+		in, out := &in.Operations, &out.Operations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HttpTool.
+func (in *HttpTool) DeepCopy() *HttpTool {
+	if in == nil {
+		return nil
+	}
+	out := new(HttpTool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HttpToolSet) DeepCopyInto(out *HttpToolSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HttpToolSet.
+func (in *HttpToolSet) DeepCopy() *HttpToolSet {
+	if in == nil {
+		return nil
+	}
+	out := new(HttpToolSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HttpToolSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HttpToolSetList) DeepCopyInto(out *HttpToolSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HttpToolSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HttpToolSetList.
+func (in *HttpToolSetList) DeepCopy() *HttpToolSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(HttpToolSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HttpToolSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HttpToolSetSpec) DeepCopyInto(out *HttpToolSetSpec) {
+	*out = *in
+	in.OpenAPI.DeepCopyInto(&out.OpenAPI)
+	if in.Operations != nil {
+		in, out := &in.Operations, &out.Operations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HeadersFrom != nil {
+		in, out := &in.HeadersFrom, &out.HeadersFrom
+		*out = make([]ValueRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HttpToolSetSpec.
+func (in *HttpToolSetSpec) DeepCopy() *HttpToolSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HttpToolSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HttpToolSetStatus) DeepCopyInto(out *HttpToolSetStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Tools != nil {
+		in, out := &in.Tools, &out.Tools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HttpToolSetStatus.
+func (in *HttpToolSetStatus) DeepCopy() *HttpToolSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HttpToolSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPPrompt) DeepCopyInto(out *MCPPrompt) {
+	*out = *in
+	if in.Arguments != nil {
+		in, out := &in.Arguments, &out.Arguments
+		*out = make([]MCPPromptArgument, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPPrompt.
+func (in *MCPPrompt) DeepCopy() *MCPPrompt {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPPrompt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPPromptArgument) DeepCopyInto(out *MCPPromptArgument) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPPromptArgument.
+func (in *MCPPromptArgument) DeepCopy() *MCPPromptArgument {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPPromptArgument)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPResource) DeepCopyInto(out *MCPResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPResource.
+func (in *MCPResource) DeepCopy() *MCPResource {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MCPTool) DeepCopyInto(out *MCPTool) {
 	*out = *in
@@ -447,6 +662,55 @@ func (in *MaxMessageTermination) DeepCopy() *MaxMessageTermination {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *McpPromptRef) DeepCopyInto(out *McpPromptRef) {
+	*out = *in
+	if in.Arguments != nil {
+		in, out := &in.Arguments, &out.Arguments
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new McpPromptRef.
+func (in *McpPromptRef) DeepCopy() *McpPromptRef {
+	if in == nil {
+		return nil
+	}
+	out := new(McpPromptRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *McpServerContext) DeepCopyInto(out *McpServerContext) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Prompts != nil {
+		in, out := &in.Prompts, &out.Prompts
+		*out = make([]McpPromptRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new McpServerContext.
+func (in *McpServerContext) DeepCopy() *McpServerContext {
+	if in == nil {
+		return nil
+	}
+	out := new(McpServerContext)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *McpServerTool) DeepCopyInto(out *McpServerTool) {
 	*out = *in
@@ -455,6 +719,23 @@ func (in *McpServerTool) DeepCopyInto(out *McpServerTool) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.IncludePatterns != nil {
+		in, out := &in.IncludePatterns, &out.IncludePatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludePatterns != nil {
+		in, out := &in.ExcludePatterns, &out.ExcludePatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DescriptionOverrides != nil {
+		in, out := &in.DescriptionOverrides, &out.DescriptionOverrides
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new McpServerTool.
@@ -721,6 +1002,50 @@ func (in *ModelInfo) DeepCopy() *ModelInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuth2ClientCredentials) DeepCopyInto(out *OAuth2ClientCredentials) {
+	*out = *in
+	out.ClientIDFrom = in.ClientIDFrom
+	out.ClientSecretFrom = in.ClientSecretFrom
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EndpointParams != nil {
+		in, out := &in.EndpointParams, &out.EndpointParams
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuth2ClientCredentials.
+func (in *OAuth2ClientCredentials) DeepCopy() *OAuth2ClientCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuth2ClientCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObservedReference) DeepCopyInto(out *ObservedReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObservedReference.
+func (in *ObservedReference) DeepCopy() *ObservedReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ObservedReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OllamaConfig) DeepCopyInto(out *OllamaConfig) {
 	*out = *in
@@ -773,6 +1098,26 @@ func (in *OpenAIConfig) DeepCopy() *OpenAIConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAPISource) DeepCopyInto(out *OpenAPISource) {
+	*out = *in
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(ValueSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPISource.
+func (in *OpenAPISource) DeepCopy() *OpenAPISource {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAPISource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OrTermination) DeepCopyInto(out *OrTermination) {
 	*out = *in
@@ -840,6 +1185,160 @@ func (in *PineconeConfig) DeepCopy() *PineconeConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteAgent) DeepCopyInto(out *RemoteAgent) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteAgent.
+func (in *RemoteAgent) DeepCopy() *RemoteAgent {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteAgent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteAgent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteAgentCard) DeepCopyInto(out *RemoteAgentCard) {
+	*out = *in
+	if in.Skills != nil {
+		in, out := &in.Skills, &out.Skills
+		*out = make([]AgentSkill, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteAgentCard.
+func (in *RemoteAgentCard) DeepCopy() *RemoteAgentCard {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteAgentCard)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteAgentList) DeepCopyInto(out *RemoteAgentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RemoteAgent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteAgentList.
+func (in *RemoteAgentList) DeepCopy() *RemoteAgentList {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteAgentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteAgentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteAgentSpec) DeepCopyInto(out *RemoteAgentSpec) {
+	*out = *in
+	if in.HeadersFrom != nil {
+		in, out := &in.HeadersFrom, &out.HeadersFrom
+		*out = make([]ValueRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteAgentSpec.
+func (in *RemoteAgentSpec) DeepCopy() *RemoteAgentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteAgentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteAgentStatus) DeepCopyInto(out *RemoteAgentStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AgentCard != nil {
+		in, out := &in.AgentCard, &out.AgentCard
+		*out = new(RemoteAgentCard)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastFetchTime != nil {
+		in, out := &in.LastFetchTime, &out.LastFetchTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteAgentStatus.
+func (in *RemoteAgentStatus) DeepCopy() *RemoteAgentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteAgentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteAgentTool) DeepCopyInto(out *RemoteAgentTool) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteAgentTool.
+func (in *RemoteAgentTool) DeepCopy() *RemoteAgentTool {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteAgentTool)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoundRobinTeamConfig) DeepCopyInto(out *RoundRobinTeamConfig) {
 	*out = *in
@@ -887,6 +1386,11 @@ func (in *SseMcpServerConfig) DeepCopyInto(out *SseMcpServerConfig) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(ToolServerAuth)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SseMcpServerConfig.
@@ -1073,6 +1577,11 @@ func (in *TeamStatus) DeepCopyInto(out *TeamStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.References != nil {
+		in, out := &in.References, &out.References
+		*out = make([]ObservedReference, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamStatus.
@@ -1173,6 +1682,16 @@ func (in *Tool) DeepCopyInto(out *Tool) {
 		*out = new(AgentTool)
 		**out = **in
 	}
+	if in.Http != nil {
+		in, out := &in.Http, &out.Http
+		*out = new(HttpTool)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemoteAgent != nil {
+		in, out := &in.RemoteAgent, &out.RemoteAgent
+		*out = new(RemoteAgentTool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tool.
@@ -1212,6 +1731,31 @@ func (in *ToolServer) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolServerAuth) DeepCopyInto(out *ToolServerAuth) {
+	*out = *in
+	if in.OAuth2 != nil {
+		in, out := &in.OAuth2, &out.OAuth2
+		*out = new(OAuth2ClientCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ToolServerTLS)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolServerAuth.
+func (in *ToolServerAuth) DeepCopy() *ToolServerAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolServerAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ToolServerConfig) DeepCopyInto(out *ToolServerConfig) {
 	*out = *in
@@ -1273,6 +1817,11 @@ func (in *ToolServerList) DeepCopyObject() runtime.Object {
 func (in *ToolServerSpec) DeepCopyInto(out *ToolServerSpec) {
 	*out = *in
 	in.Config.DeepCopyInto(&out.Config)
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolServerSpec.
@@ -1306,6 +1855,36 @@ func (in *ToolServerStatus) DeepCopyInto(out *ToolServerStatus) {
 			}
 		}
 	}
+	if in.DiscoveredResources != nil {
+		in, out := &in.DiscoveredResources, &out.DiscoveredResources
+		*out = make([]*MCPResource, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(MCPResource)
+				**out = **in
+			}
+		}
+	}
+	if in.DiscoveredPrompts != nil {
+		in, out := &in.DiscoveredPrompts, &out.DiscoveredPrompts
+		*out = make([]*MCPPrompt, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(MCPPrompt)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.LastConnectedTime != nil {
+		in, out := &in.LastConnectedTime, &out.LastConnectedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastDiscoveryTime != nil {
+		in, out := &in.LastDiscoveryTime, &out.LastDiscoveryTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolServerStatus.
@@ -1318,6 +1897,21 @@ func (in *ToolServerStatus) DeepCopy() *ToolServerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolServerTLS) DeepCopyInto(out *ToolServerTLS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolServerTLS.
+func (in *ToolServerTLS) DeepCopy() *ToolServerTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolServerTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValueRef) DeepCopyInto(out *ValueRef) {
 	*out = *in
@@ -0,0 +1,274 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+)
+
+var _ conversion.Convertible = &Agent{}
+
+// ConvertTo converts this Agent to the hub version (v1alpha1).
+func (src *Agent) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha1.Agent)
+	if !ok {
+		return fmt.Errorf("expected a v1alpha1 Agent but got %T", dstRaw)
+	}
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = v1alpha1.AgentSpec{
+		Description:        src.Spec.Description,
+		SystemMessage:      src.Spec.SystemMessage,
+		ModelConfig:        optionalHubReference(src.Spec.ModelConfig),
+		Stream:             src.Spec.Stream,
+		Memory:             hubReferences(src.Spec.Memory),
+		ServiceAccountName: src.Spec.ServiceAccountName,
+		Mode:               v1alpha1.AgentMode(src.Spec.Mode),
+		MaxToolDepth:       src.Spec.MaxToolDepth,
+	}
+	if src.Spec.Tools != nil {
+		dst.Spec.Tools = make([]*v1alpha1.Tool, len(src.Spec.Tools))
+		for i := range src.Spec.Tools {
+			dst.Spec.Tools[i] = hubTool(&src.Spec.Tools[i])
+		}
+	}
+	if src.Spec.McpContext != nil {
+		dst.Spec.McpContext = make([]*v1alpha1.McpServerContext, len(src.Spec.McpContext))
+		for i, mcpContext := range src.Spec.McpContext {
+			dst.Spec.McpContext[i] = &v1alpha1.McpServerContext{
+				ToolServer: hubReference(mcpContext.ToolServer),
+				Resources:  mcpContext.Resources,
+				Prompts:    hubPromptRefs(mcpContext.Prompts),
+			}
+		}
+	}
+	if src.Spec.A2AConfig != nil {
+		dst.Spec.A2AConfig = &v1alpha1.A2AConfig{}
+		if src.Spec.A2AConfig.Skills != nil {
+			dst.Spec.A2AConfig.Skills = make([]v1alpha1.AgentSkill, len(src.Spec.A2AConfig.Skills))
+			for i, skill := range src.Spec.A2AConfig.Skills {
+				dst.Spec.A2AConfig.Skills[i] = v1alpha1.AgentSkill(skill)
+			}
+		}
+	}
+	dst.Status = v1alpha1.AgentStatus{
+		ObservedGeneration: src.Status.ObservedGeneration,
+		Conditions:         src.Status.Conditions,
+		ResolvedTools:      src.Status.ResolvedTools,
+		ComponentHash:      src.Status.ComponentHash,
+		ModelConfig:        src.Status.ModelConfig,
+		Memory:             src.Status.Memory,
+		TeamID:             src.Status.TeamID,
+		A2AURL:             src.Status.A2AURL,
+		References:         hubObservedReferences(src.Status.References),
+	}
+	return nil
+}
+
+// ConvertFrom converts the hub version (v1alpha1) to this Agent.
+func (dst *Agent) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha1.Agent)
+	if !ok {
+		return fmt.Errorf("expected a v1alpha1 Agent but got %T", srcRaw)
+	}
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = AgentSpec{
+		Description:        src.Spec.Description,
+		SystemMessage:      src.Spec.SystemMessage,
+		ModelConfig:        optionalReferenceFromHub(ModelConfigKind, src.Spec.ModelConfig),
+		Stream:             src.Spec.Stream,
+		Memory:             referencesFromHub(MemoryKind, src.Spec.Memory),
+		ServiceAccountName: src.Spec.ServiceAccountName,
+		Mode:               AgentMode(src.Spec.Mode),
+		MaxToolDepth:       src.Spec.MaxToolDepth,
+	}
+	if src.Spec.Tools != nil {
+		dst.Spec.Tools = make([]Tool, len(src.Spec.Tools))
+		for i, tool := range src.Spec.Tools {
+			if tool != nil {
+				dst.Spec.Tools[i] = toolFromHub(tool)
+			}
+		}
+	}
+	if src.Spec.McpContext != nil {
+		dst.Spec.McpContext = make([]McpServerContext, len(src.Spec.McpContext))
+		for i, mcpContext := range src.Spec.McpContext {
+			if mcpContext == nil {
+				continue
+			}
+			dst.Spec.McpContext[i] = McpServerContext{
+				ToolServer: referenceFromHub(ToolServerKind, mcpContext.ToolServer),
+				Resources:  mcpContext.Resources,
+				Prompts:    promptRefsFromHub(mcpContext.Prompts),
+			}
+		}
+	}
+	if src.Spec.A2AConfig != nil {
+		dst.Spec.A2AConfig = &A2AConfig{}
+		if src.Spec.A2AConfig.Skills != nil {
+			dst.Spec.A2AConfig.Skills = make([]AgentSkill, len(src.Spec.A2AConfig.Skills))
+			for i, skill := range src.Spec.A2AConfig.Skills {
+				dst.Spec.A2AConfig.Skills[i] = AgentSkill(skill)
+			}
+		}
+	}
+	dst.Status = AgentStatus{
+		ObservedGeneration: src.Status.ObservedGeneration,
+		Conditions:         src.Status.Conditions,
+		ResolvedTools:      src.Status.ResolvedTools,
+		ComponentHash:      src.Status.ComponentHash,
+		ModelConfig:        src.Status.ModelConfig,
+		Memory:             src.Status.Memory,
+		TeamID:             src.Status.TeamID,
+		A2AURL:             src.Status.A2AURL,
+		References:         observedReferencesFromHub(src.Status.References),
+	}
+	return nil
+}
+
+// hubTool converts a tool to v1alpha1, where the agent tools referencing a RemoteAgent are RemoteAgent tools
+func hubTool(src *Tool) *v1alpha1.Tool {
+	dst := &v1alpha1.Tool{}
+	switch src.Type {
+	case ToolType_Builtin:
+		dst.Type = v1alpha1.ToolProviderType_Builtin
+		if src.Builtin != nil {
+			dst.Builtin = &v1alpha1.BuiltinTool{Name: src.Builtin.Name}
+			if src.Builtin.Config != nil {
+				dst.Builtin.Config = make(map[string]v1alpha1.AnyType, len(src.Builtin.Config))
+				for key, value := range src.Builtin.Config {
+					dst.Builtin.Config[key] = v1alpha1.AnyType{RawMessage: value.RawMessage}
+				}
+			}
+		}
+	case ToolType_McpServer:
+		dst.Type = v1alpha1.ToolProviderType_McpServer
+		if src.McpServer != nil {
+			dst.McpServer = &v1alpha1.McpServerTool{
+				ToolServer:           hubReference(src.McpServer.ToolServer),
+				ToolNames:            src.McpServer.ToolNames,
+				IncludePatterns:      src.McpServer.IncludePatterns,
+				ExcludePatterns:      src.McpServer.ExcludePatterns,
+				PatternType:          v1alpha1.ToolPatternType(src.McpServer.PatternType),
+				NamePrefix:           src.McpServer.NamePrefix,
+				DescriptionOverrides: src.McpServer.DescriptionOverrides,
+			}
+		}
+	case ToolType_Agent:
+		switch {
+		case src.Agent != nil && src.Agent.Ref.Kind == RemoteAgentKind:
+			dst.Type = v1alpha1.ToolProviderType_RemoteAgent
+			dst.RemoteAgent = &v1alpha1.RemoteAgentTool{Ref: hubReference(src.Agent.Ref)}
+		case src.Agent != nil:
+			dst.Type = v1alpha1.ToolProviderType_Agent
+			dst.Agent = &v1alpha1.AgentTool{Ref: hubReference(src.Agent.Ref)}
+		default:
+			dst.Type = v1alpha1.ToolProviderType_Agent
+		}
+	case ToolType_Http:
+		dst.Type = v1alpha1.ToolProviderType_Http
+		if src.Http != nil {
+			dst.Http = &v1alpha1.HttpTool{
+				ToolSet:    hubReference(src.Http.ToolSet),
+				Operations: src.Http.Operations,
+			}
+		}
+	}
+	if src.Approval != nil {
+		dst.RequireApproval = true
+		dst.ApprovalTimeout = src.Approval.Timeout
+	}
+	return dst
+}
+
+// toolFromHub converts a v1alpha1 tool by its type, the RemoteAgent tools being agent tools referencing a RemoteAgent
+func toolFromHub(src *v1alpha1.Tool) Tool {
+	dst := Tool{}
+	switch src.Type {
+	case v1alpha1.ToolProviderType_Builtin:
+		dst.Type = ToolType_Builtin
+		if src.Builtin != nil {
+			dst.Builtin = &BuiltinTool{Name: src.Builtin.Name}
+			if src.Builtin.Config != nil {
+				dst.Builtin.Config = make(map[string]AnyType, len(src.Builtin.Config))
+				for key, value := range src.Builtin.Config {
+					dst.Builtin.Config[key] = AnyType{RawMessage: value.RawMessage}
+				}
+			}
+		}
+	case v1alpha1.ToolProviderType_McpServer:
+		dst.Type = ToolType_McpServer
+		if src.McpServer != nil {
+			dst.McpServer = &McpServerTool{
+				ToolServer:           referenceFromHub(ToolServerKind, src.McpServer.ToolServer),
+				ToolNames:            src.McpServer.ToolNames,
+				IncludePatterns:      src.McpServer.IncludePatterns,
+				ExcludePatterns:      src.McpServer.ExcludePatterns,
+				PatternType:          ToolPatternType(src.McpServer.PatternType),
+				NamePrefix:           src.McpServer.NamePrefix,
+				DescriptionOverrides: src.McpServer.DescriptionOverrides,
+			}
+		}
+	case v1alpha1.ToolProviderType_Agent:
+		dst.Type = ToolType_Agent
+		if src.Agent != nil {
+			dst.Agent = &AgentTool{Ref: referenceFromHub(AgentKind, src.Agent.Ref)}
+		}
+	case v1alpha1.ToolProviderType_RemoteAgent:
+		dst.Type = ToolType_Agent
+		if src.RemoteAgent != nil {
+			dst.Agent = &AgentTool{Ref: referenceFromHub(RemoteAgentKind, src.RemoteAgent.Ref)}
+		}
+	case v1alpha1.ToolProviderType_Http:
+		dst.Type = ToolType_Http
+		if src.Http != nil {
+			dst.Http = &HttpTool{
+				ToolSet:    referenceFromHub(HttpToolSetKind, src.Http.ToolSet),
+				Operations: src.Http.Operations,
+			}
+		}
+	}
+	if src.RequireApproval {
+		dst.Approval = &ToolApproval{Timeout: src.ApprovalTimeout}
+	}
+	return dst
+}
+
+func hubPromptRefs(prompts []McpPromptRef) []v1alpha1.McpPromptRef {
+	if prompts == nil {
+		return nil
+	}
+	hubPrompts := make([]v1alpha1.McpPromptRef, len(prompts))
+	for i, prompt := range prompts {
+		hubPrompts[i] = v1alpha1.McpPromptRef(prompt)
+	}
+	return hubPrompts
+}
+
+func promptRefsFromHub(prompts []v1alpha1.McpPromptRef) []McpPromptRef {
+	if prompts == nil {
+		return nil
+	}
+	typedPrompts := make([]McpPromptRef, len(prompts))
+	for i, prompt := range prompts {
+		typedPrompts[i] = McpPromptRef(prompt)
+	}
+	return typedPrompts
+}
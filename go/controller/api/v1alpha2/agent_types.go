@@ -0,0 +1,290 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"trpc.group/trpc-go/trpc-a2a-go/server"
+)
+
+// AgentSpec defines the desired state of Agent.
+type AgentSpec struct {
+	// +optional
+	Description string `json:"description,omitempty"`
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	SystemMessage string `json:"systemMessage,omitempty"`
+	// The ModelConfig used by the agent. If not specified, the default model config is used.
+	// +optional
+	// +kubebuilder:validation:XValidation:message="modelConfig must reference a ModelConfig",rule="self.kind == 'ModelConfig'"
+	ModelConfig *TypedReference `json:"modelConfig,omitempty"`
+	// Whether to stream the response from the model.
+	// If not specified, the default value is true.
+	// +optional
+	Stream *bool `json:"stream,omitempty"`
+	// +optional
+	// +kubebuilder:validation:MaxItems=20
+	Tools []Tool `json:"tools,omitempty"`
+	// The Memory resources queried for context before the agent answers.
+	// +optional
+	// +kubebuilder:validation:MaxItems=20
+	// +kubebuilder:validation:XValidation:message="memory must reference Memory resources",rule="self.all(m, m.kind == 'Memory')"
+	Memory []TypedReference `json:"memory,omitempty"`
+	// Resources and prompts discovered on ToolServers which are provided to the agent.
+	// Resources are added to the context of the agent, prompts are added as system message fragments.
+	// +optional
+	// +kubebuilder:validation:MaxItems=20
+	McpContext []McpServerContext `json:"mcpContext,omitempty"`
+	// The name of a ServiceAccount in the namespace of the agent.
+	// If specified, the builtin tools which access the cluster use a token of this ServiceAccount,
+	// so the agent is constrained by its RBAC instead of the permissions of kagent.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// Whether the agent may call the tools which change the state of the systems they access.
+	// readOnly removes the mutating tools, dryRun keeps them but only reports the calls instead of running them.
+	// +optional
+	// +kubebuilder:default=readWrite
+	Mode AgentMode `json:"mode,omitempty"`
+	// How deeply agents may be nested as tools below this agent.
+	// If not specified, the limit configured on the controller is used.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxToolDepth *int32 `json:"maxToolDepth,omitempty"`
+	// A2AConfig instantiates an A2A server for this agent, served on the HTTP port of the kagent controller
+	// at /api/a2a/<agent-namespace>/<agent-name>.
+	// +optional
+	A2AConfig *A2AConfig `json:"a2aConfig,omitempty"`
+}
+
+// AgentMode restricts the tools an agent may call
+// +kubebuilder:validation:Enum=readWrite;readOnly;dryRun
+type AgentMode string
+
+const (
+	AgentMode_ReadWrite AgentMode = "readWrite"
+	AgentMode_ReadOnly  AgentMode = "readOnly"
+	AgentMode_DryRun    AgentMode = "dryRun"
+)
+
+// ToolType is the discriminator of a tool, naming the field which configures it
+// +kubebuilder:validation:Enum=Builtin;McpServer;Agent;Http
+type ToolType string
+
+const (
+	ToolType_Builtin   ToolType = "Builtin"
+	ToolType_McpServer ToolType = "McpServer"
+	ToolType_Agent     ToolType = "Agent"
+	ToolType_Http      ToolType = "Http"
+)
+
+// Tool is a tool provided to an agent. Exactly the field named by the type is set.
+// +union
+// +kubebuilder:validation:XValidation:message="builtin must be set if and only if the type is Builtin",rule="has(self.builtin) == (self.type == 'Builtin')"
+// +kubebuilder:validation:XValidation:message="mcpServer must be set if and only if the type is McpServer",rule="has(self.mcpServer) == (self.type == 'McpServer')"
+// +kubebuilder:validation:XValidation:message="agent must be set if and only if the type is Agent",rule="has(self.agent) == (self.type == 'Agent')"
+// +kubebuilder:validation:XValidation:message="http must be set if and only if the type is Http",rule="has(self.http) == (self.type == 'Http')"
+// +kubebuilder:validation:XValidation:message="approval is not supported for Agent tools referencing an Agent",rule="!has(self.approval) || !has(self.agent) || self.agent.ref.kind != 'Agent'"
+type Tool struct {
+	// +unionDiscriminator
+	Type ToolType `json:"type"`
+	// +optional
+	Builtin *BuiltinTool `json:"builtin,omitempty"`
+	// +optional
+	McpServer *McpServerTool `json:"mcpServer,omitempty"`
+	// +optional
+	Agent *AgentTool `json:"agent,omitempty"`
+	// +optional
+	Http *HttpTool `json:"http,omitempty"`
+	// If set, every call of the tool must be approved by a human before it runs.
+	// Pending calls are listed on /api/approvals, and fail if they are denied or not approved in time.
+	// +optional
+	Approval *ToolApproval `json:"approval,omitempty"`
+}
+
+type ToolApproval struct {
+	// How long a call waits for an approval, as a duration string, e.g. 5m. Defaults to 10m.
+	// +optional
+	Timeout string `json:"timeout,omitempty"`
+}
+
+type AgentTool struct {
+	// The Agent used as a tool, or the RemoteAgent the tasks are delegated to over A2A.
+	// +kubebuilder:validation:XValidation:message="ref must reference an Agent or a RemoteAgent",rule="self.kind == 'Agent' || self.kind == 'RemoteAgent'"
+	Ref TypedReference `json:"ref"`
+}
+
+type HttpTool struct {
+	// The HttpToolSet that provides the tools.
+	// +kubebuilder:validation:XValidation:message="toolSet must reference an HttpToolSet",rule="self.kind == 'HttpToolSet'"
+	ToolSet TypedReference `json:"toolSet"`
+	// The operation IDs of the operations of the HttpToolSet to provide.
+	// If not specified, all the tools of the HttpToolSet are provided.
+	// +optional
+	Operations []string `json:"operations,omitempty"`
+}
+
+type BuiltinTool struct {
+	// the name of the builtin tool
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// note: this implementation is due to the kubebuilder limitation https://github.com/kubernetes-sigs/controller-tools/issues/636
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +optional
+	Config map[string]AnyType `json:"config,omitempty"`
+}
+
+type McpServerTool struct {
+	// The ToolServer that provides the tools.
+	// +kubebuilder:validation:XValidation:message="toolServer must reference a ToolServer",rule="self.kind == 'ToolServer'"
+	ToolServer TypedReference `json:"toolServer"`
+	// The names of the tools to be provided by the ToolServer
+	// For a list of all the tools provided by the server,
+	// the client can query the status of the ToolServer object after it has been created
+	// +optional
+	ToolNames []string `json:"toolNames,omitempty"`
+	// Patterns matched against the names of the tools discovered on the ToolServer.
+	// Every discovered tool matching at least one pattern is provided in addition to the tools listed in toolNames.
+	// +optional
+	IncludePatterns []string `json:"includePatterns,omitempty"`
+	// Patterns matched against the names of the selected tools.
+	// Tools matching any of these patterns are removed, even if they are listed in toolNames.
+	// +optional
+	ExcludePatterns []string `json:"excludePatterns,omitempty"`
+	// The syntax used by includePatterns and excludePatterns.
+	// Regex patterns must match the whole tool name.
+	// +optional
+	// +kubebuilder:default=Glob
+	PatternType ToolPatternType `json:"patternType,omitempty"`
+	// A prefix added to the name of every tool provided by this reference, as seen by the model.
+	// +optional
+	NamePrefix string `json:"namePrefix,omitempty"`
+	// Descriptions to use instead of the ones advertised by the ToolServer, keyed by the discovered tool name.
+	// +optional
+	DescriptionOverrides map[string]string `json:"descriptionOverrides,omitempty"`
+}
+
+type McpServerContext struct {
+	// The ToolServer that provides the resources and prompts.
+	// +kubebuilder:validation:XValidation:message="toolServer must reference a ToolServer",rule="self.kind == 'ToolServer'"
+	ToolServer TypedReference `json:"toolServer"`
+	// The URIs of the resources to add to the context of the agent.
+	// +optional
+	Resources []string `json:"resources,omitempty"`
+	// The prompts to add to the system message of the agent, in order.
+	// +optional
+	Prompts []McpPromptRef `json:"prompts,omitempty"`
+}
+
+type McpPromptRef struct {
+	// The name of the prompt discovered on the ToolServer.
+	Name string `json:"name"`
+	// The arguments used to render the prompt.
+	// +optional
+	Arguments map[string]string `json:"arguments,omitempty"`
+}
+
+// ToolPatternType represents the syntax of the patterns used to select MCP tools
+// +kubebuilder:validation:Enum=Glob;Regex
+type ToolPatternType string
+
+const (
+	ToolPatternType_Glob  ToolPatternType = "Glob"
+	ToolPatternType_Regex ToolPatternType = "Regex"
+)
+
+type AnyType struct {
+	json.RawMessage `json:",inline"`
+}
+
+type A2AConfig struct {
+	// +kubebuilder:validation:MinItems=1
+	Skills []AgentSkill `json:"skills,omitempty"`
+}
+
+type AgentSkill server.AgentSkill
+
+// AgentStatus defines the observed state of Agent.
+type AgentStatus struct {
+	ObservedGeneration int64              `json:"observedGeneration,omitempty"`
+	Conditions         []metav1.Condition `json:"conditions,omitempty"`
+	// The names of the tools provided to the agent, after all tool references have been resolved.
+	// +optional
+	ResolvedTools []string `json:"resolvedTools,omitempty"`
+	// The hash of the autogen component last sent for the agent, which is only sent again once it changes.
+	// +optional
+	ComponentHash string `json:"componentHash,omitempty"`
+	// The namespace/name of the ModelConfig used by the agent, which is the default one unless the agent sets one.
+	// +optional
+	ModelConfig string `json:"modelConfig,omitempty"`
+	// The namespace/name of the Memory resources used by the agent.
+	// +optional
+	Memory []string `json:"memory,omitempty"`
+	// The ID of the team of the agent in autogen.
+	// +optional
+	TeamID int `json:"teamID,omitempty"`
+	// The URL the A2A server of the agent is advertised at, if A2A is enabled for the agent.
+	// +optional
+	A2AURL string `json:"a2aURL,omitempty"`
+	// The resources referenced by the agent, with the generation of each which was last translated.
+	// +optional
+	References []ObservedReference `json:"references,omitempty"`
+}
+
+// ObservedReference is a resource referenced by an agent or a team
+type ObservedReference struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// The generation of the resource which was last translated.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="Whether or not the agent can be used."
+// +kubebuilder:printcolumn:name="Accepted",type="string",JSONPath=".status.conditions[?(@.type=='Accepted')].status",description="Whether or not the agent has been accepted by the system."
+// +kubebuilder:printcolumn:name="ModelConfig",type="string",JSONPath=".status.modelConfig",description="The ModelConfig used by this agent."
+// +kubebuilder:printcolumn:name="Tools",type="string",JSONPath=".status.resolvedTools",priority=1,description="The tools provided to this agent."
+// +kubebuilder:printcolumn:name="A2A",type="string",JSONPath=".status.a2aURL",priority=1,description="The URL of the A2A server of this agent."
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Agent is the Schema for the agents API.
+type Agent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AgentSpec   `json:"spec,omitempty"`
+	Status AgentStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AgentList contains a list of Agent.
+type AgentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Agent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Agent{}, &AgentList{})
+}
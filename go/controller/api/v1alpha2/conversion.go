@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"strings"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+)
+
+// The v1alpha1 resources are converted to the union of the first member they set, as the resources setting
+// several members are rejected by the admission webhooks. The unions of v1alpha2 are converted by their type.
+
+// referenceFromHub converts a v1alpha1 reference of the form [namespace/]name to a reference of the kind
+func referenceFromHub(kind string, ref string) TypedReference {
+	if namespace, name, ok := strings.Cut(ref, "/"); ok && namespace != "" && !strings.Contains(name, "/") {
+		return TypedReference{Kind: kind, Namespace: namespace, Name: name}
+	}
+	return TypedReference{Kind: kind, Name: ref}
+}
+
+// optionalReferenceFromHub converts an optional v1alpha1 reference, which is empty if not set
+func optionalReferenceFromHub(kind string, ref string) *TypedReference {
+	if ref == "" {
+		return nil
+	}
+	typedRef := referenceFromHub(kind, ref)
+	return &typedRef
+}
+
+func referencesFromHub(kind string, refs []string) []TypedReference {
+	if refs == nil {
+		return nil
+	}
+	typedRefs := make([]TypedReference, len(refs))
+	for i, ref := range refs {
+		typedRefs[i] = referenceFromHub(kind, ref)
+	}
+	return typedRefs
+}
+
+// hubReference converts a reference to the [namespace/]name form of v1alpha1, where the kind is implied by the field
+func hubReference(ref TypedReference) string {
+	if ref.Namespace == "" {
+		return ref.Name
+	}
+	return ref.Namespace + "/" + ref.Name
+}
+
+func optionalHubReference(ref *TypedReference) string {
+	if ref == nil {
+		return ""
+	}
+	return hubReference(*ref)
+}
+
+func hubReferences(refs []TypedReference) []string {
+	if refs == nil {
+		return nil
+	}
+	hubRefs := make([]string, len(refs))
+	for i, ref := range refs {
+		hubRefs[i] = hubReference(ref)
+	}
+	return hubRefs
+}
+
+func observedReferencesFromHub(refs []v1alpha1.ObservedReference) []ObservedReference {
+	if refs == nil {
+		return nil
+	}
+	observedRefs := make([]ObservedReference, len(refs))
+	for i, ref := range refs {
+		observedRefs[i] = ObservedReference(ref)
+	}
+	return observedRefs
+}
+
+func hubObservedReferences(refs []ObservedReference) []v1alpha1.ObservedReference {
+	if refs == nil {
+		return nil
+	}
+	hubRefs := make([]v1alpha1.ObservedReference, len(refs))
+	for i, ref := range refs {
+		hubRefs[i] = v1alpha1.ObservedReference(ref)
+	}
+	return hubRefs
+}
@@ -0,0 +1,501 @@
+package v1alpha2_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+	"sigs.k8s.io/randfill"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha2"
+)
+
+const fuzzIterations = 1000
+
+// randomName returns a name valid for any kagent resource
+func randomName(c randfill.Continue) string {
+	name := make([]byte, 1+c.Intn(12))
+	for i := range name {
+		name[i] = byte('a' + c.Intn(26))
+	}
+	return string(name)
+}
+
+func randomReference(c randfill.Continue, kind string) v1alpha2.TypedReference {
+	ref := v1alpha2.TypedReference{Kind: kind, Name: randomName(c)}
+	if c.Bool() {
+		ref.Namespace = randomName(c)
+	}
+	return ref
+}
+
+func randomReferences(c randfill.Continue, kind string) []v1alpha2.TypedReference {
+	if c.Bool() {
+		return nil
+	}
+	refs := make([]v1alpha2.TypedReference, c.Intn(4))
+	for i := range refs {
+		refs[i] = randomReference(c, kind)
+	}
+	return refs
+}
+
+// randomHubReference returns a v1alpha1 reference of the form [namespace/]name
+func randomHubReference(c randfill.Continue) string {
+	if c.Bool() {
+		return randomName(c) + "/" + randomName(c)
+	}
+	return randomName(c)
+}
+
+func randomHubReferences(c randfill.Continue) []string {
+	if c.Bool() {
+		return nil
+	}
+	refs := make([]string, c.Intn(4))
+	for i := range refs {
+		refs[i] = randomHubReference(c)
+	}
+	return refs
+}
+
+// v1alpha2FillFuncs fill the resources admitted by the v1alpha2 schema: the unions set the member named
+// by their type, and the references are of the kinds accepted by their field.
+var v1alpha2FillFuncs = []interface{}{
+	func(spec *v1alpha2.AgentSpec, c randfill.Continue) {
+		c.FillNoCustom(spec)
+		spec.ModelConfig = nil
+		if c.Bool() {
+			ref := randomReference(c, v1alpha2.ModelConfigKind)
+			spec.ModelConfig = &ref
+		}
+		spec.Memory = randomReferences(c, v1alpha2.MemoryKind)
+	},
+	func(tool *v1alpha2.Tool, c randfill.Continue) {
+		*tool = v1alpha2.Tool{}
+		switch c.Intn(4) {
+		case 0:
+			tool.Type = v1alpha2.ToolType_Builtin
+			tool.Builtin = &v1alpha2.BuiltinTool{}
+			c.Fill(tool.Builtin)
+		case 1:
+			tool.Type = v1alpha2.ToolType_McpServer
+			tool.McpServer = &v1alpha2.McpServerTool{}
+			c.Fill(tool.McpServer)
+		case 2:
+			tool.Type = v1alpha2.ToolType_Agent
+			tool.Agent = &v1alpha2.AgentTool{}
+			c.Fill(tool.Agent)
+		case 3:
+			tool.Type = v1alpha2.ToolType_Http
+			tool.Http = &v1alpha2.HttpTool{}
+			c.Fill(tool.Http)
+		}
+		if c.Bool() && (tool.Agent == nil || tool.Agent.Ref.Kind == v1alpha2.RemoteAgentKind) {
+			tool.Approval = &v1alpha2.ToolApproval{}
+			c.Fill(tool.Approval)
+		}
+	},
+	func(tool *v1alpha2.McpServerTool, c randfill.Continue) {
+		c.FillNoCustom(tool)
+		tool.ToolServer = randomReference(c, v1alpha2.ToolServerKind)
+	},
+	func(tool *v1alpha2.AgentTool, c randfill.Continue) {
+		kind := v1alpha2.AgentKind
+		if c.Bool() {
+			kind = v1alpha2.RemoteAgentKind
+		}
+		tool.Ref = randomReference(c, kind)
+	},
+	func(tool *v1alpha2.HttpTool, c randfill.Continue) {
+		c.FillNoCustom(tool)
+		tool.ToolSet = randomReference(c, v1alpha2.HttpToolSetKind)
+	},
+	func(mcpContext *v1alpha2.McpServerContext, c randfill.Continue) {
+		c.FillNoCustom(mcpContext)
+		mcpContext.ToolServer = randomReference(c, v1alpha2.ToolServerKind)
+	},
+	func(spec *v1alpha2.TeamSpec, c randfill.Continue) {
+		c.FillNoCustom(spec)
+		spec.Participants = randomReferences(c, v1alpha2.AgentKind)
+		spec.ModelConfig = nil
+		if c.Bool() {
+			ref := randomReference(c, v1alpha2.ModelConfigKind)
+			spec.ModelConfig = &ref
+		}
+	},
+	func(groupChat *v1alpha2.GroupChat, c randfill.Continue) {
+		*groupChat = v1alpha2.GroupChat{}
+		switch c.Intn(4) {
+		case 0:
+			groupChat.Type = v1alpha2.GroupChatType_RoundRobin
+		case 1:
+			groupChat.Type = v1alpha2.GroupChatType_Selector
+			groupChat.Selector = &v1alpha2.SelectorGroupChat{}
+			c.Fill(groupChat.Selector)
+		case 2:
+			groupChat.Type = v1alpha2.GroupChatType_MagenticOne
+			groupChat.MagenticOne = &v1alpha2.MagenticOneGroupChat{}
+			c.Fill(groupChat.MagenticOne)
+		case 3:
+			groupChat.Type = v1alpha2.GroupChatType_Swarm
+		}
+	},
+	func(condition *v1alpha2.TerminationCondition, c randfill.Continue) {
+		*condition = v1alpha2.TerminationCondition{}
+		switch c.Intn(5) {
+		case 0:
+			condition.Type = v1alpha2.TerminationConditionType_MaxMessage
+			condition.MaxMessage = &v1alpha2.MaxMessageTermination{}
+			c.Fill(condition.MaxMessage)
+		case 1:
+			condition.Type = v1alpha2.TerminationConditionType_TextMention
+			condition.TextMention = &v1alpha2.TextMentionTermination{}
+			c.Fill(condition.TextMention)
+		case 2:
+			condition.Type = v1alpha2.TerminationConditionType_TextMessage
+			condition.TextMessage = &v1alpha2.TextMessageTermination{}
+			c.Fill(condition.TextMessage)
+		case 3:
+			condition.Type = v1alpha2.TerminationConditionType_StopMessage
+		case 4:
+			condition.Type = v1alpha2.TerminationConditionType_Or
+			condition.Or = &v1alpha2.OrTermination{}
+			c.Fill(condition.Or)
+		}
+	},
+	func(condition *v1alpha2.OrTerminationCondition, c randfill.Continue) {
+		*condition = v1alpha2.OrTerminationCondition{}
+		if c.Bool() {
+			condition.Type = v1alpha2.OrTerminationConditionType_MaxMessage
+			condition.MaxMessage = &v1alpha2.MaxMessageTermination{}
+			c.Fill(condition.MaxMessage)
+		} else {
+			condition.Type = v1alpha2.OrTerminationConditionType_TextMention
+			condition.TextMention = &v1alpha2.TextMentionTermination{}
+			c.Fill(condition.TextMention)
+		}
+	},
+}
+
+// v1alpha1FillFuncs fill the resources admitted by the v1alpha1 admission webhooks: the tools set the
+// provider named by their type, and the teams and termination conditions set at most one member.
+var v1alpha1FillFuncs = []interface{}{
+	func(spec *v1alpha1.AgentSpec, c randfill.Continue) {
+		c.FillNoCustom(spec)
+		spec.ModelConfig = ""
+		if c.Bool() {
+			spec.ModelConfig = randomHubReference(c)
+		}
+		spec.Memory = randomHubReferences(c)
+		for i, tool := range spec.Tools {
+			if tool == nil {
+				spec.Tools[i] = &v1alpha1.Tool{}
+				c.Fill(spec.Tools[i])
+			}
+		}
+		for i, mcpContext := range spec.McpContext {
+			if mcpContext == nil {
+				spec.McpContext[i] = &v1alpha1.McpServerContext{}
+				c.Fill(spec.McpContext[i])
+			}
+		}
+	},
+	func(tool *v1alpha1.Tool, c randfill.Continue) {
+		*tool = v1alpha1.Tool{}
+		switch c.Intn(5) {
+		case 0:
+			tool.Type = v1alpha1.ToolProviderType_Builtin
+			tool.Builtin = &v1alpha1.BuiltinTool{}
+			c.Fill(tool.Builtin)
+		case 1:
+			tool.Type = v1alpha1.ToolProviderType_McpServer
+			tool.McpServer = &v1alpha1.McpServerTool{}
+			c.Fill(tool.McpServer)
+		case 2:
+			tool.Type = v1alpha1.ToolProviderType_Agent
+			tool.Agent = &v1alpha1.AgentTool{Ref: randomHubReference(c)}
+		case 3:
+			tool.Type = v1alpha1.ToolProviderType_Http
+			tool.Http = &v1alpha1.HttpTool{}
+			c.Fill(tool.Http)
+		case 4:
+			tool.Type = v1alpha1.ToolProviderType_RemoteAgent
+			tool.RemoteAgent = &v1alpha1.RemoteAgentTool{Ref: randomHubReference(c)}
+		}
+		if tool.Type != v1alpha1.ToolProviderType_Agent && c.Bool() {
+			tool.RequireApproval = true
+			tool.ApprovalTimeout = c.String(0)
+		}
+	},
+	func(tool *v1alpha1.McpServerTool, c randfill.Continue) {
+		c.FillNoCustom(tool)
+		tool.ToolServer = randomHubReference(c)
+	},
+	func(tool *v1alpha1.HttpTool, c randfill.Continue) {
+		c.FillNoCustom(tool)
+		tool.ToolSet = randomHubReference(c)
+	},
+	func(mcpContext *v1alpha1.McpServerContext, c randfill.Continue) {
+		c.FillNoCustom(mcpContext)
+		mcpContext.ToolServer = randomHubReference(c)
+	},
+	func(spec *v1alpha1.TeamSpec, c randfill.Continue) {
+		c.FillNoCustom(spec)
+		spec.Participants = randomHubReferences(c)
+		spec.ModelConfig = ""
+		if c.Bool() {
+			spec.ModelConfig = randomHubReference(c)
+		}
+		spec.RoundRobinTeamConfig = nil
+		spec.SelectorTeamConfig = nil
+		spec.MagenticOneTeamConfig = nil
+		spec.SwarmTeamConfig = nil
+		switch c.Intn(5) {
+		case 0:
+			spec.RoundRobinTeamConfig = &v1alpha1.RoundRobinTeamConfig{}
+		case 1:
+			spec.SelectorTeamConfig = &v1alpha1.SelectorTeamConfig{}
+			c.Fill(spec.SelectorTeamConfig)
+		case 2:
+			spec.MagenticOneTeamConfig = &v1alpha1.MagenticOneTeamConfig{}
+			c.Fill(spec.MagenticOneTeamConfig)
+		case 3:
+			spec.SwarmTeamConfig = &v1alpha1.SwarmTeamConfig{}
+		}
+	},
+	func(condition *v1alpha1.TerminationCondition, c randfill.Continue) {
+		*condition = v1alpha1.TerminationCondition{}
+		switch c.Intn(6) {
+		case 0:
+			condition.MaxMessageTermination = &v1alpha1.MaxMessageTermination{}
+			c.Fill(condition.MaxMessageTermination)
+		case 1:
+			condition.TextMentionTermination = &v1alpha1.TextMentionTermination{}
+			c.Fill(condition.TextMentionTermination)
+		case 2:
+			condition.TextMessageTermination = &v1alpha1.TextMessageTermination{}
+			c.Fill(condition.TextMessageTermination)
+		case 3:
+			condition.StopMessageTermination = &v1alpha1.StopMessageTermination{}
+		case 4:
+			condition.OrTermination = &v1alpha1.OrTermination{}
+			c.Fill(condition.OrTermination)
+		}
+	},
+	func(condition *v1alpha1.OrTerminationCondition, c randfill.Continue) {
+		*condition = v1alpha1.OrTerminationCondition{}
+		switch c.Intn(3) {
+		case 0:
+			condition.MaxMessageTermination = &v1alpha1.MaxMessageTermination{}
+			c.Fill(condition.MaxMessageTermination)
+		case 1:
+			condition.TextMentionTermination = &v1alpha1.TextMentionTermination{}
+			c.Fill(condition.TextMentionTermination)
+		}
+	},
+}
+
+func newFiller(seed int64, funcs []interface{}) *randfill.Filler {
+	return randfill.NewWithSeed(seed).NilChance(0.2).NumElements(0, 3).Funcs(funcs...)
+}
+
+func TestAgentConversionRoundTrip(t *testing.T) {
+	t.Run("v1alpha2 to v1alpha1 and back", func(t *testing.T) {
+		filler := newFiller(1, v1alpha2FillFuncs)
+		for i := 0; i < fuzzIterations; i++ {
+			original := &v1alpha2.Agent{}
+			filler.Fill(original)
+			original.TypeMeta = metav1.TypeMeta{}
+
+			hub := &v1alpha1.Agent{}
+			require.NoError(t, original.ConvertTo(hub))
+			converted := &v1alpha2.Agent{}
+			require.NoError(t, converted.ConvertFrom(hub))
+			require.Equal(t, original, converted)
+		}
+	})
+
+	t.Run("v1alpha1 to v1alpha2 and back", func(t *testing.T) {
+		filler := newFiller(1, v1alpha1FillFuncs)
+		for i := 0; i < fuzzIterations; i++ {
+			original := &v1alpha1.Agent{}
+			filler.Fill(original)
+			original.TypeMeta = metav1.TypeMeta{}
+
+			spoke := &v1alpha2.Agent{}
+			require.NoError(t, spoke.ConvertFrom(original))
+			converted := &v1alpha1.Agent{}
+			require.NoError(t, spoke.ConvertTo(converted))
+			require.Equal(t, original, converted)
+		}
+	})
+}
+
+func TestTeamConversionRoundTrip(t *testing.T) {
+	t.Run("v1alpha2 to v1alpha1 and back", func(t *testing.T) {
+		filler := newFiller(1, v1alpha2FillFuncs)
+		for i := 0; i < fuzzIterations; i++ {
+			original := &v1alpha2.Team{}
+			filler.Fill(original)
+			original.TypeMeta = metav1.TypeMeta{}
+
+			hub := &v1alpha1.Team{}
+			require.NoError(t, original.ConvertTo(hub))
+			converted := &v1alpha2.Team{}
+			require.NoError(t, converted.ConvertFrom(hub))
+			require.Equal(t, original, converted)
+		}
+	})
+
+	t.Run("v1alpha1 to v1alpha2 and back", func(t *testing.T) {
+		filler := newFiller(1, v1alpha1FillFuncs)
+		for i := 0; i < fuzzIterations; i++ {
+			original := &v1alpha1.Team{}
+			filler.Fill(original)
+			original.TypeMeta = metav1.TypeMeta{}
+
+			spoke := &v1alpha2.Team{}
+			require.NoError(t, spoke.ConvertFrom(original))
+			converted := &v1alpha1.Team{}
+			require.NoError(t, spoke.ConvertTo(converted))
+			require.Equal(t, original, converted)
+		}
+	})
+}
+
+// TestConvertible checks that the conversion webhook is served for Agents and Teams
+func TestConvertible(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+	for _, obj := range []runtime.Object{&v1alpha1.Agent{}, &v1alpha1.Team{}} {
+		convertible, err := conversion.IsConvertible(scheme, obj)
+		require.NoError(t, err)
+		assert.True(t, convertible, "%T", obj)
+	}
+}
+
+// convertThroughWebhook converts the object to the version through the conversion webhook served by the controller
+func convertThroughWebhook(t *testing.T, handler http.Handler, obj runtime.Object, apiVersion string) []byte {
+	raw, err := json.Marshal(obj)
+	require.NoError(t, err)
+	body, err := json.Marshal(&apiextensionsv1.ConversionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: apiextensionsv1.SchemeGroupVersion.String(), Kind: "ConversionReview"},
+		Request: &apiextensionsv1.ConversionRequest{
+			UID:               "review",
+			DesiredAPIVersion: apiVersion,
+			Objects:           []runtime.RawExtension{{Raw: raw}},
+		},
+	})
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/convert", bytes.NewReader(body)))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	review := &apiextensionsv1.ConversionReview{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), review))
+	require.Equal(t, metav1.StatusSuccess, review.Response.Result.Status, review.Response.Result.Message)
+	require.Len(t, review.Response.ConvertedObjects, 1)
+	return review.Response.ConvertedObjects[0].Raw
+}
+
+func TestConversionWebhookRoundTrip(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+	handler := conversion.NewWebhookHandler(scheme)
+
+	t.Run("should round trip an agent", func(t *testing.T) {
+		original := &v1alpha1.Agent{
+			TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.GroupVersion.String(), Kind: "Agent"},
+			ObjectMeta: metav1.ObjectMeta{Name: "k8s-agent", Namespace: "kagent", UID: "agent-uid"},
+			Spec: v1alpha1.AgentSpec{
+				Description:   "Kubernetes agent",
+				SystemMessage: "You are a Kubernetes expert.",
+				ModelConfig:   "models/gpt-4o",
+				Tools: []*v1alpha1.Tool{
+					{Type: v1alpha1.ToolProviderType_RemoteAgent, RemoteAgent: &v1alpha1.RemoteAgentTool{Ref: "remote"}, RequireApproval: true},
+					{Type: v1alpha1.ToolProviderType_McpServer, McpServer: &v1alpha1.McpServerTool{ToolServer: "tools/mcp", ToolNames: []string{"get"}}},
+				},
+			},
+		}
+
+		converted := convertThroughWebhook(t, handler, original, v1alpha2.GroupVersion.String())
+		agent := &v1alpha2.Agent{}
+		require.NoError(t, json.Unmarshal(converted, agent))
+		assert.Equal(t, v1alpha2.GroupVersion.String(), agent.APIVersion)
+		assert.Equal(t, &v1alpha2.TypedReference{Kind: v1alpha2.ModelConfigKind, Namespace: "models", Name: "gpt-4o"}, agent.Spec.ModelConfig)
+
+		roundTripped := &v1alpha1.Agent{}
+		require.NoError(t, json.Unmarshal(convertThroughWebhook(t, handler, agent, v1alpha1.GroupVersion.String()), roundTripped))
+		assert.Equal(t, original, roundTripped)
+	})
+
+	t.Run("should round trip a team", func(t *testing.T) {
+		original := &v1alpha1.Team{
+			TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.GroupVersion.String(), Kind: "Team"},
+			ObjectMeta: metav1.ObjectMeta{Name: "k8s-team", Namespace: "kagent", UID: "team-uid"},
+			Spec: v1alpha1.TeamSpec{
+				Participants:       []string{"k8s-agent", "observability/prometheus-agent"},
+				Description:        "Kubernetes team",
+				ModelConfig:        "gpt-4o",
+				SelectorTeamConfig: &v1alpha1.SelectorTeamConfig{SelectorPrompt: "Select the next agent."},
+				TerminationCondition: v1alpha1.TerminationCondition{
+					TextMentionTermination: &v1alpha1.TextMentionTermination{Text: "TERMINATE"},
+				},
+				MaxTurns: 10,
+			},
+		}
+
+		converted := convertThroughWebhook(t, handler, original, v1alpha2.GroupVersion.String())
+		team := &v1alpha2.Team{}
+		require.NoError(t, json.Unmarshal(converted, team))
+		assert.Equal(t, v1alpha2.GroupVersion.String(), team.APIVersion)
+
+		roundTripped := &v1alpha1.Team{}
+		require.NoError(t, json.Unmarshal(convertThroughWebhook(t, handler, team, v1alpha1.GroupVersion.String()), roundTripped))
+		assert.Equal(t, original, roundTripped)
+	})
+}
+
+func TestAgentConversion(t *testing.T) {
+	hub := &v1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "k8s-agent", Namespace: "kagent"},
+		Spec: v1alpha1.AgentSpec{
+			ModelConfig: "models/gpt-4o",
+			Tools: []*v1alpha1.Tool{
+				{Type: v1alpha1.ToolProviderType_RemoteAgent, RemoteAgent: &v1alpha1.RemoteAgentTool{Ref: "remote"}, RequireApproval: true},
+				{Type: v1alpha1.ToolProviderType_McpServer, McpServer: &v1alpha1.McpServerTool{ToolServer: "tools/mcp", ToolNames: []string{"get"}}},
+			},
+		},
+	}
+
+	agent := &v1alpha2.Agent{}
+	require.NoError(t, agent.ConvertFrom(hub))
+	assert.Equal(t, &v1alpha2.TypedReference{Kind: v1alpha2.ModelConfigKind, Namespace: "models", Name: "gpt-4o"}, agent.Spec.ModelConfig)
+	assert.Equal(t, []v1alpha2.Tool{
+		{
+			Type:     v1alpha2.ToolType_Agent,
+			Agent:    &v1alpha2.AgentTool{Ref: v1alpha2.TypedReference{Kind: v1alpha2.RemoteAgentKind, Name: "remote"}},
+			Approval: &v1alpha2.ToolApproval{},
+		},
+		{
+			Type: v1alpha2.ToolType_McpServer,
+			McpServer: &v1alpha2.McpServerTool{
+				ToolServer: v1alpha2.TypedReference{Kind: v1alpha2.ToolServerKind, Namespace: "tools", Name: "mcp"},
+				ToolNames:  []string{"get"},
+			},
+		},
+	}, agent.Spec.Tools)
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+// The kinds of the resources which can be referenced
+const (
+	AgentKind       = "Agent"
+	RemoteAgentKind = "RemoteAgent"
+	ModelConfigKind = "ModelConfig"
+	MemoryKind      = "Memory"
+	ToolServerKind  = "ToolServer"
+	HttpToolSetKind = "HttpToolSet"
+)
+
+// TypedReference references a kagent resource.
+// The fields holding references restrict the kinds they accept.
+type TypedReference struct {
+	// The kind of the referenced resource.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Kind string `json:"kind"`
+	// The name of the referenced resource.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	Name string `json:"name"`
+	// The namespace of the referenced resource.
+	// If not specified, the namespace of the referencing resource is used.
+	// +optional
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Namespace string `json:"namespace,omitempty"`
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+)
+
+var _ conversion.Convertible = &Team{}
+
+// ConvertTo converts this Team to the hub version (v1alpha1).
+func (src *Team) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha1.Team)
+	if !ok {
+		return fmt.Errorf("expected a v1alpha1 Team but got %T", dstRaw)
+	}
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = v1alpha1.TeamSpec{
+		Participants:         hubReferences(src.Spec.Participants),
+		Description:          src.Spec.Description,
+		ModelConfig:          optionalHubReference(src.Spec.ModelConfig),
+		TerminationCondition: hubTerminationCondition(&src.Spec.TerminationCondition),
+		MaxTurns:             src.Spec.MaxTurns,
+	}
+	groupChat := src.Spec.GroupChat
+	switch groupChat.Type {
+	case GroupChatType_RoundRobin:
+		dst.Spec.RoundRobinTeamConfig = &v1alpha1.RoundRobinTeamConfig{}
+	case GroupChatType_Selector:
+		dst.Spec.SelectorTeamConfig = &v1alpha1.SelectorTeamConfig{}
+		if groupChat.Selector != nil {
+			dst.Spec.SelectorTeamConfig.SelectorPrompt = groupChat.Selector.SelectorPrompt
+		}
+	case GroupChatType_MagenticOne:
+		dst.Spec.MagenticOneTeamConfig = &v1alpha1.MagenticOneTeamConfig{}
+		if groupChat.MagenticOne != nil {
+			dst.Spec.MagenticOneTeamConfig.MaxStalls = groupChat.MagenticOne.MaxStalls
+			dst.Spec.MagenticOneTeamConfig.FinalAnswerPrompt = groupChat.MagenticOne.FinalAnswerPrompt
+		}
+	case GroupChatType_Swarm:
+		dst.Spec.SwarmTeamConfig = &v1alpha1.SwarmTeamConfig{}
+	}
+	dst.Status = v1alpha1.TeamStatus{
+		Conditions:         src.Status.Conditions,
+		ObservedGeneration: src.Status.ObservedGeneration,
+		ComponentHash:      src.Status.ComponentHash,
+		ModelConfig:        src.Status.ModelConfig,
+		TeamID:             src.Status.TeamID,
+		References:         hubObservedReferences(src.Status.References),
+	}
+	return nil
+}
+
+// ConvertFrom converts the hub version (v1alpha1) to this Team.
+func (dst *Team) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha1.Team)
+	if !ok {
+		return fmt.Errorf("expected a v1alpha1 Team but got %T", srcRaw)
+	}
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = TeamSpec{
+		Participants:         referencesFromHub(AgentKind, src.Spec.Participants),
+		Description:          src.Spec.Description,
+		ModelConfig:          optionalReferenceFromHub(ModelConfigKind, src.Spec.ModelConfig),
+		TerminationCondition: terminationConditionFromHub(&src.Spec.TerminationCondition),
+		MaxTurns:             src.Spec.MaxTurns,
+	}
+	switch {
+	case src.Spec.RoundRobinTeamConfig != nil:
+		dst.Spec.GroupChat.Type = GroupChatType_RoundRobin
+	case src.Spec.SelectorTeamConfig != nil:
+		dst.Spec.GroupChat.Type = GroupChatType_Selector
+		dst.Spec.GroupChat.Selector = &SelectorGroupChat{SelectorPrompt: src.Spec.SelectorTeamConfig.SelectorPrompt}
+	case src.Spec.MagenticOneTeamConfig != nil:
+		dst.Spec.GroupChat.Type = GroupChatType_MagenticOne
+		dst.Spec.GroupChat.MagenticOne = &MagenticOneGroupChat{
+			MaxStalls:         src.Spec.MagenticOneTeamConfig.MaxStalls,
+			FinalAnswerPrompt: src.Spec.MagenticOneTeamConfig.FinalAnswerPrompt,
+		}
+	case src.Spec.SwarmTeamConfig != nil:
+		dst.Spec.GroupChat.Type = GroupChatType_Swarm
+	}
+	dst.Status = TeamStatus{
+		Conditions:         src.Status.Conditions,
+		ObservedGeneration: src.Status.ObservedGeneration,
+		ComponentHash:      src.Status.ComponentHash,
+		ModelConfig:        src.Status.ModelConfig,
+		TeamID:             src.Status.TeamID,
+		References:         observedReferencesFromHub(src.Status.References),
+	}
+	return nil
+}
+
+func hubTerminationCondition(src *TerminationCondition) v1alpha1.TerminationCondition {
+	var dst v1alpha1.TerminationCondition
+	switch src.Type {
+	case TerminationConditionType_MaxMessage:
+		dst.MaxMessageTermination = &v1alpha1.MaxMessageTermination{}
+		if src.MaxMessage != nil {
+			dst.MaxMessageTermination.MaxMessages = src.MaxMessage.MaxMessages
+		}
+	case TerminationConditionType_TextMention:
+		dst.TextMentionTermination = &v1alpha1.TextMentionTermination{}
+		if src.TextMention != nil {
+			dst.TextMentionTermination.Text = src.TextMention.Text
+		}
+	case TerminationConditionType_TextMessage:
+		dst.TextMessageTermination = &v1alpha1.TextMessageTermination{}
+		if src.TextMessage != nil {
+			dst.TextMessageTermination.Source = src.TextMessage.Source
+		}
+	case TerminationConditionType_StopMessage:
+		dst.StopMessageTermination = &v1alpha1.StopMessageTermination{}
+	case TerminationConditionType_Or:
+		dst.OrTermination = &v1alpha1.OrTermination{}
+		if src.Or != nil && src.Or.Conditions != nil {
+			dst.OrTermination.Conditions = make([]v1alpha1.OrTerminationCondition, len(src.Or.Conditions))
+			for i, condition := range src.Or.Conditions {
+				switch condition.Type {
+				case OrTerminationConditionType_MaxMessage:
+					dst.OrTermination.Conditions[i].MaxMessageTermination = &v1alpha1.MaxMessageTermination{}
+					if condition.MaxMessage != nil {
+						dst.OrTermination.Conditions[i].MaxMessageTermination.MaxMessages = condition.MaxMessage.MaxMessages
+					}
+				case OrTerminationConditionType_TextMention:
+					dst.OrTermination.Conditions[i].TextMentionTermination = &v1alpha1.TextMentionTermination{}
+					if condition.TextMention != nil {
+						dst.OrTermination.Conditions[i].TextMentionTermination.Text = condition.TextMention.Text
+					}
+				}
+			}
+		}
+	}
+	return dst
+}
+
+func terminationConditionFromHub(src *v1alpha1.TerminationCondition) TerminationCondition {
+	var dst TerminationCondition
+	switch {
+	case src.MaxMessageTermination != nil:
+		dst.Type = TerminationConditionType_MaxMessage
+		dst.MaxMessage = &MaxMessageTermination{MaxMessages: src.MaxMessageTermination.MaxMessages}
+	case src.TextMentionTermination != nil:
+		dst.Type = TerminationConditionType_TextMention
+		dst.TextMention = &TextMentionTermination{Text: src.TextMentionTermination.Text}
+	case src.TextMessageTermination != nil:
+		dst.Type = TerminationConditionType_TextMessage
+		dst.TextMessage = &TextMessageTermination{Source: src.TextMessageTermination.Source}
+	case src.StopMessageTermination != nil:
+		dst.Type = TerminationConditionType_StopMessage
+	case src.OrTermination != nil:
+		dst.Type = TerminationConditionType_Or
+		dst.Or = &OrTermination{}
+		if src.OrTermination.Conditions != nil {
+			dst.Or.Conditions = make([]OrTerminationCondition, len(src.OrTermination.Conditions))
+			for i, condition := range src.OrTermination.Conditions {
+				switch {
+				case condition.MaxMessageTermination != nil:
+					dst.Or.Conditions[i].Type = OrTerminationConditionType_MaxMessage
+					dst.Or.Conditions[i].MaxMessage = &MaxMessageTermination{MaxMessages: condition.MaxMessageTermination.MaxMessages}
+				case condition.TextMentionTermination != nil:
+					dst.Or.Conditions[i].Type = OrTerminationConditionType_TextMention
+					dst.Or.Conditions[i].TextMention = &TextMentionTermination{Text: condition.TextMentionTermination.Text}
+				}
+			}
+		}
+	}
+	return dst
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TeamSpec defines the desired state of Team.
+type TeamSpec struct {
+	// The agents participating in the team.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=50
+	// +kubebuilder:validation:XValidation:message="participants must reference Agents",rule="self.all(p, p.kind == 'Agent')"
+	Participants []TypedReference `json:"participants"`
+	// +optional
+	Description string `json:"description,omitempty"`
+	// The ModelConfig used by the team. If not specified, the default model config is used.
+	// +optional
+	// +kubebuilder:validation:XValidation:message="modelConfig must reference a ModelConfig",rule="self.kind == 'ModelConfig'"
+	ModelConfig *TypedReference `json:"modelConfig,omitempty"`
+	// How the participants take turns.
+	GroupChat GroupChat `json:"groupChat"`
+	// When the conversation of the team ends.
+	TerminationCondition TerminationCondition `json:"terminationCondition"`
+	// The maximum number of turns of the team. If not specified, the turns are not limited.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxTurns int64 `json:"maxTurns,omitempty"`
+}
+
+// GroupChatType is the discriminator of a group chat
+// +kubebuilder:validation:Enum=RoundRobin;Selector;MagenticOne;Swarm
+type GroupChatType string
+
+const (
+	GroupChatType_RoundRobin  GroupChatType = "RoundRobin"
+	GroupChatType_Selector    GroupChatType = "Selector"
+	GroupChatType_MagenticOne GroupChatType = "MagenticOne"
+	GroupChatType_Swarm       GroupChatType = "Swarm"
+)
+
+// GroupChat selects how the participants of a team take turns.
+// The RoundRobin and Swarm group chats have no configuration.
+// +union
+// +kubebuilder:validation:XValidation:message="selector must be set if and only if the type is Selector",rule="has(self.selector) == (self.type == 'Selector')"
+// +kubebuilder:validation:XValidation:message="magenticOne must be set if and only if the type is MagenticOne",rule="has(self.magenticOne) == (self.type == 'MagenticOne')"
+type GroupChat struct {
+	// +unionDiscriminator
+	Type GroupChatType `json:"type"`
+	// +optional
+	Selector *SelectorGroupChat `json:"selector,omitempty"`
+	// +optional
+	MagenticOne *MagenticOneGroupChat `json:"magenticOne,omitempty"`
+}
+
+type SelectorGroupChat struct {
+	// +optional
+	SelectorPrompt string `json:"selectorPrompt,omitempty"`
+}
+
+type MagenticOneGroupChat struct {
+	// +optional
+	MaxStalls int `json:"maxStalls,omitempty"`
+	// +optional
+	FinalAnswerPrompt string `json:"finalAnswerPrompt,omitempty"`
+}
+
+// TerminationConditionType is the discriminator of a termination condition
+// +kubebuilder:validation:Enum=MaxMessage;TextMention;TextMessage;StopMessage;Or
+type TerminationConditionType string
+
+const (
+	TerminationConditionType_MaxMessage  TerminationConditionType = "MaxMessage"
+	TerminationConditionType_TextMention TerminationConditionType = "TextMention"
+	TerminationConditionType_TextMessage TerminationConditionType = "TextMessage"
+	TerminationConditionType_StopMessage TerminationConditionType = "StopMessage"
+	TerminationConditionType_Or          TerminationConditionType = "Or"
+)
+
+// TerminationCondition ends the conversation of a team. The StopMessage condition has no configuration.
+// +union
+// +kubebuilder:validation:XValidation:message="maxMessage must be set if and only if the type is MaxMessage",rule="has(self.maxMessage) == (self.type == 'MaxMessage')"
+// +kubebuilder:validation:XValidation:message="textMention must be set if and only if the type is TextMention",rule="has(self.textMention) == (self.type == 'TextMention')"
+// +kubebuilder:validation:XValidation:message="textMessage must be set if and only if the type is TextMessage",rule="has(self.textMessage) == (self.type == 'TextMessage')"
+// +kubebuilder:validation:XValidation:message="or must be set if and only if the type is Or",rule="has(self.or) == (self.type == 'Or')"
+type TerminationCondition struct {
+	// +unionDiscriminator
+	Type TerminationConditionType `json:"type"`
+	// +optional
+	MaxMessage *MaxMessageTermination `json:"maxMessage,omitempty"`
+	// +optional
+	TextMention *TextMentionTermination `json:"textMention,omitempty"`
+	// +optional
+	TextMessage *TextMessageTermination `json:"textMessage,omitempty"`
+	// +optional
+	Or *OrTermination `json:"or,omitempty"`
+}
+
+type MaxMessageTermination struct {
+	// +kubebuilder:validation:Minimum=1
+	MaxMessages int `json:"maxMessages"`
+}
+
+type TextMentionTermination struct {
+	Text string `json:"text"`
+}
+
+type TextMessageTermination struct {
+	Source string `json:"source"`
+}
+
+type OrTermination struct {
+	// The conversation ends when any of the conditions is met.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=20
+	Conditions []OrTerminationCondition `json:"conditions"`
+}
+
+// OrTerminationConditionType is the discriminator of a condition of an Or termination condition
+// +kubebuilder:validation:Enum=MaxMessage;TextMention
+type OrTerminationConditionType string
+
+const (
+	OrTerminationConditionType_MaxMessage  OrTerminationConditionType = "MaxMessage"
+	OrTerminationConditionType_TextMention OrTerminationConditionType = "TextMention"
+)
+
+// OrTerminationCondition is one of the conditions of an Or termination condition
+// +union
+// +kubebuilder:validation:XValidation:message="maxMessage must be set if and only if the type is MaxMessage",rule="has(self.maxMessage) == (self.type == 'MaxMessage')"
+// +kubebuilder:validation:XValidation:message="textMention must be set if and only if the type is TextMention",rule="has(self.textMention) == (self.type == 'TextMention')"
+type OrTerminationCondition struct {
+	// +unionDiscriminator
+	Type OrTerminationConditionType `json:"type"`
+	// +optional
+	MaxMessage *MaxMessageTermination `json:"maxMessage,omitempty"`
+	// +optional
+	TextMention *TextMentionTermination `json:"textMention,omitempty"`
+}
+
+// TeamStatus defines the observed state of Team.
+type TeamStatus struct {
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// The hash of the autogen component last sent for the team, which is only sent again once it changes.
+	// +optional
+	ComponentHash string `json:"componentHash,omitempty"`
+	// The namespace/name of the ModelConfig used by the team, which is the default one unless the team sets one.
+	// +optional
+	ModelConfig string `json:"modelConfig,omitempty"`
+	// The ID of the team in autogen.
+	// +optional
+	TeamID int `json:"teamID,omitempty"`
+	// The resources referenced by the team, with the generation of each which was last translated.
+	// +optional
+	References []ObservedReference `json:"references,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="Whether or not the team can be used."
+// +kubebuilder:printcolumn:name="ModelConfig",type="string",JSONPath=".status.modelConfig",description="The ModelConfig used by this team."
+// +kubebuilder:printcolumn:name="GroupChat",type="string",JSONPath=".spec.groupChat.type",description="How the participants take turns."
+// +kubebuilder:printcolumn:name="Participants",type="string",JSONPath=".spec.participants[*].name",priority=1,description="The agents participating in this team."
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Team is the Schema for the teams API.
+type Team struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TeamSpec   `json:"spec,omitempty"`
+	Status TeamStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TeamList contains a list of Team.
+type TeamList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Team `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Team{}, &TeamList{})
+}
@@ -0,0 +1,786 @@
+//go:build !ignore_autogenerated
+
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	"encoding/json"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *A2AConfig) DeepCopyInto(out *A2AConfig) {
+	*out = *in
+	if in.Skills != nil {
+		in, out := &in.Skills, &out.Skills
+		*out = make([]AgentSkill, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new A2AConfig.
+func (in *A2AConfig) DeepCopy() *A2AConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(A2AConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Agent) DeepCopyInto(out *Agent) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Agent.
+func (in *Agent) DeepCopy() *Agent {
+	if in == nil {
+		return nil
+	}
+	out := new(Agent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Agent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentList) DeepCopyInto(out *AgentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Agent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentList.
+func (in *AgentList) DeepCopy() *AgentList {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AgentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentSkill) DeepCopyInto(out *AgentSkill) {
+	*out = *in
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Examples != nil {
+		in, out := &in.Examples, &out.Examples
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InputModes != nil {
+		in, out := &in.InputModes, &out.InputModes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OutputModes != nil {
+		in, out := &in.OutputModes, &out.OutputModes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentSkill.
+func (in *AgentSkill) DeepCopy() *AgentSkill {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentSkill)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentSpec) DeepCopyInto(out *AgentSpec) {
+	*out = *in
+	if in.ModelConfig != nil {
+		in, out := &in.ModelConfig, &out.ModelConfig
+		*out = new(TypedReference)
+		**out = **in
+	}
+	if in.Stream != nil {
+		in, out := &in.Stream, &out.Stream
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Tools != nil {
+		in, out := &in.Tools, &out.Tools
+		*out = make([]Tool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = make([]TypedReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.McpContext != nil {
+		in, out := &in.McpContext, &out.McpContext
+		*out = make([]McpServerContext, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaxToolDepth != nil {
+		in, out := &in.MaxToolDepth, &out.MaxToolDepth
+		*out = new(int32)
+		**out = **in
+	}
+	if in.A2AConfig != nil {
+		in, out := &in.A2AConfig, &out.A2AConfig
+		*out = new(A2AConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentSpec.
+func (in *AgentSpec) DeepCopy() *AgentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentStatus) DeepCopyInto(out *AgentStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResolvedTools != nil {
+		in, out := &in.ResolvedTools, &out.ResolvedTools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.References != nil {
+		in, out := &in.References, &out.References
+		*out = make([]ObservedReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentStatus.
+func (in *AgentStatus) DeepCopy() *AgentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentTool) DeepCopyInto(out *AgentTool) {
+	*out = *in
+	out.Ref = in.Ref
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentTool.
+func (in *AgentTool) DeepCopy() *AgentTool {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentTool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnyType) DeepCopyInto(out *AnyType) {
+	*out = *in
+	if in.RawMessage != nil {
+		in, out := &in.RawMessage, &out.RawMessage
+		*out = make(json.RawMessage, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnyType.
+func (in *AnyType) DeepCopy() *AnyType {
+	if in == nil {
+		return nil
+	}
+	out := new(AnyType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuiltinTool) DeepCopyInto(out *BuiltinTool) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]AnyType, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuiltinTool.
+func (in *BuiltinTool) DeepCopy() *BuiltinTool {
+	if in == nil {
+		return nil
+	}
+	out := new(BuiltinTool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupChat) DeepCopyInto(out *GroupChat) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(SelectorGroupChat)
+		**out = **in
+	}
+	if in.MagenticOne != nil {
+		in, out := &in.MagenticOne, &out.MagenticOne
+		*out = new(MagenticOneGroupChat)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupChat.
+func (in *GroupChat) DeepCopy() *GroupChat {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupChat)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HttpTool) DeepCopyInto(out *HttpTool) {
+	*out = *in
+	out.ToolSet = in.ToolSet
+	if in.Operations != nil {
+		in, out := &in.Operations, &out.Operations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HttpTool.
+func (in *HttpTool) DeepCopy() *HttpTool {
+	if in == nil {
+		return nil
+	}
+	out := new(HttpTool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MagenticOneGroupChat) DeepCopyInto(out *MagenticOneGroupChat) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MagenticOneGroupChat.
+func (in *MagenticOneGroupChat) DeepCopy() *MagenticOneGroupChat {
+	if in == nil {
+		return nil
+	}
+	out := new(MagenticOneGroupChat)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaxMessageTermination) DeepCopyInto(out *MaxMessageTermination) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaxMessageTermination.
+func (in *MaxMessageTermination) DeepCopy() *MaxMessageTermination {
+	if in == nil {
+		return nil
+	}
+	out := new(MaxMessageTermination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *McpPromptRef) DeepCopyInto(out *McpPromptRef) {
+	*out = *in
+	if in.Arguments != nil {
+		in, out := &in.Arguments, &out.Arguments
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new McpPromptRef.
+func (in *McpPromptRef) DeepCopy() *McpPromptRef {
+	if in == nil {
+		return nil
+	}
+	out := new(McpPromptRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *McpServerContext) DeepCopyInto(out *McpServerContext) {
+	*out = *in
+	out.ToolServer = in.ToolServer
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Prompts != nil {
+		in, out := &in.Prompts, &out.Prompts
+		*out = make([]McpPromptRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new McpServerContext.
+func (in *McpServerContext) DeepCopy() *McpServerContext {
+	if in == nil {
+		return nil
+	}
+	out := new(McpServerContext)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *McpServerTool) DeepCopyInto(out *McpServerTool) {
+	*out = *in
+	out.ToolServer = in.ToolServer
+	if in.ToolNames != nil {
+		in, out := &in.ToolNames, &out.ToolNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludePatterns != nil {
+		in, out := &in.IncludePatterns, &out.IncludePatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludePatterns != nil {
+		in, out := &in.ExcludePatterns, &out.ExcludePatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DescriptionOverrides != nil {
+		in, out := &in.DescriptionOverrides, &out.DescriptionOverrides
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new McpServerTool.
+func (in *McpServerTool) DeepCopy() *McpServerTool {
+	if in == nil {
+		return nil
+	}
+	out := new(McpServerTool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObservedReference) DeepCopyInto(out *ObservedReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObservedReference.
+func (in *ObservedReference) DeepCopy() *ObservedReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ObservedReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrTermination) DeepCopyInto(out *OrTermination) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]OrTerminationCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrTermination.
+func (in *OrTermination) DeepCopy() *OrTermination {
+	if in == nil {
+		return nil
+	}
+	out := new(OrTermination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrTerminationCondition) DeepCopyInto(out *OrTerminationCondition) {
+	*out = *in
+	if in.MaxMessage != nil {
+		in, out := &in.MaxMessage, &out.MaxMessage
+		*out = new(MaxMessageTermination)
+		**out = **in
+	}
+	if in.TextMention != nil {
+		in, out := &in.TextMention, &out.TextMention
+		*out = new(TextMentionTermination)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrTerminationCondition.
+func (in *OrTerminationCondition) DeepCopy() *OrTerminationCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(OrTerminationCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelectorGroupChat) DeepCopyInto(out *SelectorGroupChat) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelectorGroupChat.
+func (in *SelectorGroupChat) DeepCopy() *SelectorGroupChat {
+	if in == nil {
+		return nil
+	}
+	out := new(SelectorGroupChat)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Team) DeepCopyInto(out *Team) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Team.
+func (in *Team) DeepCopy() *Team {
+	if in == nil {
+		return nil
+	}
+	out := new(Team)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Team) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamList) DeepCopyInto(out *TeamList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Team, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamList.
+func (in *TeamList) DeepCopy() *TeamList {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TeamList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamSpec) DeepCopyInto(out *TeamSpec) {
+	*out = *in
+	if in.Participants != nil {
+		in, out := &in.Participants, &out.Participants
+		*out = make([]TypedReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.ModelConfig != nil {
+		in, out := &in.ModelConfig, &out.ModelConfig
+		*out = new(TypedReference)
+		**out = **in
+	}
+	in.GroupChat.DeepCopyInto(&out.GroupChat)
+	in.TerminationCondition.DeepCopyInto(&out.TerminationCondition)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamSpec.
+func (in *TeamSpec) DeepCopy() *TeamSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamStatus) DeepCopyInto(out *TeamStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.References != nil {
+		in, out := &in.References, &out.References
+		*out = make([]ObservedReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamStatus.
+func (in *TeamStatus) DeepCopy() *TeamStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerminationCondition) DeepCopyInto(out *TerminationCondition) {
+	*out = *in
+	if in.MaxMessage != nil {
+		in, out := &in.MaxMessage, &out.MaxMessage
+		*out = new(MaxMessageTermination)
+		**out = **in
+	}
+	if in.TextMention != nil {
+		in, out := &in.TextMention, &out.TextMention
+		*out = new(TextMentionTermination)
+		**out = **in
+	}
+	if in.TextMessage != nil {
+		in, out := &in.TextMessage, &out.TextMessage
+		*out = new(TextMessageTermination)
+		**out = **in
+	}
+	if in.Or != nil {
+		in, out := &in.Or, &out.Or
+		*out = new(OrTermination)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerminationCondition.
+func (in *TerminationCondition) DeepCopy() *TerminationCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(TerminationCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TextMentionTermination) DeepCopyInto(out *TextMentionTermination) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TextMentionTermination.
+func (in *TextMentionTermination) DeepCopy() *TextMentionTermination {
+	if in == nil {
+		return nil
+	}
+	out := new(TextMentionTermination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TextMessageTermination) DeepCopyInto(out *TextMessageTermination) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TextMessageTermination.
+func (in *TextMessageTermination) DeepCopy() *TextMessageTermination {
+	if in == nil {
+		return nil
+	}
+	out := new(TextMessageTermination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tool) DeepCopyInto(out *Tool) {
+	*out = *in
+	if in.Builtin != nil {
+		in, out := &in.Builtin, &out.Builtin
+		*out = new(BuiltinTool)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.McpServer != nil {
+		in, out := &in.McpServer, &out.McpServer
+		*out = new(McpServerTool)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Agent != nil {
+		in, out := &in.Agent, &out.Agent
+		*out = new(AgentTool)
+		**out = **in
+	}
+	if in.Http != nil {
+		in, out := &in.Http, &out.Http
+		*out = new(HttpTool)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Approval != nil {
+		in, out := &in.Approval, &out.Approval
+		*out = new(ToolApproval)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tool.
+func (in *Tool) DeepCopy() *Tool {
+	if in == nil {
+		return nil
+	}
+	out := new(Tool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolApproval) DeepCopyInto(out *ToolApproval) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolApproval.
+func (in *ToolApproval) DeepCopy() *ToolApproval {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolApproval)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TypedReference) DeepCopyInto(out *TypedReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TypedReference.
+func (in *TypedReference) DeepCopy() *TypedReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TypedReference)
+	in.DeepCopyInto(out)
+	return out
+}
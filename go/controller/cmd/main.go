@@ -17,24 +17,23 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
-	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
-	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/types"
 
 	autogen_client "github.com/kagent-dev/kagent/go/autogen/client"
 
 	"github.com/kagent-dev/kagent/go/controller/internal/a2a"
 	"github.com/kagent-dev/kagent/go/controller/internal/autogen"
-	"github.com/kagent-dev/kagent/go/controller/internal/utils/syncutils"
 
 	"github.com/kagent-dev/kagent/go/controller/internal/httpserver"
+	"github.com/kagent-dev/kagent/go/controller/internal/mcpgateway"
 	utils_internal "github.com/kagent-dev/kagent/go/controller/internal/utils"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -48,6 +47,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/config"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -55,7 +55,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	agentv1alpha1 "github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	agentv1alpha2 "github.com/kagent-dev/kagent/go/controller/api/v1alpha2"
 	"github.com/kagent-dev/kagent/go/controller/internal/controller"
+	webhookv1alpha1 "github.com/kagent-dev/kagent/go/controller/internal/webhook/v1alpha1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -69,6 +71,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(agentv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(agentv1alpha2.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 
 	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
@@ -79,6 +82,7 @@ func main() {
 	var metricsAddr string
 	var metricsCertPath, metricsCertName, metricsCertKey string
 	var webhookCertPath, webhookCertName, webhookCertKey string
+	var webhookPort int
 	var enableLeaderElection bool
 	var probeAddr string
 	var secureMetrics bool
@@ -89,6 +93,12 @@ func main() {
 	var httpServerAddr string
 	var watchNamespaces string
 	var a2aBaseUrl string
+	var enableWebhooks bool
+	var maxConcurrentReconciles int
+	var driftResyncInterval time.Duration
+	var orphanCollectionInterval time.Duration
+	var orphanCollectionDryRun bool
+	var clusterDomain string
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -101,6 +111,9 @@ func main() {
 	flag.StringVar(&webhookCertPath, "webhook-cert-path", "", "The directory that contains the webhook certificate.")
 	flag.StringVar(&webhookCertName, "webhook-cert-name", "tls.crt", "The name of the webhook certificate file.")
 	flag.StringVar(&webhookCertKey, "webhook-cert-key", "tls.key", "The name of the webhook key file.")
+	flag.IntVar(&webhookPort, "webhook-port", webhook.DefaultPort, "The port the webhook server binds to.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"If set, the admission webhooks validating and defaulting the kagent resources, and the conversion webhook of Agents and Teams, are served. Requires the webhook certificates.")
 	flag.StringVar(&metricsCertPath, "metrics-cert-path", "",
 		"The directory that contains the metrics server certificate.")
 	flag.StringVar(&metricsCertName, "metrics-cert-name", "tls.crt", "The name of the metrics server certificate file.")
@@ -116,6 +129,19 @@ func main() {
 	flag.StringVar(&a2aBaseUrl, "a2a-base-url", "http://127.0.0.1:8083", "The base URL of the A2A Server endpoint, as advertised to clients.")
 
 	flag.StringVar(&watchNamespaces, "watch-namespaces", "", "The namespaces to watch for .")
+	flag.IntVar(&autogen.DefaultMaxAgentToolDepth, "max-agent-tool-depth", autogen.DefaultMaxAgentToolDepth,
+		"How deeply agents may be nested as tools of each other, unless the agent sets maxToolDepth.")
+	flag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 4,
+		"How many resources of each kind may be reconciled in parallel.")
+	flag.DurationVar(&driftResyncInterval, "drift-resync-interval", 5*time.Minute,
+		"How often the teams and tool servers in autogen are compared with the resources, to repair the changes made outside of kagent.")
+	flag.DurationVar(&orphanCollectionInterval, "orphan-collection-interval", 10*time.Minute,
+		"How often the teams and tool servers left in autogen by deleted resources are deleted.")
+	flag.BoolVar(&orphanCollectionDryRun, "orphan-collection-dry-run", false,
+		"If set, the teams and tool servers left in autogen by deleted resources are only logged instead of deleted.")
+	flag.StringVar(&clusterDomain, "cluster-domain", "",
+		"The domain of the cluster appended to the host names of the services exposing MCP servers, e.g. cluster.local. "+
+			"If empty, the host names end with .svc.")
 
 	opts := zap.Options{
 		Development: true,
@@ -166,6 +192,7 @@ func main() {
 	}
 
 	webhookServer := webhook.NewServer(webhook.Options{
+		Port:    webhookPort,
 		TLSOpts: webhookTLSOpts,
 	})
 
@@ -224,6 +251,9 @@ func main() {
 		Cache: cache.Options{
 			DefaultNamespaces: ConfigureNamespaceWatching(watchNamespaces),
 		},
+		Controller: config.Controller{
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+		},
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -241,22 +271,19 @@ func main() {
 		os.Exit(1)
 	}
 
-	// TODO(ilackarms): aliases for builtin autogen tools
-	builtinTools := syncutils.NewAtomicMap[string, string]()
-	builtinTools.Set("k8s-get-pod", "k8s.get_pod")
-
 	autogenClient := autogen_client.New(
 		autogenStudioBaseURL,
 	)
 
-	// wait for autogen to become ready on port 8081 before starting the manager
-	if err := waitForAutogenReady(setupLog, autogenClient, time.Minute*5, time.Second*5); err != nil {
-		setupLog.Error(err, "failed to wait for autogen to become ready")
+	kubeClient := mgr.GetClient()
+
+	// the manager starts even if autogen is unavailable, and the reconciles are held back until it is available
+	autogenAvailability := autogen.NewAutogenAvailability(autogenClient, kubeClient)
+	if err := mgr.Add(autogenAvailability); err != nil {
+		setupLog.Error(err, "unable to set up autogen availability probe")
 		os.Exit(1)
 	}
 
-	kubeClient := mgr.GetClient()
-
 	apiTranslator := autogen.NewAutogenApiTranslator(
 		kubeClient,
 		defaultModelConfig,
@@ -276,20 +303,35 @@ func main() {
 		autogenClient,
 		defaultModelConfig,
 		a2aReconciler,
+		mgr.GetEventRecorderFor("kagent-controller"),
+		autogenAvailability,
 	)
+	autogenAvailability.OnRecovered(func(ctx context.Context) {
+		// autogen may have lost the teams and tool servers while it was unavailable
+		if err := autogenReconciler.RepairDrift(ctx); err != nil {
+			setupLog.Error(err, "failed to repair the drift of autogen")
+		}
+	})
+
+	if err := autogen.SetupIndexes(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		setupLog.Error(err, "unable to set up field indexes")
+		os.Exit(1)
+	}
 
 	if err = (&controller.AutogenTeamReconciler{
-		Client:     kubeClient,
-		Scheme:     mgr.GetScheme(),
-		Reconciler: autogenReconciler,
+		Client:       kubeClient,
+		Scheme:       mgr.GetScheme(),
+		Reconciler:   autogenReconciler,
+		Availability: autogenAvailability,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AutogenTeam")
 		os.Exit(1)
 	}
 	if err = (&controller.AutogenAgentReconciler{
-		Client:     kubeClient,
-		Scheme:     mgr.GetScheme(),
-		Reconciler: autogenReconciler,
+		Client:       kubeClient,
+		Scheme:       mgr.GetScheme(),
+		Reconciler:   autogenReconciler,
+		Availability: autogenAvailability,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AutogenAgent")
 		os.Exit(1)
@@ -302,20 +344,38 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "AutogenModelConfig")
 		os.Exit(1)
 	}
-	if err = (&controller.AutogenSecretReconciler{
+	if err = (&controller.ToolServerReconciler{
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		Reconciler:   autogenReconciler,
+		Availability: autogenAvailability,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ToolServer")
+		os.Exit(1)
+	}
+	if err = (&controller.ServiceReconciler{
+		Client:        kubeClient,
+		Scheme:        mgr.GetScheme(),
+		Recorder:      mgr.GetEventRecorderFor("kagent-controller"),
+		ClusterDomain: clusterDomain,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Service")
+		os.Exit(1)
+	}
+	if err = (&controller.HttpToolSetReconciler{
 		Client:     kubeClient,
 		Scheme:     mgr.GetScheme(),
 		Reconciler: autogenReconciler,
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "AutogenSecret")
+		setupLog.Error(err, "unable to create controller", "controller", "HttpToolSet")
 		os.Exit(1)
 	}
-	if err = (&controller.ToolServerReconciler{
-		Client:     mgr.GetClient(),
+	if err = (&controller.RemoteAgentReconciler{
+		Client:     kubeClient,
 		Scheme:     mgr.GetScheme(),
 		Reconciler: autogenReconciler,
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "ToolServer")
+		setupLog.Error(err, "unable to create controller", "controller", "RemoteAgent")
 		os.Exit(1)
 	}
 	if err = (&controller.AutogenMemoryReconciler{
@@ -326,6 +386,36 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "Memory")
 		os.Exit(1)
 	}
+	if err := mgr.Add(autogen.DriftRepairer(autogenReconciler, driftResyncInterval)); err != nil {
+		setupLog.Error(err, "unable to set up drift repair")
+		os.Exit(1)
+	}
+	if err := mgr.Add(autogen.OrphanCollector(autogenReconciler, orphanCollectionInterval, orphanCollectionDryRun)); err != nil {
+		setupLog.Error(err, "unable to set up orphan collection")
+		os.Exit(1)
+	}
+	if enableWebhooks {
+		if err = webhookv1alpha1.SetupAgentWebhookWithManager(mgr, defaultModelConfig); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Agent")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupTeamWebhookWithManager(mgr, defaultModelConfig); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Team")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupModelConfigWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ModelConfig")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupToolServerWebhookWithManager(mgr, defaultModelConfig); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ToolServer")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupMemoryWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Memory")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 	if metricsCertWatcher != nil {
 		setupLog.Info("Adding metrics certificate watcher to manager")
@@ -351,12 +441,23 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	if err := mgr.AddReadyzCheck("autogen", autogenAvailability.Check); err != nil {
+		setupLog.Error(err, "unable to set up autogen ready check")
+		os.Exit(1)
+	}
 
+	authenticator := mcpgateway.NewTokenReviewAuthenticator(kubeClient)
 	httpServer := httpserver.NewHTTPServer(httpserver.ServerConfig{
 		BindAddr:      httpServerAddr,
 		AutogenClient: autogenClient,
 		KubeClient:    kubeClient,
 		A2AHandler:    a2aHandler,
+		MCPGateway: mcpgateway.NewGateway(
+			kubeClient,
+			autogenClient,
+			authenticator,
+		),
+		Authenticator: authenticator,
 	})
 	if err := mgr.Add(httpServer); err != nil {
 		setupLog.Error(err, "unable to set up HTTP server")
@@ -370,37 +471,6 @@ func main() {
 	}
 }
 
-func waitForAutogenReady(
-	log logr.Logger,
-	client autogen_client.Client,
-	timeout, interval time.Duration,
-) error {
-	log.Info("waiting for autogen to become ready")
-	return waitForReady(func() error {
-		version, err := client.GetVersion()
-		if err != nil {
-			log.Error(err, "autogen is not ready")
-			return err
-		}
-		log.Info("autogen is ready", "version", version)
-		return nil
-	}, timeout, interval)
-}
-
-func waitForReady(f func() error, timeout, interval time.Duration) error {
-	deadline := time.Now().Add(timeout)
-	for {
-		if time.Now().After(deadline) {
-			return fmt.Errorf("timed out after %v", timeout)
-		}
-		if err := f(); err == nil {
-			return nil
-		}
-
-		time.Sleep(interval)
-	}
-}
-
 // ConfigureNamespaceWatching sets up the controller manager to watch specific namespaces
 // based on the provided configuration. It returns the list of namespaces being watched,
 // or nil if watching all namespaces.
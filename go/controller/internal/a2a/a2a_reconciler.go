@@ -13,11 +13,13 @@ var (
 )
 
 type A2AReconciler interface {
+	// ReconcileAutogenAgent serves the A2A handler of the agent, and returns the URL it is advertised at,
+	// or an empty one if A2A is disabled for the agent
 	ReconcileAutogenAgent(
 		ctx context.Context,
 		agent *v1alpha1.Agent,
 		autogenTeam *autogen_client.Team,
-	) error
+	) (string, error)
 
 	ReconcileAutogenAgentDeletion(
 		agentNamespace string,
@@ -47,20 +49,24 @@ func (a *a2aReconciler) ReconcileAutogenAgent(
 	ctx context.Context,
 	agent *v1alpha1.Agent,
 	autogenTeam *autogen_client.Team,
-) error {
+) (string, error) {
 	params, err := a.a2aTranslator.TranslateHandlerForAgent(ctx, agent, autogenTeam)
 	if err != nil {
-		return err
+		return "", err
 	}
 	if params == nil {
 		reconcileLog.Info("No a2a handler found for agent, a2a will be disabled", "agent", agent.Name)
-		return nil
+		a.a2aHandler.RemoveAgentHandler(agent.Namespace, agent.Name)
+		return "", nil
 	}
 
-	return a.a2aHandler.SetAgentHandler(
+	if err := a.a2aHandler.SetAgentHandler(
 		agent.Namespace, agent.Name,
 		params,
-	)
+	); err != nil {
+		return "", err
+	}
+	return params.AgentCard.URL, nil
 }
 
 func (a *a2aReconciler) ReconcileAutogenAgentDeletion(
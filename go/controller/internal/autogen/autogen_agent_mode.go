@@ -0,0 +1,162 @@
+package autogen
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/kagent-dev/kagent/go/autogen/api"
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"github.com/kagent-dev/kagent/go/controller/internal/builtintools"
+)
+
+const (
+	dryRunToolProvider = "kagent.tools.common.DryRunTool"
+	// the discovered MCP tools are adapters for the transport of their ToolServer
+	mcpToolProviderPrefix = "autogen_ext.tools.mcp."
+)
+
+// ParseAgentMode returns the mode with the given name, defaulting to readWrite
+func ParseAgentMode(mode string) (v1alpha1.AgentMode, error) {
+	switch v1alpha1.AgentMode(mode) {
+	case "", v1alpha1.AgentMode_ReadWrite:
+		return v1alpha1.AgentMode_ReadWrite, nil
+	case v1alpha1.AgentMode_ReadOnly, v1alpha1.AgentMode_DryRun:
+		return v1alpha1.AgentMode(mode), nil
+	}
+	return "", fmt.Errorf("unknown agent mode %s, must be one of %s, %s or %s",
+		mode, v1alpha1.AgentMode_ReadWrite, v1alpha1.AgentMode_ReadOnly, v1alpha1.AgentMode_DryRun)
+}
+
+// ApplyAgentMode restricts the tools of the agents of a translated component to the given mode,
+// including the agents of the teams used as tools. readOnly removes the mutating tools and dryRun
+// wraps them so their calls are reported instead of run. Tools which are already restricted stay so,
+// so a mode can only make a component more restrictive.
+func ApplyAgentMode(component *api.Component, mode v1alpha1.AgentMode) error {
+	mode, err := ParseAgentMode(string(mode))
+	if err != nil {
+		return err
+	}
+	if mode == v1alpha1.AgentMode_ReadWrite || component == nil {
+		return nil
+	}
+
+	if component.ComponentType == "agent" {
+		if err := applyAgentModeToAgentConfig(component.Config, mode); err != nil {
+			return err
+		}
+	}
+	for _, value := range component.Config {
+		if err := applyAgentModeToValue(value, mode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyAgentModeToValue looks for the agents nested in a component config, e.g. the participants of a team
+func applyAgentModeToValue(value interface{}, mode v1alpha1.AgentMode) error {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		if value["component_type"] == "agent" {
+			if config, ok := value["config"].(map[string]interface{}); ok {
+				if err := applyAgentModeToAgentConfig(config, mode); err != nil {
+					return err
+				}
+			}
+		}
+		for _, nested := range value {
+			if err := applyAgentModeToValue(nested, mode); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, nested := range value {
+			if err := applyAgentModeToValue(nested, mode); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func applyAgentModeToAgentConfig(config map[string]interface{}, mode v1alpha1.AgentMode) error {
+	tools, ok := config["tools"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	restricted := []interface{}{}
+	for _, tool := range tools {
+		toolComponent, ok := tool.(map[string]interface{})
+		if !ok || !isMutatingTool(toolComponent) {
+			restricted = append(restricted, tool)
+			continue
+		}
+		if mode == v1alpha1.AgentMode_ReadOnly {
+			continue
+		}
+
+		byt, err := json.Marshal(toolComponent)
+		if err != nil {
+			return err
+		}
+		wrapped := &api.Component{}
+		if err := json.Unmarshal(byt, wrapped); err != nil {
+			return fmt.Errorf("invalid tool %v: %v", toolComponent["label"], err)
+		}
+		dryRunTool, err := translateDryRunTool(wrapped).ToConfig()
+		if err != nil {
+			return err
+		}
+		restricted = append(restricted, dryRunTool)
+	}
+	config["tools"] = restricted
+	return nil
+}
+
+// translateDryRunTool wraps the tool so that its calls are reported to the model instead of being run
+func translateDryRunTool(tool *api.Component) *api.Component {
+	return &api.Component{
+		Provider:      dryRunToolProvider,
+		ComponentType: "tool",
+		Version:       1,
+		Description:   tool.Description,
+		Label:         tool.Label,
+		Config: api.MustToConfig(&api.DryRunToolConfig{
+			Tool: tool,
+		}),
+	}
+}
+
+// isMutatingTool returns whether a translated tool may change the state of the systems it accesses.
+// Tools whose effects are unknown are considered mutating.
+func isMutatingTool(tool map[string]interface{}) bool {
+	provider, _ := tool["provider"].(string)
+	config, _ := tool["config"].(map[string]interface{})
+
+	switch {
+	case provider == dryRunToolProvider:
+		return false
+	case provider == "autogen_agentchat.tools.TeamTool":
+		// the tools of the agents of the team are restricted on their own
+		return false
+	case provider == "kagent.tools.common.ApprovalTool", provider == "kagent.tools.common.RenamedTool":
+		wrapped, ok := config["tool"].(map[string]interface{})
+		return !ok || isMutatingTool(wrapped)
+	case provider == httpToolProvider:
+		method, _ := config["method"].(string)
+		return !strings.EqualFold(method, "GET")
+	case strings.HasPrefix(provider, mcpToolProviderPrefix):
+		// MCP tools may change anything unless the server annotates them as read-only
+		mcpTool, _ := config["tool"].(map[string]interface{})
+		annotations, _ := mcpTool["annotations"].(map[string]interface{})
+		readOnly, _ := annotations["readOnlyHint"].(bool)
+		return !readOnly
+	}
+
+	if builtinTool, ok := builtintools.Default().Get(provider); ok {
+		return builtinTool.Mutating
+	}
+	return true
+}
@@ -4,14 +4,19 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"path"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/kagent-dev/kagent/go/autogen/api"
 	autogen_client "github.com/kagent-dev/kagent/go/autogen/client"
 	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"github.com/kagent-dev/kagent/go/controller/internal/builtintools"
 	common "github.com/kagent-dev/kagent/go/controller/internal/utils"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
@@ -20,45 +25,58 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-var (
-	// hard-coded array of tools that require a model client
-	// this is automatically populated from the parent agent's model client
-	toolsProvidersRequiringModelClient = []string{
-		"kagent.tools.prometheus.GeneratePromQLTool",
-		"kagent.tools.k8s.GenerateResourceTool",
-	}
-	toolsProvidersRequiringOpenaiApiKey = []string{
-		"kagent.tools.docs.QueryTool",
-	}
-)
-
 type ApiTranslator interface {
 	TranslateGroupChatForTeam(
 		ctx context.Context,
 		team *v1alpha1.Team,
-	) (*autogen_client.Team, error)
+	) (*TranslatedTeam, error)
 
 	TranslateGroupChatForAgent(
 		ctx context.Context,
 		agent *v1alpha1.Agent,
-	) (*autogen_client.Team, error)
+	) (*TranslatedTeam, error)
 
 	TranslateToolServer(ctx context.Context, toolServer *v1alpha1.ToolServer) (*autogen_client.ToolServer, error)
+
+	// TranslateHttpToolSet returns the HTTP tools generated from the operations of the OpenAPI document of the tool set
+	TranslateHttpToolSet(ctx context.Context, toolSet *v1alpha1.HttpToolSet) ([]*api.Component, error)
+
+	// FetchRemoteAgentCard fetches the A2A agent card of the remote agent
+	FetchRemoteAgentCard(ctx context.Context, remoteAgent *v1alpha1.RemoteAgent) (*v1alpha1.RemoteAgentCard, error)
+
+	// ResolveAgentTools returns the names of the tools provided to the agent, after resolving all tool references
+	ResolveAgentTools(ctx context.Context, agent *v1alpha1.Agent) ([]string, error)
 }
 
 type apiTranslator struct {
-	kube               client.Client
-	defaultModelConfig types.NamespacedName
+	kube                 client.Client
+	defaultModelConfig   types.NamespacedName
+	oauth2Tokens         *oauth2TokenCache
+	serviceAccountTokens *serviceAccountTokenCache
+	withoutRemoteCalls   bool
+}
+
+// TranslatorOption configures an ApiTranslator
+type TranslatorOption func(*apiTranslator)
+
+// WithoutRemoteCalls makes the translator skip the calls to remote systems, for the dry-run translations
+// validating resources at admission, which must answer quickly even if these systems are slow or down.
+// The OpenAPI documents of HttpToolSets are the ones the controller downloaded last, and no token is minted
+// for the ServiceAccounts of agents nor requested from the OAuth2 servers of tool servers.
+func WithoutRemoteCalls() TranslatorOption {
+	return func(a *apiTranslator) {
+		a.withoutRemoteCalls = true
+	}
 }
 
 func (a *apiTranslator) TranslateToolServer(ctx context.Context, toolServer *v1alpha1.ToolServer) (*autogen_client.ToolServer, error) {
 	// provder = "kagent.tool_servers.StdioMcpToolServer" || "kagent.tool_servers.SseMcpToolServer"
-	provider, toolServerConfig, err := a.translateToolServerConfig(ctx, toolServer.Spec.Config, toolServer.Namespace)
+	provider, toolServerConfig, err := a.translateToolServerConfig(ctx, toolServer, true)
 	if err != nil {
 		return nil, err
 	}
 
-	return &autogen_client.ToolServer{
+	autogenToolServer := &autogen_client.ToolServer{
 		UserID: common.GetGlobalUserID(),
 		Component: api.Component{
 			Provider:      provider,
@@ -68,7 +86,61 @@ func (a *apiTranslator) TranslateToolServer(ctx context.Context, toolServer *v1a
 			Label:         toolServer.Name,
 			Config:        api.MustToConfig(toolServerConfig),
 		},
-	}, nil
+	}
+	markKagentManaged(&autogenToolServer.Component)
+	return autogenToolServer, nil
+}
+
+func (a *apiTranslator) ResolveAgentTools(ctx context.Context, agent *v1alpha1.Agent) ([]string, error) {
+	var toolNames []string
+	for _, tool := range agent.Spec.Tools {
+		switch {
+		case tool.Builtin != nil:
+			builtinTool, _, err := builtintools.Default().Validate(tool.Builtin)
+			if err != nil {
+				return nil, err
+			}
+			toolNames = append(toolNames, getToolName(&api.Component{Provider: builtinTool.Name}))
+		case tool.McpServer != nil:
+			_, resolvedTools, err := resolveToolServerTools(ctx, a.kube, tool.McpServer, agent.Namespace)
+			if err != nil {
+				return nil, err
+			}
+			for _, resolvedTool := range resolvedTools {
+				toolNames = append(toolNames, resolvedTool.name)
+			}
+		case tool.Http != nil:
+			httpTools, err := a.translateHttpTools(ctx, tool.Http, agent.Namespace)
+			if err != nil {
+				return nil, err
+			}
+			for _, httpTool := range httpTools {
+				toolNames = append(toolNames, getToolName(httpTool))
+			}
+		case tool.Agent != nil:
+			toolNames = append(toolNames, getRefFromString(tool.Agent.Ref, agent.Namespace).Name)
+		case tool.RemoteAgent != nil:
+			toolNames = append(toolNames, convertToPythonIdentifier(getRefFromString(tool.RemoteAgent.Ref, agent.Namespace).Name))
+		}
+	}
+	return toolNames, nil
+}
+
+// resolveHeaders resolves the values of the headers sent to an HTTP server
+func (a *apiTranslator) resolveHeaders(ctx context.Context, headersFrom []v1alpha1.ValueRef, namespace string) (map[string]string, error) {
+	headers := map[string]string{}
+	for _, header := range headersFrom {
+		if header.ValueFrom != nil {
+			value, err := a.resolveValueSource(ctx, header.ValueFrom, namespace)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve header %s: %v", header.Name, err)
+			}
+			headers[header.Name] = value
+		} else if header.Value != "" {
+			headers[header.Name] = header.Value
+		}
+	}
+	return headers, nil
 }
 
 // resolveValueSource resolves a value from a ValueSource
@@ -137,7 +209,15 @@ func (a *apiTranslator) getSecretValue(ctx context.Context, source *v1alpha1.Val
 	return string(value), nil
 }
 
-func (a *apiTranslator) translateToolServerConfig(ctx context.Context, config v1alpha1.ToolServerConfig, namespace string) (string, *api.ToolServerConfig, error) {
+// translateToolServerConfig returns the provider and the config of the tool server in autogen,
+// requesting a new oauth2 token for it if requestOAuth2Token is set
+func (a *apiTranslator) translateToolServerConfig(
+	ctx context.Context,
+	toolServer *v1alpha1.ToolServer,
+	requestOAuth2Token bool,
+) (string, *api.ToolServerConfig, error) {
+	config := toolServer.Spec.Config
+	namespace := toolServer.Namespace
 	switch {
 	case config.Stdio != nil:
 		env := make(map[string]string)
@@ -202,12 +282,23 @@ func (a *apiTranslator) translateToolServerConfig(ctx context.Context, config v1
 			return "", nil, err
 		}
 
+		var tlsConfig *api.McpTLSConfig
+		var headersFrom map[string]api.McpSecretKeyRef
+		if config.Sse.Auth != nil {
+			tlsConfig, headersFrom, err = a.translateToolServerAuth(ctx, toolServer, config.Sse.Auth, requestOAuth2Token)
+			if err != nil {
+				return "", nil, err
+			}
+		}
+
 		return "kagent.tool_servers.SseMcpToolServer", &api.ToolServerConfig{
 			SseMcpServerConfig: &api.SseMcpServerConfig{
 				URL:            config.Sse.URL,
 				Headers:        headers,
+				HeadersFrom:    headersFrom,
 				Timeout:        timeout,
 				SseReadTimeout: sseReadTimeout,
+				TLS:            tlsConfig,
 			},
 		}, nil
 	}
@@ -229,53 +320,141 @@ func convertDurationToSeconds(timeout string) (int, error) {
 func NewAutogenApiTranslator(
 	kube client.Client,
 	defaultModelConfig types.NamespacedName,
+	opts ...TranslatorOption,
 ) ApiTranslator {
-	return &apiTranslator{
-		kube:               kube,
-		defaultModelConfig: defaultModelConfig,
+	a := &apiTranslator{
+		kube:                 kube,
+		defaultModelConfig:   defaultModelConfig,
+		oauth2Tokens:         newOAuth2TokenCache(),
+		serviceAccountTokens: newServiceAccountTokenCache(),
+	}
+	for _, opt := range opts {
+		opt(a)
 	}
+	return a
+}
+
+// TranslatedTeam is the autogen team translated for an agent or a team
+type TranslatedTeam struct {
+	*autogen_client.Team
+	// UsesServiceAccountToken is set when a token minted for the ServiceAccount of an agent of the tree is embedded
+	// in the team, which must then be translated again before the token expires
+	UsesServiceAccountToken bool `json:"-"`
 }
 
-func (a *apiTranslator) TranslateGroupChatForAgent(ctx context.Context, agent *v1alpha1.Agent) (*autogen_client.Team, error) {
+func (a *apiTranslator) TranslateGroupChatForAgent(ctx context.Context, agent *v1alpha1.Agent) (*TranslatedTeam, error) {
 	stream := true
 	if agent.Spec.Stream != nil {
 		stream = *agent.Spec.Stream
 	}
 	opts := defaultTeamOptions()
 	opts.stream = stream
-	return a.translateGroupChatForAgent(ctx, agent, opts, &tState{})
+	var maxDepth int32
+	if agent.Spec.MaxToolDepth != nil {
+		maxDepth = *agent.Spec.MaxToolDepth
+	}
+	state := newTState(maxDepth)
+	autogenTeam, err := a.translateGroupChatForAgent(ctx, agent, opts, state)
+	if err != nil {
+		return nil, err
+	}
+	markKagentManaged(autogenTeam.Component)
+	return &TranslatedTeam{Team: autogenTeam, UsesServiceAccountToken: state.tokens.usesServiceAccountToken}, nil
 }
 
 func (a *apiTranslator) TranslateGroupChatForTeam(
 	ctx context.Context,
 	team *v1alpha1.Team,
-) (*autogen_client.Team, error) {
-	return a.translateGroupChatForTeam(ctx, team, defaultTeamOptions(), &tState{})
+) (*TranslatedTeam, error) {
+	state := newTState(0)
+	autogenTeam, err := a.translateGroupChatForTeam(ctx, team, defaultTeamOptions(), state.withTeam(team))
+	if err != nil {
+		return nil, err
+	}
+	markKagentManaged(autogenTeam.Component)
+	return &TranslatedTeam{Team: autogenTeam, UsesServiceAccountToken: state.tokens.usesServiceAccountToken}, nil
 }
 
 type teamOptions struct {
 	stream bool
 }
 
-const MAX_DEPTH = 10
+// DefaultMaxAgentToolDepth is how deeply agents may be nested as tools of each other, unless the agent overrides it
+var DefaultMaxAgentToolDepth = 10
+
+// DefaultApprovalTimeout is how long a call of a tool requiring an approval waits for it, unless the tool reference overrides it
+const DefaultApprovalTimeout = 10 * time.Minute
 
+// AgentToolChainError is returned when the agents used as tools form a cycle, or a chain deeper than the limit
+type AgentToolChainError struct {
+	// Chain lists the teams and agents traversed, from the translated resource to the offending agent
+	Chain []string
+	// MaxDepth is the exceeded limit, or 0 if the chain is a cycle
+	MaxDepth int
+}
+
+func (e *AgentToolChainError) Error() string {
+	chain := strings.Join(e.Chain, " -> ")
+	if e.IsCycle() {
+		return fmt.Sprintf("cycle detected in agent tool chain: %s", chain)
+	}
+	return fmt.Sprintf("agent tool chain exceeds the maximum depth of %d: %s", e.MaxDepth, chain)
+}
+
+// IsCycle returns whether the chain loops back to an agent it already traversed
+func (e *AgentToolChainError) IsCycle() bool {
+	return e.MaxDepth == 0
+}
+
+// tState is the chain of teams and agents leading to the agent being translated.
+// It is copied on each step, so that the siblings of a team or of the tools of an agent don't see each other.
 type tState struct {
-	// used to prevent infinite loops
-	// The recursion limit is 10
-	depth uint8
-	// used to enforce DAG
-	// The final member of the list will be the "parent" agent
-	visitedAgents []string
+	// the limit of the agent or team being translated applies to the whole chain
+	maxDepth int
+	// the number of agents nested as tools, the participants of the translated team being at depth 0
+	depth int
+	chain []string
+	// the namespaced names of the agents of the chain, used to enforce a DAG
+	agents []string
+	// the tokens embedded in the whole tree, shared by the states of all its agents
+	tokens *tTokens
+}
+
+// tTokens records the credentials embedded in a translated tree which expire
+type tTokens struct {
+	usesServiceAccountToken bool
+}
+
+func newTState(maxDepth int32) *tState {
+	if maxDepth <= 0 {
+		maxDepth = int32(DefaultMaxAgentToolDepth)
+	}
+	return &tState{maxDepth: int(maxDepth), tokens: &tTokens{}}
 }
 
-func (s *tState) with(agent *v1alpha1.Agent) *tState {
-	s.depth++
-	s.visitedAgents = append(s.visitedAgents, agent.Name)
-	return s
+// withTeam returns the state of the participants of a team
+func (s *tState) withTeam(team *v1alpha1.Team) *tState {
+	next := *s
+	next.chain = append(slices.Clone(s.chain), fmt.Sprintf("Team %s/%s", team.Namespace, team.Name))
+	return &next
 }
 
-func (t *tState) isVisited(agentName string) bool {
-	return slices.Contains(t.visitedAgents, agentName)
+// withAgent returns the state of the tools of an agent, or an error if the agent closes a cycle or is nested too deeply
+func (s *tState) withAgent(agent *v1alpha1.Agent) (*tState, error) {
+	key := agent.Namespace + "/" + agent.Name
+	next := *s
+	next.chain = append(slices.Clone(s.chain), "Agent "+key)
+	if len(s.agents) > 0 {
+		next.depth++
+	}
+	if slices.Contains(s.agents, key) {
+		return nil, &AgentToolChainError{Chain: next.chain}
+	}
+	if next.depth > s.maxDepth {
+		return nil, &AgentToolChainError{Chain: next.chain, MaxDepth: s.maxDepth}
+	}
+	next.agents = append(slices.Clone(s.agents), key)
+	return &next, nil
 }
 
 func defaultTeamOptions() *teamOptions {
@@ -509,6 +688,19 @@ func (a *apiTranslator) translateAssistantAgent(
 	opts *teamOptions,
 	state *tState,
 ) (*api.Component, error) {
+	state, err := state.withAgent(agent)
+	if err != nil {
+		return nil, err
+	}
+
+	// a single token is minted for all the tools of the agent, and only if one of them accesses the cluster
+	kubeToken := sync.OnceValues(func() (string, error) {
+		token, err := a.serviceAccountToken(ctx, agent)
+		if token != "" {
+			state.tokens.usesServiceAccountToken = true
+		}
+		return token, err
+	})
 
 	tools := []*api.Component{}
 	for _, tool := range agent.Spec.Tools {
@@ -519,38 +711,68 @@ func (a *apiTranslator) translateAssistantAgent(
 				modelClientWithoutStreaming,
 				modelConfig,
 				tool.Builtin,
+				kubeToken,
 			)
 			if err != nil {
 				return nil, err
 			}
+			autogenTool, err = translateToolApproval(tool, agent, autogenTool)
+			if err != nil {
+				return nil, err
+			}
 			tools = append(tools, autogenTool)
 		case tool.McpServer != nil:
-			for _, toolName := range tool.McpServer.ToolNames {
-				autogenTool, err := translateToolServerTool(
-					ctx,
-					a.kube,
-					tool.McpServer.ToolServer,
-					toolName,
-					agent.Namespace,
-				)
+			toolServer, resolvedTools, err := resolveToolServerTools(
+				ctx,
+				a.kube,
+				tool.McpServer,
+				agent.Namespace,
+			)
+			if err != nil {
+				return nil, err
+			}
+			serverCredentials, err := a.toolServerCredentials(ctx, toolServer)
+			if err != nil {
+				return nil, err
+			}
+			for _, resolvedTool := range resolvedTools {
+				autogenTool, err := translateToolServerTool(resolvedTool, serverCredentials)
+				if err != nil {
+					return nil, err
+				}
+				autogenTool, err = translateToolApproval(tool, agent, autogenTool)
 				if err != nil {
 					return nil, err
 				}
 				tools = append(tools, autogenTool)
 			}
+		case tool.Http != nil:
+			httpTools, err := a.translateHttpTools(ctx, tool.Http, agent.Namespace)
+			if err != nil {
+				return nil, err
+			}
+			for _, autogenTool := range httpTools {
+				autogenTool, err = translateToolApproval(tool, agent, autogenTool)
+				if err != nil {
+					return nil, err
+				}
+				tools = append(tools, autogenTool)
+			}
+		case tool.RemoteAgent != nil:
+			autogenTool, err := a.translateRemoteAgentTool(ctx, tool.RemoteAgent, agent.Namespace)
+			if err != nil {
+				return nil, err
+			}
+			autogenTool, err = translateToolApproval(tool, agent, autogenTool)
+			if err != nil {
+				return nil, err
+			}
+			tools = append(tools, autogenTool)
 		case tool.Agent != nil:
 			if tool.Agent.Ref == agent.Name {
 				return nil, fmt.Errorf("agent tool cannot be used to reference itself, %s", agent.Name)
 			}
 
-			if state.isVisited(tool.Agent.Ref) {
-				return nil, fmt.Errorf("cycle detected in agent tool chain: %s -> %s", agent.Name, tool.Agent.Ref)
-			}
-
-			if state.depth > MAX_DEPTH {
-				return nil, fmt.Errorf("recursion limit reached in agent tool chain: %s -> %s", agent.Name, tool.Agent.Ref)
-			}
-
 			// Translate a nested tool
 			toolAgent := v1alpha1.Agent{}
 
@@ -569,7 +791,7 @@ func (a *apiTranslator) translateAssistantAgent(
 			if err != nil {
 				return nil, err
 			}
-			autogenTool, err := a.translateGroupChatForTeam(ctx, team, &teamOptions{}, state.with(agent))
+			autogenTool, err := a.translateGroupChatForTeam(ctx, team, &teamOptions{}, state)
 			if err != nil {
 				return nil, err
 			}
@@ -592,6 +814,10 @@ func (a *apiTranslator) translateAssistantAgent(
 		}
 	}
 
+	if err := validateUniqueToolNames(tools); err != nil {
+		return nil, fmt.Errorf("invalid tools for agent %s: %v", agent.Name, err)
+	}
+
 	sysMsg := agent.Spec.SystemMessage
 
 	cfg := &api.AssistantAgentConfig{
@@ -624,13 +850,27 @@ func (a *apiTranslator) translateAssistantAgent(
 		}
 	}
 
-	return &api.Component{
+	for _, mcpContext := range agent.Spec.McpContext {
+		autogenMemory, err := a.translateMcpContext(ctx, mcpContext, agent.Namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg.Memory = append(cfg.Memory, autogenMemory)
+	}
+
+	assistantAgent := &api.Component{
 		Provider:      "autogen_agentchat.agents.AssistantAgent",
 		ComponentType: "agent",
 		Version:       1,
 		Description:   agent.Spec.Description,
 		Config:        api.MustToConfig(cfg),
-	}, nil
+	}
+	if err := ApplyAgentMode(assistantAgent, agent.Spec.Mode); err != nil {
+		return nil, fmt.Errorf("invalid mode for agent %s: %v", agent.Name, err)
+	}
+
+	return assistantAgent, nil
 }
 
 func (a *apiTranslator) translateMemory(ctx context.Context, memoryName string, memoryNamespace string) (*api.Component, error) {
@@ -670,24 +910,106 @@ func (a *apiTranslator) translateMemory(ctx context.Context, memoryName string,
 	return nil, fmt.Errorf("unsupported memory provider: %s", memoryObj.Spec.Provider)
 }
 
+// translateMcpContext translates the resources and prompts of a ToolServer into a memory
+// which adds them to the context of the agent
+func (a *apiTranslator) translateMcpContext(ctx context.Context, mcpContext *v1alpha1.McpServerContext, agentNamespace string) (*api.Component, error) {
+	toolServer := &v1alpha1.ToolServer{}
+	err := fetchObjKube(ctx, a.kube, toolServer, mcpContext.ToolServer, agentNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	// requires the resources and prompts to have been discovered
+	for _, uri := range mcpContext.Resources {
+		if !slices.ContainsFunc(toolServer.Status.DiscoveredResources, func(resource *v1alpha1.MCPResource) bool {
+			return resource.URI == uri
+		}) {
+			return nil, fmt.Errorf("resource %v not found in discovered resources in ToolServer %v", uri, toolServer.Name)
+		}
+	}
+
+	prompts := make([]api.McpPromptConfig, 0, len(mcpContext.Prompts))
+	for _, promptRef := range mcpContext.Prompts {
+		idx := slices.IndexFunc(toolServer.Status.DiscoveredPrompts, func(prompt *v1alpha1.MCPPrompt) bool {
+			return prompt.Name == promptRef.Name
+		})
+		if idx < 0 {
+			return nil, fmt.Errorf("prompt %v not found in discovered prompts in ToolServer %v", promptRef.Name, toolServer.Name)
+		}
+		for _, argument := range toolServer.Status.DiscoveredPrompts[idx].Arguments {
+			if _, ok := promptRef.Arguments[argument.Name]; argument.Required && !ok {
+				return nil, fmt.Errorf("missing required argument %v for prompt %v in ToolServer %v", argument.Name, promptRef.Name, toolServer.Name)
+			}
+		}
+		prompts = append(prompts, api.McpPromptConfig{
+			Name:      promptRef.Name,
+			Arguments: promptRef.Arguments,
+		})
+	}
+
+	_, toolServerConfig, err := a.translateToolServerConfig(ctx, toolServer, false)
+	if err != nil {
+		return nil, err
+	}
+	serverParams, err := toolServerConfig.ToConfig()
+	if err != nil {
+		return nil, err
+	}
+	// the server params are a discriminated union in autogen
+	var tlsConfig *api.McpTLSConfig
+	var headersFrom map[string]api.McpSecretKeyRef
+	if toolServerConfig.StdioMcpServerConfig != nil {
+		serverParams["type"] = "StdioServerParams"
+	} else {
+		serverParams["type"] = "SseServerParams"
+		tlsConfig = toolServerConfig.SseMcpServerConfig.TLS
+		headersFrom = toolServerConfig.SseMcpServerConfig.HeadersFrom
+		delete(serverParams, "tls")
+		delete(serverParams, "headers_from")
+	}
+
+	return &api.Component{
+		Provider:      "kagent.memory.McpContextMemory",
+		ComponentType: "memory",
+		Version:       1,
+		Label:         toolServer.Name,
+		Config: api.MustToConfig(&api.McpContextMemoryConfig{
+			ServerParams: serverParams,
+			Resources:    mcpContext.Resources,
+			Prompts:      prompts,
+			TLS:          tlsConfig,
+			HeadersFrom:  headersFrom,
+		}),
+	}, nil
+}
+
 func (a *apiTranslator) translateBuiltinTool(
 	ctx context.Context,
 	modelClient *api.Component,
 	modelConfig *v1alpha1.ModelConfig,
 	tool *v1alpha1.BuiltinTool,
+	kubeToken func() (string, error),
 ) (*api.Component, error) {
+	builtinTool, warnings, err := builtintools.Default().Validate(tool)
+	if err != nil {
+		return nil, err
+	}
+	// the unknown keys are passed on unchanged, the admission webhook warns about them too
+	for _, warning := range warnings {
+		reconcileLog.Info(warning)
+	}
 
 	toolConfig, err := convertMapFromAnytype(tool.Config)
 	if err != nil {
 		return nil, err
 	}
 	// special case where we put the model client in the tool config
-	if toolNeedsModelClient(tool.Name) {
+	if builtinTool.NeedsModelClient {
 		if err := addModelClientToConfig(modelClient, &toolConfig); err != nil {
 			return nil, fmt.Errorf("failed to add model client to tool config: %v", err)
 		}
 	}
-	if toolNeedsOpenaiApiKey(tool.Name) {
+	if builtinTool.NeedsOpenAIKey {
 		if (modelConfig.Spec.Provider != v1alpha1.OpenAI) && modelConfig.Spec.Provider != v1alpha1.AzureOpenAI {
 			return nil, fmt.Errorf("tool %s requires OpenAI API key, but model config is not OpenAI", tool.Name)
 		}
@@ -701,11 +1023,25 @@ func (a *apiTranslator) translateBuiltinTool(
 		}
 	}
 
-	providerParts := strings.Split(tool.Name, ".")
+	// the tools which access the cluster use the service account of the agent, if it has one
+	if builtinTool.NeedsKubeToken {
+		token, err := kubeToken()
+		if err != nil {
+			return nil, err
+		}
+		if token != "" {
+			if err := addKubeTokenToConfig(token, &toolConfig); err != nil {
+				return nil, fmt.Errorf("failed to add kube token to tool config: %v", err)
+			}
+		}
+	}
+
+	// aliases are resolved so the provider is always importable
+	providerParts := strings.Split(builtinTool.Name, ".")
 	toolLabel := providerParts[len(providerParts)-1]
 
 	return &api.Component{
-		Provider:      tool.Name,
+		Provider:      builtinTool.Name,
 		ComponentType: "tool",
 		Version:       1,
 		Config:        toolConfig,
@@ -713,33 +1049,259 @@ func (a *apiTranslator) translateBuiltinTool(
 	}, nil
 }
 
-func translateToolServerTool(
+// resolvedMcpTool is a tool discovered on a ToolServer which was selected by an McpServerTool reference
+type resolvedMcpTool struct {
+	// the name exposed to the model, including any prefix
+	name string
+	// replaces the description advertised by the ToolServer, if set
+	descriptionOverride string
+	// the tool as discovered on the ToolServer
+	discoveredTool *v1alpha1.MCPTool
+}
+
+// resolveToolServerTools returns the referenced ToolServer and selects the tools discovered on it
+// using the tool names and patterns of the McpServerTool, in discovery order
+func resolveToolServerTools(
 	ctx context.Context,
 	kube client.Client,
-	toolServerName string,
-	toolName string,
+	mcpServerTool *v1alpha1.McpServerTool,
 	agentNamespace string,
-) (*api.Component, error) {
+) (*v1alpha1.ToolServer, []*resolvedMcpTool, error) {
 	toolServer := &v1alpha1.ToolServer{}
 	err := fetchObjKube(
 		ctx,
 		kube,
 		toolServer,
-		toolServerName,
+		mcpServerTool.ToolServer,
 		agentNamespace,
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	includeMatchers, err := compileToolPatterns(mcpServerTool.PatternType, mcpServerTool.IncludePatterns)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid include pattern for ToolServer %v: %v", toolServer.Name, err)
+	}
+	excludeMatchers, err := compileToolPatterns(mcpServerTool.PatternType, mcpServerTool.ExcludePatterns)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid exclude pattern for ToolServer %v: %v", toolServer.Name, err)
 	}
 
-	// requires the tool to have been discovered
+	// requires the tools to have been discovered
+	for _, toolName := range mcpServerTool.ToolNames {
+		if !slices.ContainsFunc(toolServer.Status.DiscoveredTools, func(discoveredTool *v1alpha1.MCPTool) bool {
+			return discoveredTool.Name == toolName
+		}) {
+			return nil, nil, fmt.Errorf("tool %v not found in discovered tools in ToolServer %v", toolName, toolServer.Name)
+		}
+	}
+
+	var resolvedTools []*resolvedMcpTool
 	for _, discoveredTool := range toolServer.Status.DiscoveredTools {
-		if discoveredTool.Name == toolName {
-			return convertComponent(discoveredTool.Component)
+		selected := slices.Contains(mcpServerTool.ToolNames, discoveredTool.Name) ||
+			matchesAnyToolPattern(includeMatchers, discoveredTool.Name)
+		if !selected || matchesAnyToolPattern(excludeMatchers, discoveredTool.Name) {
+			continue
+		}
+
+		resolvedTools = append(resolvedTools, &resolvedMcpTool{
+			name:                mcpServerTool.NamePrefix + discoveredTool.Name,
+			descriptionOverride: mcpServerTool.DescriptionOverrides[discoveredTool.Name],
+			discoveredTool:      discoveredTool,
+		})
+	}
+
+	return toolServer, resolvedTools, nil
+}
+
+// credentialServerParams are the server params of MCP tools holding credentials or references to them
+var credentialServerParams = []string{"headers", "headers_from", "tls", "env"}
+
+// withoutServerCredentials returns a copy of the config of an MCP tool without the credentials in its server params
+func withoutServerCredentials(config map[string]interface{}) map[string]interface{} {
+	serverParams, ok := config["server_params"].(map[string]interface{})
+	if !ok {
+		return config
+	}
+	strippedParams := maps.Clone(serverParams)
+	for _, param := range credentialServerParams {
+		delete(strippedParams, param)
+	}
+	strippedConfig := maps.Clone(config)
+	strippedConfig["server_params"] = strippedParams
+	return strippedConfig
+}
+
+// toolServerCredentials returns the server params of the tool server holding credentials,
+// which are added to its tools as they are removed from the tools in its status
+func (a *apiTranslator) toolServerCredentials(ctx context.Context, toolServer *v1alpha1.ToolServer) (map[string]interface{}, error) {
+	_, toolServerConfig, err := a.translateToolServerConfig(ctx, toolServer, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate ToolServer %v: %v", toolServer.Name, err)
+	}
+	serverParams, err := toolServerConfig.ToConfig()
+	if err != nil {
+		return nil, err
+	}
+	credentials := map[string]interface{}{}
+	for _, param := range credentialServerParams {
+		if value, ok := serverParams[param]; ok {
+			credentials[param] = value
 		}
 	}
+	return credentials, nil
+}
 
-	return nil, fmt.Errorf("tool %v not found in discovered tools in ToolServer %v", toolName, toolServer.Name)
+// compileToolPatterns converts glob or regex patterns into matcher functions
+func compileToolPatterns(patternType v1alpha1.ToolPatternType, patterns []string) ([]func(string) bool, error) {
+	var matchers []func(string) bool
+	for _, pattern := range patterns {
+		switch patternType {
+		case v1alpha1.ToolPatternType_Regex:
+			// regex patterns must match the whole tool name
+			re, err := regexp.Compile("^(?:" + pattern + ")$")
+			if err != nil {
+				return nil, fmt.Errorf("failed to compile regex %q: %v", pattern, err)
+			}
+			matchers = append(matchers, re.MatchString)
+		case v1alpha1.ToolPatternType_Glob, "":
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("failed to parse glob %q: %v", pattern, err)
+			}
+			matchers = append(matchers, func(name string) bool {
+				matched, _ := path.Match(pattern, name)
+				return matched
+			})
+		default:
+			return nil, fmt.Errorf("unknown pattern type %v", patternType)
+		}
+	}
+	return matchers, nil
+}
+
+func matchesAnyToolPattern(matchers []func(string) bool, name string) bool {
+	for _, match := range matchers {
+		if match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func translateToolServerTool(resolvedTool *resolvedMcpTool, serverCredentials map[string]interface{}) (*api.Component, error) {
+	autogenTool, err := convertComponent(resolvedTool.discoveredTool.Component)
+	if err != nil {
+		return nil, err
+	}
+
+	// the tools in the status of the tool server have no credentials, the current ones of the tool server are added
+	if _, ok := autogenTool.Config["server_params"].(map[string]interface{}); ok {
+		autogenTool.Config = withoutServerCredentials(autogenTool.Config)
+		maps.Copy(autogenTool.Config["server_params"].(map[string]interface{}), serverCredentials)
+	}
+
+	// the description seen by the model is the one embedded in the mcp tool config
+	if resolvedTool.descriptionOverride != "" {
+		autogenTool.Description = resolvedTool.descriptionOverride
+		if toolConfig, ok := autogenTool.Config["tool"].(map[string]interface{}); ok {
+			toolConfig["description"] = resolvedTool.descriptionOverride
+		}
+	}
+
+	if resolvedTool.name == resolvedTool.discoveredTool.Name {
+		return autogenTool, nil
+	}
+
+	// mcp tools call the server using the name they were discovered with,
+	// so the tool is wrapped rather than renamed
+	return &api.Component{
+		Provider:      "kagent.tools.common.RenamedTool",
+		ComponentType: "tool",
+		Version:       1,
+		Description:   autogenTool.Description,
+		Label:         resolvedTool.name,
+		Config: api.MustToConfig(&api.RenamedToolConfig{
+			Name:        resolvedTool.name,
+			Description: resolvedTool.descriptionOverride,
+			Tool:        autogenTool,
+		}),
+	}, nil
+}
+
+// translateToolApproval wraps the tool so that every call waits for a human approval, if the reference requires it
+func translateToolApproval(tool *v1alpha1.Tool, agent *v1alpha1.Agent, autogenTool *api.Component) (*api.Component, error) {
+	if !tool.RequireApproval {
+		return autogenTool, nil
+	}
+
+	timeoutSeconds := int(DefaultApprovalTimeout.Seconds())
+	if tool.ApprovalTimeout != "" {
+		timeout, err := time.ParseDuration(tool.ApprovalTimeout)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("invalid approval timeout %q for tool %s", tool.ApprovalTimeout, getToolName(autogenTool))
+		}
+		timeoutSeconds = int(timeout.Seconds())
+	}
+
+	return &api.Component{
+		Provider:      "kagent.tools.common.ApprovalTool",
+		ComponentType: "tool",
+		Version:       1,
+		Description:   autogenTool.Description,
+		Label:         autogenTool.Label,
+		Config: api.MustToConfig(&api.ApprovalToolConfig{
+			Tool:           autogenTool,
+			Agent:          fmt.Sprintf("%s/%s", agent.Namespace, agent.Name),
+			TimeoutSeconds: timeoutSeconds,
+		}),
+	}, nil
+}
+
+// validateUniqueToolNames returns an error if two tools would be exposed to the model with the same name
+func validateUniqueToolNames(tools []*api.Component) error {
+	seen := map[string]bool{}
+	for _, tool := range tools {
+		name := getToolName(tool)
+		if name == "" {
+			continue
+		}
+		if seen[name] {
+			return fmt.Errorf("duplicate tool name %s, use namePrefix to disambiguate tools from different ToolServers", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// getToolName returns the name a translated tool is exposed to the model with
+func getToolName(tool *api.Component) string {
+	switch tool.Provider {
+	case "kagent.tools.common.RenamedTool", "autogen_agentchat.tools.TeamTool", httpToolProvider, remoteAgentToolProvider:
+		name, _ := tool.Config["name"].(string)
+		return name
+	case dryRunToolProvider:
+		cfg := &api.DryRunToolConfig{}
+		if err := cfg.FromConfig(tool.Config); err != nil || cfg.Tool == nil {
+			return tool.Label
+		}
+		return getToolName(cfg.Tool)
+	case "kagent.tools.common.ApprovalTool":
+		cfg := &api.ApprovalToolConfig{}
+		if err := cfg.FromConfig(tool.Config); err != nil || cfg.Tool == nil {
+			return tool.Label
+		}
+		return getToolName(cfg.Tool)
+	}
+	if toolConfig, ok := tool.Config["tool"].(map[string]interface{}); ok {
+		name, _ := toolConfig["name"].(string)
+		return name
+	}
+	// the builtin tools name themselves, their config doesn't have a name
+	if builtinTool, ok := builtintools.Default().Get(tool.Provider); ok && builtinTool.ToolName != "" {
+		return builtinTool.ToolName
+	}
+	return tool.Label
 }
 
 func convertComponent(component v1alpha1.Component) (*api.Component, error) {
@@ -884,24 +1446,6 @@ func convertToPythonIdentifier(name string) string {
 	return strings.ReplaceAll(name, "-", "_")
 }
 
-func toolNeedsModelClient(provider string) bool {
-	for _, p := range toolsProvidersRequiringModelClient {
-		if p == provider {
-			return true
-		}
-	}
-	return false
-}
-
-func toolNeedsOpenaiApiKey(provider string) bool {
-	for _, p := range toolsProvidersRequiringOpenaiApiKey {
-		if p == provider {
-			return true
-		}
-	}
-	return false
-}
-
 func addModelClientToConfig(
 	modelClient *api.Component,
 	toolConfig *map[string]interface{},
@@ -931,6 +1475,18 @@ func addOpenaiApiKeyToConfig(
 	return nil
 }
 
+func addKubeTokenToConfig(
+	kubeToken string,
+	toolConfig *map[string]interface{},
+) error {
+	if *toolConfig == nil {
+		*toolConfig = make(map[string]interface{})
+	}
+
+	(*toolConfig)["kube_token"] = kubeToken
+	return nil
+}
+
 // createModelClientForProvider creates a model client component based on the model provider
 func (a *apiTranslator) createModelClientForProvider(ctx context.Context, modelConfig *v1alpha1.ModelConfig, stream bool) (*api.Component, error) {
 
@@ -1364,21 +1920,5 @@ func (a *apiTranslator) getModelConfigApiKey(ctx context.Context, modelConfig *v
 }
 
 func getRefFromString(ref string, parentNamespace string) types.NamespacedName {
-	parts := strings.Split(ref, "/")
-	var (
-		namespace string
-		name      string
-	)
-	if len(parts) == 2 {
-		namespace = parts[0]
-		name = parts[1]
-	} else {
-		namespace = parentNamespace
-		name = ref
-	}
-
-	return types.NamespacedName{
-		Namespace: namespace,
-		Name:      name,
-	}
+	return common.ParseRef(ref, parentNamespace)
 }
@@ -0,0 +1,192 @@
+package autogen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	autogen_client "github.com/kagent-dev/kagent/go/autogen/client"
+)
+
+const (
+	// autogenProbeInterval is how often autogen is probed while it is available, to notice when it goes down
+	autogenProbeInterval = 30 * time.Second
+	// autogenMinBackoff and autogenMaxBackoff bound how long the calls to autogen are held back once it is unavailable.
+	// The backoff doubles with every failed probe.
+	autogenMinBackoff = 5 * time.Second
+	autogenMaxBackoff = 5 * time.Minute
+	// resyncBufferSize is how many objects of a type are buffered for the resync until the controller consumes them
+	resyncBufferSize = 1024
+)
+
+// ErrAutogenUnavailable is returned while autogen can't be reached
+var ErrAutogenUnavailable = errors.New("autogen is unavailable")
+
+// autogenAvailable reports the availability of autogen, which is also reported by the readiness of the controller
+var autogenAvailable = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "kagent_autogen_available",
+	Help: "Whether autogen could be reached by the last probe of the controller (1) or not (0).",
+})
+
+func init() {
+	metrics.Registry.MustRegister(autogenAvailable)
+}
+
+// AutogenAvailability is a circuit breaker tracking whether autogen can be reached. While it can't, the reconciles
+// which need autogen are requeued with a growing backoff instead of failing against it, and once it can be reached
+// again the resources are resynced.
+type AutogenAvailability struct {
+	autogenClient autogen_client.Client
+	kube          client.Client
+
+	mu          sync.Mutex
+	unavailable bool
+	backoff     time.Duration
+	retryAt     time.Time
+	onRecovered []func(ctx context.Context)
+}
+
+func NewAutogenAvailability(autogenClient autogen_client.Client, kube client.Client) *AutogenAvailability {
+	return &AutogenAvailability{
+		autogenClient: autogenClient,
+		kube:          kube,
+	}
+}
+
+// Start probes autogen until the context is done
+func (a *AutogenAvailability) Start(ctx context.Context) error {
+	for {
+		interval := autogenProbeInterval
+		if err := a.Probe(ctx); err != nil {
+			interval = a.RetryAfter()
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// NeedLeaderElection is false as every replica reports whether it can reach autogen in its readiness
+func (a *AutogenAvailability) NeedLeaderElection() bool {
+	return false
+}
+
+// Probe checks whether autogen can be reached, opening or closing the circuit accordingly.
+// The resources are resynced once autogen can be reached again.
+func (a *AutogenAvailability) Probe(ctx context.Context) error {
+	_, err := a.autogenClient.GetVersion()
+
+	a.mu.Lock()
+	if err != nil {
+		if !a.unavailable {
+			reconcileLog.Error(err, "autogen is unavailable")
+		}
+		a.unavailable = true
+		a.backoff = min(max(2*a.backoff, autogenMinBackoff), autogenMaxBackoff)
+		a.retryAt = time.Now().Add(a.backoff)
+		a.mu.Unlock()
+		autogenAvailable.Set(0)
+		return fmt.Errorf("%w: %v", ErrAutogenUnavailable, err)
+	}
+	recovered := a.unavailable
+	a.unavailable = false
+	a.backoff = 0
+	onRecovered := a.onRecovered
+	a.mu.Unlock()
+	autogenAvailable.Set(1)
+
+	if recovered {
+		reconcileLog.Info("autogen is available again, resyncing the resources")
+		for _, f := range onRecovered {
+			f(ctx)
+		}
+	}
+	return nil
+}
+
+// RetryAfter returns how long the calls to autogen are held back, or 0 if autogen is available
+func (a *AutogenAvailability) RetryAfter() time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.unavailable {
+		return 0
+	}
+	// the reconciles are held back until the circuit is closed by a probe
+	return max(time.Until(a.retryAt), autogenMinBackoff)
+}
+
+// Check is a readiness check failing while autogen is unavailable
+func (a *AutogenAvailability) Check(_ *http.Request) error {
+	if retryAfter := a.RetryAfter(); retryAfter > 0 {
+		return fmt.Errorf("%w, retrying in %v", ErrAutogenUnavailable, retryAfter.Round(time.Second))
+	}
+	return nil
+}
+
+// OnRecovered registers a function called once autogen is available again
+func (a *AutogenAvailability) OnRecovered(f func(ctx context.Context)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.onRecovered = append(a.onRecovered, f)
+}
+
+// ResyncSource returns a source enqueueing all the objects of the type of the list once autogen is available again.
+// The events are sent without blocking, as the probes must go on while the controller doesn't consume them, e.g.
+// on the replicas which aren't the leader. Objects which don't fit in the buffer are reconciled on the next resync
+// of the controller instead.
+func (a *AutogenAvailability) ResyncSource(list client.ObjectList) source.Source {
+	events := make(chan event.GenericEvent, resyncBufferSize)
+	a.OnRecovered(func(ctx context.Context) {
+		list := list.DeepCopyObject().(client.ObjectList)
+		if err := a.kube.List(ctx, list); err != nil {
+			reconcileLog.Error(err, "failed to list the objects to resync")
+			return
+		}
+		dropped := 0
+		_ = meta.EachListItem(list, func(item runtime.Object) error {
+			if obj, ok := item.(client.Object); ok {
+				select {
+				case events <- event.GenericEvent{Object: obj}:
+				default:
+					dropped++
+				}
+			}
+			return nil
+		})
+		if dropped > 0 {
+			reconcileLog.Info("The resync buffer is full, skipping objects until the next resync", "skipped", dropped)
+		}
+	})
+	return source.Channel(events, &handler.EnqueueRequestForObject{})
+}
+
+// withAutogen runs a reconcile which needs autogen unless autogen is unavailable. If the reconcile fails because
+// autogen is unavailable, it is requeued once autogen is expected to be available again instead of failing.
+func (a *autogenReconciler) withAutogen(ctx context.Context, reconcile func() (ctrl.Result, error)) (ctrl.Result, error) {
+	if retryAfter := a.availability.RetryAfter(); retryAfter > 0 {
+		return ctrl.Result{RequeueAfter: retryAfter}, nil
+	}
+
+	result, err := reconcile()
+	if err != nil && a.availability.Probe(ctx) != nil {
+		retryAfter := a.availability.RetryAfter()
+		reconcileLog.Info("Requeueing the reconcile while autogen is unavailable", "error", err.Error(), "retryAfter", retryAfter)
+		return ctrl.Result{RequeueAfter: retryAfter}, nil
+	}
+	return result, err
+}
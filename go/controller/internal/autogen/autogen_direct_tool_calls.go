@@ -0,0 +1,107 @@
+package autogen
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	common "github.com/kagent-dev/kagent/go/controller/internal/utils"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DirectToolCallError is returned when a tool of a ToolServer may not be called directly,
+// i.e. through the API or the MCP gateway instead of by an agent
+type DirectToolCallError struct {
+	msg string
+}
+
+func (e *DirectToolCallError) Error() string {
+	return e.msg
+}
+
+// CheckDirectToolCall checks whether a tool discovered on a ToolServer may be called directly in the given mode,
+// and returns whether the call must only be reported instead of run, in dryRun mode.
+// The tools which an agent calls only once they are approved are refused, as direct calls cannot wait for
+// an approval, and the mutating tools are refused in readOnly mode.
+func CheckDirectToolCall(
+	ctx context.Context,
+	kube client.Client,
+	toolServer *v1alpha1.ToolServer,
+	tool *v1alpha1.MCPTool,
+	mode v1alpha1.AgentMode,
+) (bool, error) {
+	mode, err := ParseAgentMode(string(mode))
+	if err != nil {
+		return false, err
+	}
+
+	agents := &v1alpha1.AgentList{}
+	if err := kube.List(ctx, agents); err != nil {
+		return false, fmt.Errorf("failed to list agents: %v", err)
+	}
+	for _, agent := range agents.Items {
+		for _, agentTool := range agent.Spec.Tools {
+			if !agentTool.RequireApproval || agentTool.McpServer == nil ||
+				common.ParseRef(agentTool.McpServer.ToolServer, agent.Namespace) != client.ObjectKeyFromObject(toolServer) {
+				continue
+			}
+			if selectsMcpTool(agentTool.McpServer, tool.Name) {
+				return false, &DirectToolCallError{msg: fmt.Sprintf(
+					"tool %s of tool server %s/%s requires an approval when agent %s/%s calls it, so it cannot be called directly",
+					tool.Name, toolServer.Namespace, toolServer.Name, agent.Namespace, agent.Name)}
+			}
+		}
+	}
+
+	if mode == v1alpha1.AgentMode_ReadWrite || !isMutatingMCPTool(tool) {
+		return false, nil
+	}
+	if mode == v1alpha1.AgentMode_ReadOnly {
+		return false, &DirectToolCallError{msg: fmt.Sprintf(
+			"tool %s of tool server %s/%s is not annotated as read-only, so it cannot be called in %s mode",
+			tool.Name, toolServer.Namespace, toolServer.Name, mode)}
+	}
+	return true, nil
+}
+
+// DryRunToolCallResult is the text reported instead of the result of a call in dryRun mode,
+// the same as the one of the dry run tools of the agents
+func DryRunToolCallResult(toolName string, arguments map[string]interface{}) string {
+	return fmt.Sprintf("Dry run: %s was not executed because the agent runs in dry-run mode. "+
+		"It would have been called with the arguments %v.", toolName, arguments)
+}
+
+// selectsMcpTool returns whether an McpServerTool selects the tool with the given name.
+// Tools are considered selected if the patterns are invalid, as the agent could not be translated anyway.
+func selectsMcpTool(mcpServerTool *v1alpha1.McpServerTool, toolName string) bool {
+	includeMatchers, err := compileToolPatterns(mcpServerTool.PatternType, mcpServerTool.IncludePatterns)
+	if err != nil {
+		return true
+	}
+	excludeMatchers, err := compileToolPatterns(mcpServerTool.PatternType, mcpServerTool.ExcludePatterns)
+	if err != nil {
+		return true
+	}
+	selected := slices.Contains(mcpServerTool.ToolNames, toolName) || matchesAnyToolPattern(includeMatchers, toolName)
+	return selected && !matchesAnyToolPattern(excludeMatchers, toolName)
+}
+
+// isMutatingMCPTool returns whether a discovered MCP tool may change the state of the systems it accesses,
+// i.e. unless the server annotates it as read-only
+func isMutatingMCPTool(tool *v1alpha1.MCPTool) bool {
+	config, ok := tool.Component.Config["tool"]
+	if !ok {
+		return true
+	}
+	var mcpTool struct {
+		Annotations struct {
+			ReadOnlyHint bool `json:"readOnlyHint"`
+		} `json:"annotations"`
+	}
+	if err := json.Unmarshal(config.RawMessage, &mcpTool); err != nil {
+		return true
+	}
+	return !mcpTool.Annotations.ReadOnlyHint
+}
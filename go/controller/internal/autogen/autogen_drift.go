@@ -0,0 +1,247 @@
+package autogen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	autogen_client "github.com/kagent-dev/kagent/go/autogen/client"
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	common "github.com/kagent-dev/kagent/go/controller/internal/utils"
+)
+
+var driftRepairs = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "kagent_autogen_drift_repairs_total",
+	Help: "The number of teams and tool servers changed in autogen outside of kagent which were repaired.",
+}, []string{"kind"})
+
+func init() {
+	metrics.Registry.MustRegister(driftRepairs)
+}
+
+// DriftRepairer repairs the drift of autogen periodically while the manager runs, e.g. the teams edited or deleted
+// through Autogen Studio, which the controllers don't notice as long as the resources don't change.
+func DriftRepairer(reconciler AutogenReconciler, interval time.Duration) manager.Runnable {
+	return manager.RunnableFunc(func(ctx context.Context) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				if err := reconciler.RepairDrift(ctx); err != nil {
+					reconcileLog.Error(err, "failed to repair the drift of autogen")
+				}
+			}
+		}
+	})
+}
+
+// RepairDrift compares the teams and tool servers in autogen with the ones translated from the resources,
+// and sends the translated ones again where they differ
+func (a *autogenReconciler) RepairDrift(ctx context.Context) error {
+	if a.availability.RetryAfter() > 0 {
+		return nil
+	}
+
+	var errs []error
+
+	agents := &v1alpha1.AgentList{}
+	if err := a.kube.List(ctx, agents); err != nil {
+		return fmt.Errorf("failed to list agents: %v", err)
+	}
+	for i := range agents.Items {
+		if err := a.repairAgentDrift(ctx, &agents.Items[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	teams := &v1alpha1.TeamList{}
+	if err := a.kube.List(ctx, teams); err != nil {
+		return fmt.Errorf("failed to list teams: %v", err)
+	}
+	for i := range teams.Items {
+		if err := a.repairTeamDrift(ctx, &teams.Items[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	toolServers := &v1alpha1.ToolServerList{}
+	if err := a.kube.List(ctx, toolServers); err != nil {
+		return fmt.Errorf("failed to list tool servers: %v", err)
+	}
+	for i := range toolServers.Items {
+		if err := a.repairToolServerDrift(ctx, &toolServers.Items[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (a *autogenReconciler) repairAgentDrift(ctx context.Context, agent *v1alpha1.Agent) error {
+	// only the agents which were sent to autogen can drift
+	if agent.Status.ComponentHash == "" || !agent.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	autogenTeam, err := a.autogenTranslator.TranslateGroupChatForAgent(ctx, agent)
+	if err != nil {
+		return fmt.Errorf("failed to translate agent %s/%s: %w", agent.Namespace, agent.Name, err)
+	}
+	componentHash, drifted, err := a.repairAutogenTeam(autogenTeam.Team, agent.Status.ComponentHash)
+	if err != nil {
+		return fmt.Errorf("failed to repair agent %s/%s: %v", agent.Namespace, agent.Name, err)
+	}
+	if drifted {
+		reconcileLog.Info("Repaired the autogen team of the agent", "namespace", agent.Namespace, "name", agent.Name)
+		driftRepairs.WithLabelValues("Agent").Inc()
+		agent.Status.ComponentHash = componentHash
+		agent.Status.TeamID = autogenTeam.Id
+	}
+
+	if setDriftedCondition(&agent.Status.Conditions, v1alpha1.AgentConditionTypeDrifted, "team", drifted) || drifted {
+		if err := a.kube.Status().Update(ctx, agent); err != nil {
+			return fmt.Errorf("failed to update agent status: %v", err)
+		}
+	}
+	return nil
+}
+
+func (a *autogenReconciler) repairTeamDrift(ctx context.Context, team *v1alpha1.Team) error {
+	// only the teams which were sent to autogen can drift
+	if team.Status.ComponentHash == "" || !team.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	autogenTeam, err := a.autogenTranslator.TranslateGroupChatForTeam(ctx, team)
+	if err != nil {
+		return fmt.Errorf("failed to translate team %s/%s: %w", team.Namespace, team.Name, err)
+	}
+	componentHash, drifted, err := a.repairAutogenTeam(autogenTeam.Team, team.Status.ComponentHash)
+	if err != nil {
+		return fmt.Errorf("failed to repair team %s/%s: %v", team.Namespace, team.Name, err)
+	}
+	if drifted {
+		reconcileLog.Info("Repaired the autogen team", "namespace", team.Namespace, "name", team.Name)
+		driftRepairs.WithLabelValues("Team").Inc()
+		team.Status.ComponentHash = componentHash
+		team.Status.TeamID = autogenTeam.Id
+	}
+
+	if setDriftedCondition(&team.Status.Conditions, v1alpha1.TeamConditionTypeDrifted, "team", drifted) || drifted {
+		if err := a.kube.Status().Update(ctx, team); err != nil {
+			return fmt.Errorf("failed to update team status: %v", err)
+		}
+	}
+	return nil
+}
+
+func (a *autogenReconciler) repairToolServerDrift(ctx context.Context, toolServer *v1alpha1.ToolServer) error {
+	// only the tool servers which were sent to autogen can drift
+	if !controllerutil.ContainsFinalizer(toolServer, KagentFinalizer) || !toolServer.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	autogenToolServer, err := a.autogenTranslator.TranslateToolServer(ctx, toolServer)
+	if err != nil {
+		return fmt.Errorf("failed to translate tool server %s/%s: %v", toolServer.Namespace, toolServer.Name, err)
+	}
+	drifted, err := a.repairAutogenToolServer(autogenToolServer)
+	if err != nil {
+		return fmt.Errorf("failed to repair tool server %s/%s: %v", toolServer.Namespace, toolServer.Name, err)
+	}
+	if drifted {
+		reconcileLog.Info("Repaired the autogen tool server", "namespace", toolServer.Namespace, "name", toolServer.Name)
+		driftRepairs.WithLabelValues("ToolServer").Inc()
+	}
+
+	if setDriftedCondition(&toolServer.Status.Conditions, v1alpha1.ToolServerConditionTypeDrifted, "tool server", drifted) {
+		if err := a.kube.Status().Update(ctx, toolServer); err != nil {
+			return fmt.Errorf("failed to update tool server status: %v", err)
+		}
+	}
+	return nil
+}
+
+// repairAutogenTeam sends the team to autogen again if the one in autogen is missing, or is neither the one
+// last sent nor the desired one. The latter differ until the resource is reconciled, e.g. after a change of
+// its model config.
+func (a *autogenReconciler) repairAutogenTeam(team *autogen_client.Team, lastHash string) (string, bool, error) {
+	existingTeam, err := a.autogenClient.GetTeam(team.Component.Label, common.GetGlobalUserID())
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get team %s: %v", team.Component.Label, err)
+	}
+	if existingTeam != nil {
+		existingHash, err := componentHash(existingTeam.Component)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to hash team %s: %v", team.Component.Label, err)
+		}
+		desiredHash, err := componentHash(team.Component)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to hash team %s: %v", team.Component.Label, err)
+		}
+		if existingHash == lastHash || existingHash == desiredHash {
+			return "", false, nil
+		}
+	}
+
+	componentHash, err := a.upsertTeam(team, "")
+	if err != nil {
+		return "", false, err
+	}
+	return componentHash, true, nil
+}
+
+// repairAutogenToolServer sends the tool server to autogen again if the one in autogen is missing or differs
+func (a *autogenReconciler) repairAutogenToolServer(toolServer *autogen_client.ToolServer) (bool, error) {
+	existingToolServer, err := a.autogenClient.GetToolServerByLabel(toolServer.Component.Label, common.GetGlobalUserID())
+	if err != nil && !strings.Contains(err.Error(), "not found") {
+		return false, fmt.Errorf("failed to get tool server %s: %v", toolServer.Component.Label, err)
+	}
+	if existingToolServer != nil {
+		existingHash, err := componentHash(&existingToolServer.Component)
+		if err != nil {
+			return false, fmt.Errorf("failed to hash tool server %s: %v", toolServer.Component.Label, err)
+		}
+		desiredHash, err := componentHash(&toolServer.Component)
+		if err != nil {
+			return false, fmt.Errorf("failed to hash tool server %s: %v", toolServer.Component.Label, err)
+		}
+		if existingHash == desiredHash {
+			return false, nil
+		}
+	}
+
+	if _, err := a.upsertToolServer(toolServer); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// setDriftedCondition reports whether the autogen counterpart of a resource had to be repaired
+// by the last resync, and returns whether the condition has changed
+func setDriftedCondition(conditions *[]metav1.Condition, conditionType string, counterpart string, drifted bool) bool {
+	condition := metav1.Condition{
+		Type:    conditionType,
+		Status:  metav1.ConditionFalse,
+		Reason:  "InSync",
+		Message: fmt.Sprintf("The autogen %s matches the resource", counterpart),
+	}
+	if drifted {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "DriftRepaired"
+		condition.Message = fmt.Sprintf("The autogen %s was changed outside of kagent and has been repaired", counterpart)
+	}
+	return meta.SetStatusCondition(conditions, condition)
+}
@@ -0,0 +1,129 @@
+package autogen
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	common "github.com/kagent-dev/kagent/go/controller/internal/utils"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// KagentFinalizer is added to the resources which have a counterpart in autogen or in the A2A server,
+// so that the counterpart is deleted before the resource is, even if the controller wasn't running when it was deleted.
+const KagentFinalizer = "kagent.dev/finalizer"
+
+func (a *autogenReconciler) addFinalizer(ctx context.Context, obj client.Object) error {
+	if !controllerutil.AddFinalizer(obj, KagentFinalizer) {
+		return nil
+	}
+	if err := a.kube.Update(ctx, obj); err != nil {
+		return fmt.Errorf("failed to add finalizer to %s/%s: %v", obj.GetNamespace(), obj.GetName(), err)
+	}
+	return nil
+}
+
+func (a *autogenReconciler) removeFinalizer(ctx context.Context, obj client.Object) error {
+	if !controllerutil.RemoveFinalizer(obj, KagentFinalizer) {
+		return nil
+	}
+	if err := a.kube.Update(ctx, obj); err != nil {
+		return fmt.Errorf("failed to remove finalizer from %s/%s: %v", obj.GetNamespace(), obj.GetName(), err)
+	}
+	return nil
+}
+
+// deleteOwnedAutogenTeam deletes the autogen team of an agent or a team being deleted, unless an agent or a team
+// of another namespace with the same name still uses it.
+func (a *autogenReconciler) deleteOwnedAutogenTeam(ctx context.Context, obj client.Object, teamID int) error {
+	agents := &v1alpha1.AgentList{}
+	if err := a.kube.List(ctx, agents); err != nil {
+		return fmt.Errorf("failed to list agents: %v", err)
+	}
+	teams := &v1alpha1.TeamList{}
+	if err := a.kube.List(ctx, teams); err != nil {
+		return fmt.Errorf("failed to list teams: %v", err)
+	}
+	for _, agent := range agents.Items {
+		if sharesLabel(&agent, obj) {
+			return nil
+		}
+	}
+	for _, team := range teams.Items {
+		if sharesLabel(&team, obj) {
+			return nil
+		}
+	}
+	return a.deleteAutogenTeam(teamID, obj.GetName())
+}
+
+// deleteOwnedAutogenToolServer deletes the autogen tool server of a ToolServer being deleted, unless a ToolServer
+// of another namespace with the same name still uses it.
+func (a *autogenReconciler) deleteOwnedAutogenToolServer(ctx context.Context, toolServer *v1alpha1.ToolServer) error {
+	toolServers := &v1alpha1.ToolServerList{}
+	if err := a.kube.List(ctx, toolServers); err != nil {
+		return fmt.Errorf("failed to list tool servers: %v", err)
+	}
+	for _, other := range toolServers.Items {
+		if sharesLabel(&other, toolServer) {
+			return nil
+		}
+	}
+	return a.deleteAutogenToolServer(toolServer.Status.ServerID, toolServer.Name)
+}
+
+// sharesLabel returns whether other is a resource of another namespace, which isn't being deleted,
+// translated to the same autogen label as obj
+func sharesLabel(other, obj client.Object) bool {
+	return other.GetName() == obj.GetName() &&
+		other.GetNamespace() != obj.GetNamespace() &&
+		other.GetDeletionTimestamp().IsZero()
+}
+
+// deleteAutogenTeam deletes the autogen team with the given id and label, if it exists.
+// Teams are deleted by id, as the agents and teams of other namespaces may have the same label.
+func (a *autogenReconciler) deleteAutogenTeam(teamID int, label string) error {
+	if teamID == 0 {
+		return nil
+	}
+	defer a.labelLocks.lock(teamLockKey(label))()
+
+	teams, err := a.autogenClient.ListTeams(common.GetGlobalUserID())
+	if err != nil {
+		return fmt.Errorf("failed to list teams: %v", err)
+	}
+	for _, team := range teams {
+		// the label is checked too, in case autogen reused the id
+		if team.Id != teamID || team.Component == nil || team.Component.Label != label {
+			continue
+		}
+		if err := a.autogenClient.DeleteTeam(team.Id, team.UserID); err != nil {
+			return fmt.Errorf("failed to delete team %s: %v", label, err)
+		}
+	}
+	return nil
+}
+
+// deleteAutogenToolServer deletes the autogen tool server with the given id and label, if it exists.
+// Tool servers are deleted by id, as the ToolServers of other namespaces may have the same label.
+func (a *autogenReconciler) deleteAutogenToolServer(serverID int, label string) error {
+	if serverID == 0 {
+		return nil
+	}
+	defer a.labelLocks.lock(toolServerLockKey(label))()
+
+	toolServers, err := a.autogenClient.ListToolServers(common.GetGlobalUserID())
+	if err != nil {
+		return fmt.Errorf("failed to list tool servers: %v", err)
+	}
+	for _, toolServer := range toolServers {
+		if toolServer.Id != serverID || toolServer.Component.Label != label {
+			continue
+		}
+		if err := a.autogenClient.DeleteToolServer(&toolServer.Id, common.GetGlobalUserID()); err != nil {
+			return fmt.Errorf("failed to delete tool server %s: %v", label, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package autogen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/kagent-dev/kagent/go/autogen/api"
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	common "github.com/kagent-dev/kagent/go/controller/internal/utils"
+)
+
+// KagentManagedConfigKey marks the config of the teams and tool servers created in autogen by kagent,
+// so that they are deleted once their resource is gone. Autogen ignores the config keys it doesn't know.
+const KagentManagedConfigKey = "kagent_managed"
+
+func markKagentManaged(component *api.Component) {
+	if component.Config == nil {
+		component.Config = map[string]interface{}{}
+	}
+	component.Config[KagentManagedConfigKey] = true
+}
+
+func isKagentManaged(component *api.Component) bool {
+	managed, _ := component.Config[KagentManagedConfigKey].(bool)
+	return managed
+}
+
+// OrphanCollector deletes the orphaned teams and tool servers of autogen on startup, and periodically while
+// the manager runs, e.g. the ones left behind by resources deleted while the controller wasn't running.
+// In dry-run mode, they are only reported.
+func OrphanCollector(reconciler AutogenReconciler, interval time.Duration, dryRun bool) manager.Runnable {
+	return manager.RunnableFunc(func(ctx context.Context) error {
+		wait.UntilWithContext(ctx, func(ctx context.Context) {
+			if _, err := reconciler.CollectOrphans(ctx, dryRun); err != nil {
+				reconcileLog.Error(err, "failed to collect the orphans of autogen")
+			}
+		}, interval)
+		return nil
+	})
+}
+
+// CollectOrphans deletes the teams and tool servers of autogen marked as managed by kagent
+// which no resource translates to, and returns them. In dry-run mode, they are only returned.
+func (a *autogenReconciler) CollectOrphans(ctx context.Context, dryRun bool) ([]string, error) {
+	if a.availability.RetryAfter() > 0 {
+		return nil, ErrAutogenUnavailable
+	}
+
+	// autogen is listed before the resources, as a resource is always in the cache before it is translated
+	teams, err := a.autogenClient.ListTeams(common.GetGlobalUserID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams: %v", err)
+	}
+	toolServers, err := a.autogenClient.ListToolServers(common.GetGlobalUserID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tool servers: %v", err)
+	}
+
+	// the labels are the names of the resources
+	teamLabels := map[string]bool{}
+	agentList := &v1alpha1.AgentList{}
+	if err := a.kube.List(ctx, agentList); err != nil {
+		return nil, fmt.Errorf("failed to list agents: %v", err)
+	}
+	for _, agent := range agentList.Items {
+		teamLabels[agent.Name] = true
+	}
+	teamList := &v1alpha1.TeamList{}
+	if err := a.kube.List(ctx, teamList); err != nil {
+		return nil, fmt.Errorf("failed to list teams: %v", err)
+	}
+	for _, team := range teamList.Items {
+		teamLabels[team.Name] = true
+	}
+	toolServerLabels := map[string]bool{}
+	toolServerList := &v1alpha1.ToolServerList{}
+	if err := a.kube.List(ctx, toolServerList); err != nil {
+		return nil, fmt.Errorf("failed to list tool servers: %v", err)
+	}
+	for _, toolServer := range toolServerList.Items {
+		toolServerLabels[toolServer.Name] = true
+	}
+
+	var (
+		orphans []string
+		errs    []error
+	)
+	for _, team := range teams {
+		if team.Component == nil || !isKagentManaged(team.Component) || teamLabels[team.Component.Label] {
+			continue
+		}
+		orphans = append(orphans, "team "+team.Component.Label)
+		if dryRun {
+			reconcileLog.Info("Found orphaned autogen team", "label", team.Component.Label)
+			continue
+		}
+		reconcileLog.Info("Deleting orphaned autogen team", "label", team.Component.Label)
+		if err := a.deleteAutogenTeam(team.Id, team.Component.Label); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, toolServer := range toolServers {
+		if !isKagentManaged(&toolServer.Component) || toolServerLabels[toolServer.Component.Label] {
+			continue
+		}
+		orphans = append(orphans, "tool server "+toolServer.Component.Label)
+		if dryRun {
+			reconcileLog.Info("Found orphaned autogen tool server", "label", toolServer.Component.Label)
+			continue
+		}
+		reconcileLog.Info("Deleting orphaned autogen tool server", "label", toolServer.Component.Label)
+		if err := a.deleteAutogenToolServer(toolServer.Id, toolServer.Component.Label); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return orphans, errors.Join(errs...)
+}
@@ -0,0 +1,422 @@
+package autogen
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/autogen/api"
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	httpToolProvider = "autogen_ext.tools.http.HttpTool"
+
+	// maxOpenAPIDocumentSize limits the size of the OpenAPI documents downloaded from a URL
+	maxOpenAPIDocumentSize = 10 << 20
+	// maxSchemaRefDepth limits how deep $refs are inlined in the JSON schemas of the tools
+	maxSchemaRefDepth = 10
+)
+
+// openAPIHttpClient downloads the OpenAPI documents of HttpToolSets
+var openAPIHttpClient = &http.Client{Timeout: 30 * time.Second}
+
+// downloadedOpenAPIDocuments keeps the OpenAPI document last downloaded from each URL,
+// which the translations without remote calls use instead of downloading it
+var downloadedOpenAPIDocuments = struct {
+	sync.Mutex
+	content map[string][]byte
+}{content: map[string][]byte{}}
+
+// the methods supported by the autogen HttpTool, in the order the tools of a path are generated
+var httpToolMethods = []string{"get", "post", "put", "patch", "delete"}
+
+// tool names must be valid function names for the model providers
+var invalidToolNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+// httpOperationTool is a tool generated from an operation of an OpenAPI document
+type httpOperationTool struct {
+	operationID string
+	component   *api.Component
+}
+
+func (a *apiTranslator) TranslateHttpToolSet(ctx context.Context, toolSet *v1alpha1.HttpToolSet) ([]*api.Component, error) {
+	operationTools, err := a.translateHttpToolSet(ctx, toolSet)
+	if err != nil {
+		return nil, err
+	}
+
+	tools := make([]*api.Component, 0, len(operationTools))
+	for _, operationTool := range operationTools {
+		tools = append(tools, operationTool.component)
+	}
+	return tools, nil
+}
+
+// translateHttpTools returns the tools of the HttpToolSet referenced by an Http tool of an agent
+func (a *apiTranslator) translateHttpTools(ctx context.Context, httpTool *v1alpha1.HttpTool, agentNamespace string) ([]*api.Component, error) {
+	toolSet := &v1alpha1.HttpToolSet{}
+	if err := fetchObjKube(ctx, a.kube, toolSet, httpTool.ToolSet, agentNamespace); err != nil {
+		return nil, err
+	}
+
+	operationTools, err := a.translateHttpToolSet(ctx, toolSet)
+	if err != nil {
+		return nil, err
+	}
+
+	var tools []*api.Component
+	for _, operationTool := range operationTools {
+		if len(httpTool.Operations) == 0 || slices.Contains(httpTool.Operations, operationTool.operationID) {
+			tools = append(tools, operationTool.component)
+		}
+	}
+	for _, operationID := range httpTool.Operations {
+		if !slices.ContainsFunc(operationTools, func(t *httpOperationTool) bool { return t.operationID == operationID }) {
+			return nil, fmt.Errorf("operation %v not found in HttpToolSet %v", operationID, toolSet.Name)
+		}
+	}
+
+	return tools, nil
+}
+
+func (a *apiTranslator) translateHttpToolSet(ctx context.Context, toolSet *v1alpha1.HttpToolSet) ([]*httpOperationTool, error) {
+	document, documentURL, err := a.loadOpenAPIDocument(ctx, &toolSet.Spec.OpenAPI, toolSet.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI document of HttpToolSet %s: %v", toolSet.Name, err)
+	}
+
+	baseURL, err := openAPIBaseURL(toolSet.Spec.BaseURL, document, documentURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL for HttpToolSet %s: %v", toolSet.Name, err)
+	}
+	port := 443
+	if baseURL.Scheme == "http" {
+		port = 80
+	}
+	if baseURL.Port() != "" {
+		if port, err = strconv.Atoi(baseURL.Port()); err != nil {
+			return nil, fmt.Errorf("invalid port in base URL for HttpToolSet %s: %v", toolSet.Name, err)
+		}
+	}
+
+	headers, err := a.resolveHeaders(ctx, toolSet.Spec.HeadersFrom, toolSet.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	paths, _ := document["paths"].(map[string]interface{})
+	pathNames := make([]string, 0, len(paths))
+	for path := range paths {
+		pathNames = append(pathNames, path)
+	}
+	sort.Strings(pathNames)
+
+	var tools []*httpOperationTool
+	seen := map[string]bool{}
+	for _, path := range pathNames {
+		pathItem, _ := resolveOpenAPIRef(document, paths[path]).(map[string]interface{})
+		for _, method := range httpToolMethods {
+			operation, ok := pathItem[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			operationID, _ := operation["operationId"].(string)
+			// operations without an ID cannot be referenced, so no tool is generated for them
+			if operationID == "" {
+				continue
+			}
+			if len(toolSet.Spec.Operations) > 0 && !slices.Contains(toolSet.Spec.Operations, operationID) {
+				continue
+			}
+
+			name := invalidToolNameChars.ReplaceAllString(operationID, "_")
+			if seen[name] {
+				return nil, fmt.Errorf("duplicate tool name %s in HttpToolSet %s", name, toolSet.Name)
+			}
+			seen[name] = true
+
+			tools = append(tools, &httpOperationTool{
+				operationID: operationID,
+				component: &api.Component{
+					Provider:      httpToolProvider,
+					ComponentType: "tool",
+					Version:       1,
+					Label:         name,
+					Config: api.MustToConfig(&api.HTTPToolConfig{
+						Name:        name,
+						Description: openAPIOperationDescription(operation, method, path),
+						Scheme:      baseURL.Scheme,
+						Host:        baseURL.Hostname(),
+						Port:        port,
+						Path:        strings.TrimSuffix(baseURL.Path, "/") + path,
+						Method:      strings.ToUpper(method),
+						Headers:     headers,
+						JSONSchema:  openAPIOperationSchema(document, pathItem, operation),
+					}),
+				},
+			})
+		}
+	}
+
+	for _, operationID := range toolSet.Spec.Operations {
+		if !slices.ContainsFunc(tools, func(t *httpOperationTool) bool { return t.operationID == operationID }) {
+			return nil, fmt.Errorf("operation %v not found in the OpenAPI document of HttpToolSet %v", operationID, toolSet.Name)
+		}
+	}
+
+	return tools, nil
+}
+
+// loadOpenAPIDocument returns the parsed OpenAPI document and the URL it was downloaded from, if any
+func (a *apiTranslator) loadOpenAPIDocument(ctx context.Context, source *v1alpha1.OpenAPISource, namespace string) (map[string]interface{}, *url.URL, error) {
+	var (
+		content     []byte
+		documentURL *url.URL
+	)
+	switch {
+	case source.ValueFrom != nil:
+		value, err := a.resolveValueSource(ctx, source.ValueFrom, namespace)
+		if err != nil {
+			return nil, nil, err
+		}
+		content = []byte(value)
+	case source.URL != "":
+		var err error
+		if documentURL, err = url.Parse(source.URL); err != nil {
+			return nil, nil, err
+		}
+		if a.withoutRemoteCalls {
+			downloadedOpenAPIDocuments.Lock()
+			content = downloadedOpenAPIDocuments.content[source.URL]
+			downloadedOpenAPIDocuments.Unlock()
+			if content == nil {
+				return nil, nil, fmt.Errorf("the document at %s was not downloaded yet", source.URL)
+			}
+			break
+		}
+		if content, err = downloadOpenAPIDocument(ctx, source.URL); err != nil {
+			return nil, nil, err
+		}
+		downloadedOpenAPIDocuments.Lock()
+		downloadedOpenAPIDocuments.content[source.URL] = content
+		downloadedOpenAPIDocuments.Unlock()
+	default:
+		return nil, nil, fmt.Errorf("either url or valueFrom must be specified")
+	}
+
+	// YAML is a superset of JSON, so both formats are accepted
+	jsonContent, err := yaml.YAMLToJSON(content)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse document: %v", err)
+	}
+	var document map[string]interface{}
+	if err := json.Unmarshal(jsonContent, &document); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse document: %v", err)
+	}
+	if version, _ := document["openapi"].(string); !strings.HasPrefix(version, "3.") {
+		return nil, nil, fmt.Errorf("unsupported OpenAPI version %q, only OpenAPI 3 is supported", version)
+	}
+
+	return document, documentURL, nil
+}
+
+func downloadOpenAPIDocument(ctx context.Context, documentURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, documentURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := openAPIHttpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: %s", documentURL, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxOpenAPIDocumentSize))
+}
+
+// openAPIBaseURL returns the URL the operations are sent to.
+// Relative server URLs are resolved against the URL the document was downloaded from.
+func openAPIBaseURL(override string, document map[string]interface{}, documentURL *url.URL) (*url.URL, error) {
+	rawURL := override
+	if rawURL == "" {
+		if servers, ok := document["servers"].([]interface{}); ok && len(servers) > 0 {
+			if server, ok := servers[0].(map[string]interface{}); ok {
+				rawURL, _ = server["url"].(string)
+			}
+		}
+	}
+	if rawURL == "" {
+		return nil, fmt.Errorf("baseURL must be specified if the OpenAPI document has no servers")
+	}
+
+	baseURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if !baseURL.IsAbs() && documentURL != nil {
+		baseURL = documentURL.ResolveReference(baseURL)
+	}
+	if baseURL.Scheme != "http" && baseURL.Scheme != "https" {
+		return nil, fmt.Errorf("%s is not an absolute http or https URL", rawURL)
+	}
+	return baseURL, nil
+}
+
+func openAPIOperationDescription(operation map[string]interface{}, method, path string) string {
+	for _, field := range []string{"summary", "description"} {
+		if description, ok := operation[field].(string); ok && description != "" {
+			return strings.TrimSpace(description)
+		}
+	}
+	return fmt.Sprintf("%s %s", strings.ToUpper(method), path)
+}
+
+// openAPIOperationSchema returns the JSON schema of the arguments of the tool of an operation.
+// The autogen HttpTool fills the path parameters from the arguments, and sends the others as query parameters
+// for GET and DELETE requests or as the JSON body otherwise, so the parameters and the properties of the body are merged.
+func openAPIOperationSchema(document, pathItem, operation map[string]interface{}) map[string]interface{} {
+	properties := map[string]interface{}{}
+	var required []string
+
+	// parameters of the operation override the ones of the path with the same name and location
+	parameters := map[string]map[string]interface{}{}
+	var parameterKeys []string
+	for _, source := range []map[string]interface{}{pathItem, operation} {
+		list, _ := source["parameters"].([]interface{})
+		for _, item := range list {
+			parameter, ok := resolveOpenAPIRef(document, item).(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _ := parameter["name"].(string)
+			in, _ := parameter["in"].(string)
+			// headers and cookies cannot be set by the model
+			if name == "" || (in != "path" && in != "query") {
+				continue
+			}
+			key := in + "/" + name
+			if _, exists := parameters[key]; !exists {
+				parameterKeys = append(parameterKeys, key)
+			}
+			parameters[key] = parameter
+		}
+	}
+	for _, key := range parameterKeys {
+		parameter := parameters[key]
+		name := parameter["name"].(string)
+
+		property, _ := resolveOpenAPISchema(document, parameter["schema"], 0).(map[string]interface{})
+		if property == nil {
+			property = map[string]interface{}{"type": "string"}
+		}
+		if description, ok := parameter["description"].(string); ok && description != "" {
+			property["description"] = description
+		}
+		properties[name] = property
+
+		if isRequired, _ := parameter["required"].(bool); isRequired || parameter["in"] == "path" {
+			required = append(required, name)
+		}
+	}
+
+	// only JSON object bodies can be built from the arguments
+	if requestBody, ok := resolveOpenAPIRef(document, operation["requestBody"]).(map[string]interface{}); ok {
+		content, _ := requestBody["content"].(map[string]interface{})
+		mediaType, _ := content["application/json"].(map[string]interface{})
+		body, _ := resolveOpenAPISchema(document, mediaType["schema"], 0).(map[string]interface{})
+		if bodyProperties, ok := body["properties"].(map[string]interface{}); ok {
+			for name, property := range bodyProperties {
+				properties[name] = property
+			}
+			if bodyRequired, ok := body["required"].([]interface{}); ok {
+				for _, name := range bodyRequired {
+					if name, ok := name.(string); ok && !slices.Contains(required, name) {
+						required = append(required, name)
+					}
+				}
+			}
+		}
+	}
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// resolveOpenAPIRef returns the object referenced by a local $ref, or the node itself if it is not a reference
+func resolveOpenAPIRef(document map[string]interface{}, node interface{}) interface{} {
+	for range maxSchemaRefDepth {
+		object, ok := node.(map[string]interface{})
+		if !ok {
+			return node
+		}
+		ref, ok := object["$ref"].(string)
+		if !ok {
+			return node
+		}
+		if !strings.HasPrefix(ref, "#/") {
+			return nil
+		}
+
+		var current interface{} = document
+		for _, segment := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			segment = strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+			parent, ok := current.(map[string]interface{})
+			if !ok {
+				return nil
+			}
+			current = parent[segment]
+		}
+		node = current
+	}
+	return nil
+}
+
+// resolveOpenAPISchema returns a copy of the schema with all its local $refs inlined,
+// as the tool schemas cannot reference the components of the document
+func resolveOpenAPISchema(document map[string]interface{}, schema interface{}, depth int) interface{} {
+	if depth > maxSchemaRefDepth {
+		// recursive schemas are cut off
+		return map[string]interface{}{}
+	}
+
+	switch node := schema.(type) {
+	case map[string]interface{}:
+		if _, isRef := node["$ref"]; isRef {
+			return resolveOpenAPISchema(document, resolveOpenAPIRef(document, node), depth+1)
+		}
+		resolved := make(map[string]interface{}, len(node))
+		for key, value := range node {
+			resolved[key] = resolveOpenAPISchema(document, value, depth)
+		}
+		return resolved
+	case []interface{}:
+		resolved := make([]interface{}, len(node))
+		for i, value := range node {
+			resolved[i] = resolveOpenAPISchema(document, value, depth)
+		}
+		return resolved
+	default:
+		return node
+	}
+}
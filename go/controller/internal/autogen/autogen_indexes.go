@@ -0,0 +1,269 @@
+package autogen
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Field indexes from kagent resources to the resources they reference.
+// The indexed values are the namespace/name of the referenced object.
+const (
+	ToolServerConfigMapIndex  = "spec.config.configMapRefs"
+	ToolServerSecretIndex     = "spec.config.secretRefs"
+	ModelConfigSecretIndex    = "spec.apiKeySecretRef"
+	MemorySecretIndex         = "spec.apiKeySecretRef"
+	HttpToolSetConfigMapIndex = "spec.configMapRefs"
+	HttpToolSetSecretIndex    = "spec.secretRefs"
+	RemoteAgentConfigMapIndex = "spec.headersFrom.configMapRefs"
+	RemoteAgentSecretIndex    = "spec.headersFrom.secretRefs"
+
+	AgentModelConfigIndex    = "spec.modelConfig"
+	AgentMemoryIndex         = "spec.memory"
+	AgentServiceAccountIndex = "spec.serviceAccountName"
+	// the tool servers providing tools or context to the agent
+	AgentToolServerIndex  = "spec.toolServerRefs"
+	AgentHttpToolSetIndex = "spec.tools.http.toolSet"
+	AgentRemoteAgentIndex = "spec.tools.remoteAgent.ref"
+	AgentAgentToolIndex   = "spec.tools.agent.ref"
+
+	TeamModelConfigIndex = "spec.modelConfig"
+	TeamParticipantIndex = "spec.participants"
+)
+
+// SetupIndexes registers the field indexes used to find the resources affected by a change to a resource they reference.
+// It must be called before the manager is started.
+func SetupIndexes(ctx context.Context, indexer client.FieldIndexer) error {
+	if err := indexer.IndexField(ctx, &v1alpha1.ToolServer{}, ToolServerConfigMapIndex, func(obj client.Object) []string {
+		return toolServerValueSourceRefs(obj.(*v1alpha1.ToolServer), v1alpha1.ConfigMapValueSource)
+	}); err != nil {
+		return fmt.Errorf("failed to index tool server config maps: %v", err)
+	}
+
+	if err := indexer.IndexField(ctx, &v1alpha1.ToolServer{}, ToolServerSecretIndex, func(obj client.Object) []string {
+		return toolServerSecretRefs(obj.(*v1alpha1.ToolServer))
+	}); err != nil {
+		return fmt.Errorf("failed to index tool server secrets: %v", err)
+	}
+
+	if err := indexer.IndexField(ctx, &v1alpha1.ModelConfig{}, ModelConfigSecretIndex, func(obj client.Object) []string {
+		modelConfig := obj.(*v1alpha1.ModelConfig)
+		if modelConfig.Spec.APIKeySecretRef == "" {
+			return nil
+		}
+		return []string{getRefFromString(modelConfig.Spec.APIKeySecretRef, modelConfig.Namespace).String()}
+	}); err != nil {
+		return fmt.Errorf("failed to index model config secrets: %v", err)
+	}
+
+	if err := indexer.IndexField(ctx, &v1alpha1.Memory{}, MemorySecretIndex, func(obj client.Object) []string {
+		memory := obj.(*v1alpha1.Memory)
+		return namespacedRefs(memory.Namespace, memory.Spec.APIKeySecretRef)
+	}); err != nil {
+		return fmt.Errorf("failed to index memory secrets: %v", err)
+	}
+
+	if err := indexer.IndexField(ctx, &v1alpha1.HttpToolSet{}, HttpToolSetConfigMapIndex, func(obj client.Object) []string {
+		return httpToolSetValueSourceRefs(obj.(*v1alpha1.HttpToolSet), v1alpha1.ConfigMapValueSource)
+	}); err != nil {
+		return fmt.Errorf("failed to index http tool set config maps: %v", err)
+	}
+
+	if err := indexer.IndexField(ctx, &v1alpha1.HttpToolSet{}, HttpToolSetSecretIndex, func(obj client.Object) []string {
+		return httpToolSetValueSourceRefs(obj.(*v1alpha1.HttpToolSet), v1alpha1.SecretValueSource)
+	}); err != nil {
+		return fmt.Errorf("failed to index http tool set secrets: %v", err)
+	}
+
+	if err := indexer.IndexField(ctx, &v1alpha1.RemoteAgent{}, RemoteAgentConfigMapIndex, func(obj client.Object) []string {
+		return remoteAgentValueSourceRefs(obj.(*v1alpha1.RemoteAgent), v1alpha1.ConfigMapValueSource)
+	}); err != nil {
+		return fmt.Errorf("failed to index remote agent config maps: %v", err)
+	}
+
+	if err := indexer.IndexField(ctx, &v1alpha1.RemoteAgent{}, RemoteAgentSecretIndex, func(obj client.Object) []string {
+		return remoteAgentValueSourceRefs(obj.(*v1alpha1.RemoteAgent), v1alpha1.SecretValueSource)
+	}); err != nil {
+		return fmt.Errorf("failed to index remote agent secrets: %v", err)
+	}
+
+	if err := setupAgentIndexes(ctx, indexer); err != nil {
+		return err
+	}
+
+	if err := indexer.IndexField(ctx, &v1alpha1.Team{}, TeamModelConfigIndex, func(obj client.Object) []string {
+		team := obj.(*v1alpha1.Team)
+		return namespacedRefs(team.Namespace, team.Spec.ModelConfig)
+	}); err != nil {
+		return fmt.Errorf("failed to index team model configs: %v", err)
+	}
+
+	if err := indexer.IndexField(ctx, &v1alpha1.Team{}, TeamParticipantIndex, func(obj client.Object) []string {
+		team := obj.(*v1alpha1.Team)
+		return namespacedRefs(team.Namespace, team.Spec.Participants...)
+	}); err != nil {
+		return fmt.Errorf("failed to index team participants: %v", err)
+	}
+
+	return nil
+}
+
+func setupAgentIndexes(ctx context.Context, indexer client.FieldIndexer) error {
+	agentIndexes := map[string]func(agent *v1alpha1.Agent) []string{
+		AgentModelConfigIndex: func(agent *v1alpha1.Agent) []string {
+			return namespacedRefs(agent.Namespace, agent.Spec.ModelConfig)
+		},
+		AgentMemoryIndex: func(agent *v1alpha1.Agent) []string {
+			return namespacedRefs(agent.Namespace, agent.Spec.Memory...)
+		},
+		AgentServiceAccountIndex: func(agent *v1alpha1.Agent) []string {
+			// the service account is always in the namespace of the agent
+			if agent.Spec.ServiceAccountName == "" {
+				return nil
+			}
+			return []string{agent.Namespace + "/" + agent.Spec.ServiceAccountName}
+		},
+		AgentToolServerIndex: func(agent *v1alpha1.Agent) []string {
+			var names []string
+			for _, tool := range agent.Spec.Tools {
+				if tool.McpServer != nil {
+					names = append(names, tool.McpServer.ToolServer)
+				}
+			}
+			for _, mcpContext := range agent.Spec.McpContext {
+				names = append(names, mcpContext.ToolServer)
+			}
+			return namespacedRefs(agent.Namespace, names...)
+		},
+		AgentHttpToolSetIndex: func(agent *v1alpha1.Agent) []string {
+			var names []string
+			for _, tool := range agent.Spec.Tools {
+				if tool.Http != nil {
+					names = append(names, tool.Http.ToolSet)
+				}
+			}
+			return namespacedRefs(agent.Namespace, names...)
+		},
+		AgentRemoteAgentIndex: func(agent *v1alpha1.Agent) []string {
+			var names []string
+			for _, tool := range agent.Spec.Tools {
+				if tool.RemoteAgent != nil {
+					names = append(names, tool.RemoteAgent.Ref)
+				}
+			}
+			return namespacedRefs(agent.Namespace, names...)
+		},
+		AgentAgentToolIndex: func(agent *v1alpha1.Agent) []string {
+			var names []string
+			for _, tool := range agent.Spec.Tools {
+				if tool.Agent != nil {
+					names = append(names, tool.Agent.Ref)
+				}
+			}
+			return namespacedRefs(agent.Namespace, names...)
+		},
+	}
+
+	for index, extractRefs := range agentIndexes {
+		if err := indexer.IndexField(ctx, &v1alpha1.Agent{}, index, func(obj client.Object) []string {
+			return extractRefs(obj.(*v1alpha1.Agent))
+		}); err != nil {
+			return fmt.Errorf("failed to index agent field %s: %v", index, err)
+		}
+	}
+
+	return nil
+}
+
+// namespacedRefs returns the namespace/name of the objects referenced by name, relative to the namespace of the referencing object
+func namespacedRefs(namespace string, names ...string) []string {
+	var refs []string
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		refs = appendUniqueRef(refs, getRefFromString(name, namespace).String())
+	}
+	return refs
+}
+
+// httpToolSetValueSourceRefs returns the objects of the given type referenced by the document and the headers of the tool set
+func httpToolSetValueSourceRefs(toolSet *v1alpha1.HttpToolSet, sourceType v1alpha1.ValueSourceType) []string {
+	sources := []*v1alpha1.ValueSource{toolSet.Spec.OpenAPI.ValueFrom}
+	for _, header := range toolSet.Spec.HeadersFrom {
+		sources = append(sources, header.ValueFrom)
+	}
+
+	var refs []string
+	for _, source := range sources {
+		if source == nil || source.Type != sourceType || source.ValueRef == "" {
+			continue
+		}
+		refs = appendUniqueRef(refs, getRefFromString(source.ValueRef, toolSet.Namespace).String())
+	}
+
+	return refs
+}
+
+// remoteAgentValueSourceRefs returns the objects of the given type referenced by the headers of the remote agent
+func remoteAgentValueSourceRefs(remoteAgent *v1alpha1.RemoteAgent, sourceType v1alpha1.ValueSourceType) []string {
+	var refs []string
+	for _, header := range remoteAgent.Spec.HeadersFrom {
+		source := header.ValueFrom
+		if source == nil || source.Type != sourceType || source.ValueRef == "" {
+			continue
+		}
+		refs = appendUniqueRef(refs, getRefFromString(source.ValueRef, remoteAgent.Namespace).String())
+	}
+
+	return refs
+}
+
+func toolServerSecretRefs(toolServer *v1alpha1.ToolServer) []string {
+	refs := toolServerValueSourceRefs(toolServer, v1alpha1.SecretValueSource)
+
+	sse := toolServer.Spec.Config.Sse
+	if sse != nil && sse.Auth != nil && sse.Auth.TLS != nil {
+		refs = appendUniqueRef(refs, getRefFromString(sse.Auth.TLS.SecretRef, toolServer.Namespace).String())
+	}
+
+	return refs
+}
+
+// toolServerValueSourceRefs returns the objects of the given type referenced by the value sources of the tool server
+func toolServerValueSourceRefs(toolServer *v1alpha1.ToolServer, sourceType v1alpha1.ValueSourceType) []string {
+	var sources []*v1alpha1.ValueSource
+	if stdio := toolServer.Spec.Config.Stdio; stdio != nil {
+		for _, env := range stdio.EnvFrom {
+			sources = append(sources, env.ValueFrom)
+		}
+	}
+	if sse := toolServer.Spec.Config.Sse; sse != nil {
+		for _, header := range sse.HeadersFrom {
+			sources = append(sources, header.ValueFrom)
+		}
+		if sse.Auth != nil && sse.Auth.OAuth2 != nil {
+			sources = append(sources, &sse.Auth.OAuth2.ClientIDFrom, &sse.Auth.OAuth2.ClientSecretFrom)
+		}
+	}
+
+	var refs []string
+	for _, source := range sources {
+		if source == nil || source.Type != sourceType || source.ValueRef == "" {
+			continue
+		}
+		refs = appendUniqueRef(refs, getRefFromString(source.ValueRef, toolServer.Namespace).String())
+	}
+
+	return refs
+}
+
+func appendUniqueRef(refs []string, ref string) []string {
+	if slices.Contains(refs, ref) {
+		return refs
+	}
+	return append(refs, ref)
+}
@@ -0,0 +1,26 @@
+package autogen
+
+import "sync"
+
+// labelLocks serializes the calls to autogen for the same label,
+// while the resources with different labels are reconciled in parallel
+type labelLocks struct {
+	locks sync.Map
+}
+
+// lock locks the label, and returns the function unlocking it
+func (l *labelLocks) lock(key string) func() {
+	value, _ := l.locks.LoadOrStore(key, &sync.Mutex{})
+	mu := value.(*sync.Mutex)
+	mu.Lock()
+	return mu.Unlock
+}
+
+// the teams and the tool servers of autogen are distinct, even if their labels are the same
+func teamLockKey(label string) string {
+	return "team/" + label
+}
+
+func toolServerLockKey(label string) string {
+	return "toolserver/" + label
+}
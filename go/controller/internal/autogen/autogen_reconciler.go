@@ -2,14 +2,17 @@ package autogen
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"reflect"
+	"slices"
 	"strings"
-	"sync"
+	"time"
 
-	"github.com/hashicorp/go-multierror"
 	"github.com/kagent-dev/kagent/go/autogen/api"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -19,6 +22,7 @@ import (
 	"github.com/kagent-dev/kagent/go/controller/internal/a2a"
 	common "github.com/kagent-dev/kagent/go/controller/internal/utils"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -28,12 +32,15 @@ var (
 )
 
 type AutogenReconciler interface {
-	ReconcileAutogenAgent(ctx context.Context, req ctrl.Request) error
+	ReconcileAutogenAgent(ctx context.Context, req ctrl.Request) (ctrl.Result, error)
 	ReconcileAutogenModelConfig(ctx context.Context, req ctrl.Request) error
-	ReconcileAutogenTeam(ctx context.Context, req ctrl.Request) error
-	ReconcileAutogenApiKeySecret(ctx context.Context, req ctrl.Request) error
-	ReconcileAutogenToolServer(ctx context.Context, req ctrl.Request) error
+	ReconcileAutogenTeam(ctx context.Context, req ctrl.Request) (ctrl.Result, error)
+	ReconcileAutogenToolServer(ctx context.Context, req ctrl.Request) (ctrl.Result, error)
 	ReconcileAutogenMemory(ctx context.Context, req ctrl.Request) error
+	ReconcileAutogenHttpToolSet(ctx context.Context, req ctrl.Request) error
+	ReconcileAutogenRemoteAgent(ctx context.Context, req ctrl.Request) (ctrl.Result, error)
+	RepairDrift(ctx context.Context) error
+	CollectOrphans(ctx context.Context, dryRun bool) ([]string, error)
 }
 
 type autogenReconciler struct {
@@ -44,7 +51,10 @@ type autogenReconciler struct {
 	autogenClient autogen_client.Client
 
 	defaultModelConfig types.NamespacedName
-	upsertLock         sync.Mutex
+	labelLocks         labelLocks
+
+	recorder     record.EventRecorder
+	availability *AutogenAvailability
 }
 
 func NewAutogenReconciler(
@@ -53,6 +63,8 @@ func NewAutogenReconciler(
 	autogenClient autogen_client.Client,
 	defaultModelConfig types.NamespacedName,
 	a2aReconciler a2a.A2AReconciler,
+	recorder record.EventRecorder,
+	availability *AutogenAvailability,
 ) AutogenReconciler {
 	return &autogenReconciler{
 		autogenTranslator:  translator,
@@ -60,63 +72,64 @@ func NewAutogenReconciler(
 		autogenClient:      autogenClient,
 		defaultModelConfig: defaultModelConfig,
 		a2aReconciler:      a2aReconciler,
+		recorder:           recorder,
+		availability:       availability,
 	}
 }
 
-func (a *autogenReconciler) ReconcileAutogenAgent(ctx context.Context, req ctrl.Request) error {
-	// reconcile the agent team itself
-
-	// TODO(sbx0r): missing finalizer logic
+func (a *autogenReconciler) ReconcileAutogenAgent(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return a.withAutogen(ctx, func() (ctrl.Result, error) {
+		return a.reconcileAutogenAgent(ctx, req)
+	})
+}
 
+func (a *autogenReconciler) reconcileAutogenAgent(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	// reconcile the agent team itself
 	agent := &v1alpha1.Agent{}
 	if err := a.kube.Get(ctx, req.NamespacedName, agent); err != nil {
 		if k8s_errors.IsNotFound(err) {
-			return a.handleAgentDeletion(req)
+			return ctrl.Result{}, a.handleAgentDeletion(req)
 		}
 
-		return fmt.Errorf("failed to get agent %s/%s: %w", req.Namespace, req.Name, err)
+		return ctrl.Result{}, fmt.Errorf("failed to get agent %s/%s: %w", req.Namespace, req.Name, err)
+	}
+
+	if !agent.DeletionTimestamp.IsZero() {
+		if err := a.deleteAgentTeam(ctx, agent); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, a.removeFinalizer(ctx, agent)
+	}
+
+	if err := a.addFinalizer(ctx, agent); err != nil {
+		return ctrl.Result{}, err
 	}
 
 	return a.handleExistingAgent(ctx, agent, req)
 }
 
+// handleAgentDeletion is called once the agent is gone.
+// The teams and agents referencing it are enqueued by the watches of their controllers.
+// The autogen teams of agents created before the finalizer was added are deleted by the OrphanCollector,
+// as the id of their team is gone with them.
 func (a *autogenReconciler) handleAgentDeletion(req ctrl.Request) error {
-	// TODO(sbx0r): handle deletion of agents with multiple teams assignment
-
-	// agents, err := a.findTeamsUsingAgent(ctx, req)
-	// if err != nil {
-	// 	return fmt.Errorf("failed to find teams for agent %s/%s: %v", req.Namespace, req.Name, err)
-	// }
-	// if len(agents) > 1 {
-	// 	reconcileLog.Info("agent with multiple dependencies was deleted",
-	// 	"namespace", req.Namespace,
-	// 	"name", req.Name,
-	// 	"agents", agents)
-	// }
-
-	// remove a2a handler if it exists
 	a.a2aReconciler.ReconcileAutogenAgentDeletion(req.Namespace, req.Name)
 
-	// TODO(sbx0r): temporary mock on GlobalUserID.
-	//              This block will be removed after resolving previous TODO
-	team, err := a.autogenClient.GetTeam(req.Name, common.GetGlobalUserID())
-	if err != nil {
-		return fmt.Errorf("failed to get agent on agent deletion %s/%s: %w",
-			req.Namespace, req.Name, err)
-	}
+	reconcileLog.Info("Agent was deleted", "namespace", req.Namespace, "name", req.Name)
+	return nil
+}
 
-	if team != nil {
-		if err = a.autogenClient.DeleteTeam(team.Id, team.UserID); err != nil {
-			return fmt.Errorf("failed to delete agent %s/%s: %w",
-				req.Namespace, req.Name, err)
-		}
-	}
+// deleteAgentTeam deletes the autogen team and the A2A handler of the agent
+func (a *autogenReconciler) deleteAgentTeam(ctx context.Context, agent *v1alpha1.Agent) error {
+	a.a2aReconciler.ReconcileAutogenAgentDeletion(agent.Namespace, agent.Name)
 
-	reconcileLog.Info("Agent was deleted", "namespace", req.Namespace, "name", req.Name)
+	if err := a.deleteOwnedAutogenTeam(ctx, agent, agent.Status.TeamID); err != nil {
+		return fmt.Errorf("failed to delete agent %s/%s: %w", agent.Namespace, agent.Name, err)
+	}
 	return nil
 }
 
-func (a *autogenReconciler) handleExistingAgent(ctx context.Context, agent *v1alpha1.Agent, req ctrl.Request) error {
+func (a *autogenReconciler) handleExistingAgent(ctx context.Context, agent *v1alpha1.Agent, req ctrl.Request) (ctrl.Result, error) {
 	isNewAgent := agent.Status.ObservedGeneration == 0
 	isUpdatedAgent := agent.Generation > agent.Status.ObservedGeneration
 
@@ -133,27 +146,44 @@ func (a *autogenReconciler) handleExistingAgent(ctx context.Context, agent *v1al
 			"newGeneration", agent.Generation)
 	}
 
-	if err := a.reconcileAgents(ctx, agent); err != nil {
-		return fmt.Errorf("failed to reconcile agent %s/%s: %w",
-			req.Namespace, req.Name, err)
-	}
-
-	teams, err := a.findTeamsUsingAgent(ctx, req)
+	result, err := a.reconcileAgent(ctx, agent)
+	a.recordReconcileEvents(agent, agent.Status.ComponentHash, agent.Status.A2AURL, result, err)
 	if err != nil {
-		return fmt.Errorf("failed to find teams for agent %s/%s: %w",
+		reconcileErr := fmt.Errorf("failed to reconcile agent %s/%s: %w",
 			req.Namespace, req.Name, err)
+		// report the failure, e.g. a missing service account, on the agent
+		if statusErr := a.reconcileAgentStatus(ctx, agent, result, reconcileErr); statusErr != nil {
+			return ctrl.Result{}, statusErr
+		}
+		return ctrl.Result{}, reconcileErr
 	}
 
-	return a.reconcileAgentStatus(ctx, agent, a.reconcileTeams(ctx, teams...))
+	// the tokens minted for the service accounts of the agents of its tree are renewed before they expire
+	return ctrl.Result{RequeueAfter: result.requeueAfter}, a.reconcileAgentStatus(ctx, agent, result, nil)
 }
 
-func (a *autogenReconciler) reconcileAgentStatus(ctx context.Context, agent *v1alpha1.Agent, err error) error {
+func (a *autogenReconciler) reconcileAgentStatus(
+	ctx context.Context,
+	agent *v1alpha1.Agent,
+	result *teamReconcileResult,
+	err error,
+) error {
 	var (
 		status  metav1.ConditionStatus
 		message string
 		reason  string
 	)
-	if err != nil {
+	var chainErr *AgentToolChainError
+	if errors.As(err, &chainErr) {
+		// the whole chain is reported, as the offending agent may be far from this one
+		status = metav1.ConditionFalse
+		message = chainErr.Error()
+		reason = "AgentToolDepthExceeded"
+		if chainErr.IsCycle() {
+			reason = "AgentToolCycleDetected"
+		}
+		reconcileLog.Error(err, "failed to reconcile agent", "agent", agent)
+	} else if err != nil {
 		status = metav1.ConditionFalse
 		message = err.Error()
 		reason = "AgentReconcileFailed"
@@ -163,6 +193,21 @@ func (a *autogenReconciler) reconcileAgentStatus(ctx context.Context, agent *v1a
 		reason = "AgentReconciled"
 	}
 
+	resolvedTools := agent.Status.ResolvedTools
+	if err == nil {
+		var resolveErr error
+		resolvedTools, resolveErr = a.autogenTranslator.ResolveAgentTools(ctx, agent)
+		if resolveErr != nil {
+			reconcileLog.Error(resolveErr, "failed to resolve agent tools", "agent", agent)
+		}
+	}
+	toolsChanged := !slices.Equal(resolvedTools, agent.Status.ResolvedTools)
+
+	serviceAccountChanged, serviceAccountErr := a.reconcileAgentServiceAccountCondition(ctx, agent)
+	if serviceAccountErr != nil {
+		return serviceAccountErr
+	}
+
 	conditionChanged := meta.SetStatusCondition(&agent.Status.Conditions, metav1.Condition{
 		Type:               v1alpha1.AgentConditionTypeAccepted,
 		Status:             status,
@@ -170,10 +215,31 @@ func (a *autogenReconciler) reconcileAgentStatus(ctx context.Context, agent *v1a
 		Reason:             reason,
 		Message:            message,
 	})
+	conditionChanged = setStageConditions(&agent.Status.Conditions, err) || conditionChanged
+
+	// the references are kept as they were last resolved
+	references := agent.Status.References
+	if result.references != nil {
+		references = result.references
+	}
+	modelConfig := a.modelConfigRef(agent.Spec.ModelConfig, agent.Namespace).String()
+	memory := memoryRefs(agent)
+	resolvedChanged := agent.Status.ModelConfig != modelConfig ||
+		!slices.Equal(agent.Status.Memory, memory) ||
+		!slices.Equal(agent.Status.References, references)
 
 	// update the status if it has changed or the generation has changed
-	if conditionChanged || agent.Status.ObservedGeneration != agent.Generation {
+	if conditionChanged || toolsChanged || serviceAccountChanged || resolvedChanged ||
+		agent.Status.ComponentHash != result.componentHash || agent.Status.TeamID != result.teamID ||
+		agent.Status.A2AURL != result.a2aURL || agent.Status.ObservedGeneration != agent.Generation {
 		agent.Status.ObservedGeneration = agent.Generation
+		agent.Status.ResolvedTools = resolvedTools
+		agent.Status.ComponentHash = result.componentHash
+		agent.Status.ModelConfig = modelConfig
+		agent.Status.Memory = memory
+		agent.Status.TeamID = result.teamID
+		agent.Status.A2AURL = result.a2aURL
+		agent.Status.References = references
 		if err := a.kube.Status().Update(ctx, agent); err != nil {
 			return fmt.Errorf("failed to update agent status: %v", err)
 		}
@@ -181,31 +247,43 @@ func (a *autogenReconciler) reconcileAgentStatus(ctx context.Context, agent *v1a
 	return nil
 }
 
-func (a *autogenReconciler) ReconcileAutogenModelConfig(ctx context.Context, req ctrl.Request) error {
-	modelConfig := &v1alpha1.ModelConfig{}
-	if err := a.kube.Get(ctx, req.NamespacedName, modelConfig); err != nil {
-		return fmt.Errorf("failed to get model %s: %v", req.Name, err)
+// reconcileAgentServiceAccountCondition warns when the ServiceAccount of the agent is missing,
+// and returns whether the condition has changed
+func (a *autogenReconciler) reconcileAgentServiceAccountCondition(ctx context.Context, agent *v1alpha1.Agent) (bool, error) {
+	if agent.Spec.ServiceAccountName == "" {
+		return meta.RemoveStatusCondition(&agent.Status.Conditions, v1alpha1.AgentConditionTypeServiceAccountReady), nil
 	}
 
-	agents, err := a.findAgentsUsingModel(ctx, req)
-	if err != nil {
-		return fmt.Errorf("failed to find agents for model %s: %v", req.Name, err)
+	condition := metav1.Condition{
+		Type:   v1alpha1.AgentConditionTypeServiceAccountReady,
+		Status: metav1.ConditionTrue,
+		Reason: "ServiceAccountFound",
 	}
-
-	if err := a.reconcileAgents(ctx, agents...); err != nil {
-		return fmt.Errorf("failed to reconcile agents for model %s: %v", req.Name, err)
+	serviceAccount := &corev1.ServiceAccount{}
+	err := a.kube.Get(ctx, types.NamespacedName{Namespace: agent.Namespace, Name: agent.Spec.ServiceAccountName}, serviceAccount)
+	if k8s_errors.IsNotFound(err) {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ServiceAccountNotFound"
+		condition.Message = fmt.Sprintf("service account %s not found, the tools which access the cluster cannot be used", agent.Spec.ServiceAccountName)
+	} else if err != nil {
+		return false, fmt.Errorf("failed to get service account %s/%s: %v", agent.Namespace, agent.Spec.ServiceAccountName, err)
 	}
 
-	teams, err := a.findTeamsUsingModel(ctx, req)
-	if err != nil {
-		return fmt.Errorf("failed to find teams for model %s: %v", req.Name, err)
+	return meta.SetStatusCondition(&agent.Status.Conditions, condition), nil
+}
+
+// ReconcileAutogenModelConfig reports that the model config was observed.
+// The agents and teams using it are enqueued by the watches of their controllers, and report their own failures.
+func (a *autogenReconciler) ReconcileAutogenModelConfig(ctx context.Context, req ctrl.Request) error {
+	modelConfig := &v1alpha1.ModelConfig{}
+	if err := a.kube.Get(ctx, req.NamespacedName, modelConfig); err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get model %s: %v", req.Name, err)
 	}
 
-	return a.reconcileModelConfigStatus(
-		ctx,
-		modelConfig,
-		a.reconcileTeams(ctx, teams...),
-	)
+	return a.reconcileModelConfigStatus(ctx, modelConfig, nil)
 }
 
 func (a *autogenReconciler) reconcileModelConfigStatus(ctx context.Context, modelConfig *v1alpha1.ModelConfig, err error) error {
@@ -242,16 +320,48 @@ func (a *autogenReconciler) reconcileModelConfigStatus(ctx context.Context, mode
 	return nil
 }
 
-func (a *autogenReconciler) ReconcileAutogenTeam(ctx context.Context, req ctrl.Request) error {
+func (a *autogenReconciler) ReconcileAutogenTeam(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return a.withAutogen(ctx, func() (ctrl.Result, error) {
+		return a.reconcileAutogenTeam(ctx, req)
+	})
+}
+
+func (a *autogenReconciler) reconcileAutogenTeam(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	team := &v1alpha1.Team{}
 	if err := a.kube.Get(ctx, req.NamespacedName, team); err != nil {
-		return fmt.Errorf("failed to get team %s: %v", req.Name, err)
+		// the autogen team was deleted before the finalizer was removed
+		if k8s_errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get team %s: %v", req.Name, err)
+	}
+
+	if !team.DeletionTimestamp.IsZero() {
+		if err := a.deleteOwnedAutogenTeam(ctx, team, team.Status.TeamID); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete team %s: %v", req.Name, err)
+		}
+		return ctrl.Result{}, a.removeFinalizer(ctx, team)
 	}
 
-	return a.reconcileTeamStatus(ctx, team, a.reconcileTeams(ctx, team))
+	if err := a.addFinalizer(ctx, team); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	result, err := a.reconcileTeam(ctx, team)
+	a.recordReconcileEvents(team, team.Status.ComponentHash, "", result, err)
+	if statusErr := a.reconcileTeamStatus(ctx, team, result, err); statusErr != nil || err != nil {
+		return ctrl.Result{}, statusErr
+	}
+	// the tokens minted for the service accounts of the participants are renewed before they expire
+	return ctrl.Result{RequeueAfter: result.requeueAfter}, nil
 }
 
-func (a *autogenReconciler) reconcileTeamStatus(ctx context.Context, team *v1alpha1.Team, err error) error {
+func (a *autogenReconciler) reconcileTeamStatus(
+	ctx context.Context,
+	team *v1alpha1.Team,
+	result *teamReconcileResult,
+	err error,
+) error {
 	var (
 		status  metav1.ConditionStatus
 		message string
@@ -274,9 +384,23 @@ func (a *autogenReconciler) reconcileTeamStatus(ctx context.Context, team *v1alp
 		Reason:             reason,
 		Message:            message,
 	})
+	conditionChanged = setStageConditions(&team.Status.Conditions, err) || conditionChanged
 
-	if conditionChanged || team.Status.ObservedGeneration != team.Generation {
+	// the references are kept as they were last resolved
+	references := team.Status.References
+	if result.references != nil {
+		references = result.references
+	}
+	modelConfig := a.modelConfigRef(team.Spec.ModelConfig, team.Namespace).String()
+
+	if conditionChanged || team.Status.ComponentHash != result.componentHash || team.Status.TeamID != result.teamID ||
+		team.Status.ModelConfig != modelConfig || !slices.Equal(team.Status.References, references) ||
+		team.Status.ObservedGeneration != team.Generation {
 		team.Status.ObservedGeneration = team.Generation
+		team.Status.ComponentHash = result.componentHash
+		team.Status.TeamID = result.teamID
+		team.Status.ModelConfig = modelConfig
+		team.Status.References = references
 		if err := a.kube.Status().Update(ctx, team); err != nil {
 			return fmt.Errorf("failed to update team status: %v", err)
 		}
@@ -285,33 +409,33 @@ func (a *autogenReconciler) reconcileTeamStatus(ctx context.Context, team *v1alp
 	return nil
 }
 
-func (a *autogenReconciler) ReconcileAutogenApiKeySecret(ctx context.Context, req ctrl.Request) error {
-	agents, err := a.findAgentsUsingApiKeySecret(ctx, req)
-	if err != nil {
-		return fmt.Errorf("failed to find agents for secret %s: %v", req.Name, err)
-	}
-
-	if err := a.reconcileAgents(ctx, agents...); err != nil {
-		return fmt.Errorf("failed to reconcile agents for secret %s: %v", req.Name, err)
-	}
-
-	teams, err := a.findTeamsUsingApiKeySecret(ctx, req)
-	if err != nil {
-		return fmt.Errorf("failed to find teams for api key secret %s: %v", req.Name, err)
-	}
-
-	return a.reconcileTeams(ctx, teams...)
+func (a *autogenReconciler) ReconcileAutogenToolServer(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return a.withAutogen(ctx, func() (ctrl.Result, error) {
+		return a.reconcileAutogenToolServer(ctx, req)
+	})
 }
 
-func (a *autogenReconciler) ReconcileAutogenToolServer(ctx context.Context, req ctrl.Request) error {
+func (a *autogenReconciler) reconcileAutogenToolServer(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	// reconcile the agent team itself
 	toolServer := &v1alpha1.ToolServer{}
 	if err := a.kube.Get(ctx, req.NamespacedName, toolServer); err != nil {
-		// if the tool server is not found, we can ignore it
+		// the autogen tool servers of tool servers created before the finalizer was added
+		// are deleted by the OrphanCollector
 		if k8s_errors.IsNotFound(err) {
-			return nil
+			return ctrl.Result{}, nil
 		}
-		return fmt.Errorf("failed to get tool server %s: %v", req.Name, err)
+		return ctrl.Result{}, fmt.Errorf("failed to get tool server %s: %v", req.Name, err)
+	}
+
+	if !toolServer.DeletionTimestamp.IsZero() {
+		if err := a.deleteOwnedAutogenToolServer(ctx, toolServer); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete tool server %s: %v", req.Name, err)
+		}
+		return ctrl.Result{}, a.removeFinalizer(ctx, toolServer)
+	}
+
+	if err := a.addFinalizer(ctx, toolServer); err != nil {
+		return ctrl.Result{}, err
 	}
 
 	serverID, reconcileErr := a.reconcileToolServer(ctx, toolServer)
@@ -323,20 +447,12 @@ func (a *autogenReconciler) ReconcileAutogenToolServer(ctx context.Context, req
 		serverID,
 		reconcileErr,
 	); err != nil {
-		return fmt.Errorf("failed to reconcile tool server %s: %v", req.Name, err)
-	}
-
-	// find and reconcile all agents which use this tool server
-	agents, err := a.findAgentsUsingToolServer(ctx, req)
-	if err != nil {
-		return fmt.Errorf("failed to find teams for agent %s: %v", req.Name, err)
-	}
-
-	if err := a.reconcileAgents(ctx, agents...); err != nil {
-		return fmt.Errorf("failed to reconcile agents for tool server %s: %v", req.Name, err)
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile tool server %s: %v", req.Name, err)
 	}
 
-	return nil
+	// refresh the tool server periodically, backing off while discovery fails.
+	// The agents using it are enqueued when its status changes.
+	return ctrl.Result{RequeueAfter: toolServerRequeueAfter(toolServer)}, nil
 }
 
 func (a *autogenReconciler) reconcileToolServerStatus(
@@ -345,11 +461,197 @@ func (a *autogenReconciler) reconcileToolServerStatus(
 	serverID int,
 	err error,
 ) error {
+	now := metav1.Now()
+	// the status is only written if it changed, so refreshing a healthy tool server doesn't update it
+	previous := toolServer.Status.DeepCopy()
+	if serverID != 0 {
+		toolServer.Status.ServerID = serverID
+	}
+
+	// connecting to the server refreshes the tools it provides
+	connectReason := "ReconcileFailed"
+	var authErr *toolServerAuthError
+	if errors.As(err, &authErr) {
+		connectReason = "AuthenticationFailed"
+	}
+	if err == nil {
+		connectReason = "ConnectionFailed"
+		if refreshErr := a.autogenClient.RefreshToolServer(serverID, common.GetGlobalUserID()); refreshErr != nil {
+			err = fmt.Errorf("failed to refresh tool server %s: %v", toolServer.Name, refreshErr)
+		}
+	}
+	if err != nil {
+		reconcileLog.Error(err, "failed to connect to tool server", "toolServer", toolServer)
+		meta.SetStatusCondition(&toolServer.Status.Conditions, metav1.Condition{
+			Type:    v1alpha1.ToolServerConditionTypeConnected,
+			Status:  metav1.ConditionFalse,
+			Reason:  connectReason,
+			Message: err.Error(),
+		})
+		meta.SetStatusCondition(&toolServer.Status.Conditions, metav1.Condition{
+			Type:    v1alpha1.ToolServerConditionTypeToolsDiscovered,
+			Status:  metav1.ConditionUnknown,
+			Reason:  "NotConnected",
+			Message: "tools cannot be discovered until the tool server is connected",
+		})
+		return a.updateToolServerStatus(ctx, toolServer, previous, true)
+	}
+
+	// the times are only set when the state changes, not on every refresh
+	if !meta.IsStatusConditionTrue(toolServer.Status.Conditions, v1alpha1.ToolServerConditionTypeConnected) {
+		toolServer.Status.LastConnectedTime = &now
+	}
+	meta.SetStatusCondition(&toolServer.Status.Conditions, metav1.Condition{
+		Type:   v1alpha1.ToolServerConditionTypeConnected,
+		Status: metav1.ConditionTrue,
+		Reason: "Connected",
+	})
+
 	discoveredTools, discoveryErr := a.getDiscoveredMCPTools(serverID)
+	var (
+		discoveredResources []*v1alpha1.MCPResource
+		discoveredPrompts   []*v1alpha1.MCPPrompt
+	)
+	if discoveryErr == nil {
+		discoveredResources, discoveredPrompts, discoveryErr = a.getDiscoveredMCPContext(serverID)
+	}
 	if discoveryErr != nil {
-		err = multierror.Append(err, discoveryErr)
+		// keep the results of the last successful discovery, as the agents depend on them
+		reconcileLog.Error(discoveryErr, "failed to discover tools", "toolServer", toolServer)
+		meta.SetStatusCondition(&toolServer.Status.Conditions, metav1.Condition{
+			Type:    v1alpha1.ToolServerConditionTypeToolsDiscovered,
+			Status:  metav1.ConditionFalse,
+			Reason:  "DiscoveryFailed",
+			Message: discoveryErr.Error(),
+		})
+		return a.updateToolServerStatus(ctx, toolServer, previous, true)
+	}
+
+	a.recordDiscoveredToolsChange(toolServer, discoveredTools)
+
+	if !meta.IsStatusConditionTrue(toolServer.Status.Conditions, v1alpha1.ToolServerConditionTypeToolsDiscovered) ||
+		!equality.Semantic.DeepEqual(toolServer.Status.DiscoveredTools, discoveredTools) ||
+		!equality.Semantic.DeepEqual(toolServer.Status.DiscoveredResources, discoveredResources) ||
+		!equality.Semantic.DeepEqual(toolServer.Status.DiscoveredPrompts, discoveredPrompts) {
+		toolServer.Status.LastDiscoveryTime = &now
+	}
+
+	meta.SetStatusCondition(&toolServer.Status.Conditions, metav1.Condition{
+		Type:    v1alpha1.ToolServerConditionTypeToolsDiscovered,
+		Status:  metav1.ConditionTrue,
+		Reason:  "ToolsDiscovered",
+		Message: fmt.Sprintf("discovered %d tools, %d resources and %d prompts", len(discoveredTools), len(discoveredResources), len(discoveredPrompts)),
+	})
+	toolServer.Status.DiscoveredTools = discoveredTools
+	toolServer.Status.DiscoveredResources = discoveredResources
+	toolServer.Status.DiscoveredPrompts = discoveredPrompts
+
+	return a.updateToolServerStatus(ctx, toolServer, previous, false)
+}
+
+func (a *autogenReconciler) updateToolServerStatus(
+	ctx context.Context,
+	toolServer *v1alpha1.ToolServer,
+	previous *v1alpha1.ToolServerStatus,
+	failed bool,
+) error {
+	if failed {
+		toolServer.Status.ConsecutiveFailures++
+	} else {
+		toolServer.Status.ConsecutiveFailures = 0
+	}
+	toolServer.Status.ObservedGeneration = toolServer.Generation
+	if equality.Semantic.DeepEqual(previous, &toolServer.Status) {
+		return nil
+	}
+
+	if err := a.kube.Status().Update(ctx, toolServer); err != nil {
+		return fmt.Errorf("failed to update tool server status: %v", err)
+	}
+
+	return nil
+}
+
+// recordDiscoveredToolsChange emits an event if the set of tools discovered on the tool server has changed
+func (a *autogenReconciler) recordDiscoveredToolsChange(toolServer *v1alpha1.ToolServer, discoveredTools []*v1alpha1.MCPTool) {
+	previous := map[string]bool{}
+	for _, tool := range toolServer.Status.DiscoveredTools {
+		previous[tool.Name] = true
+	}
+
+	var added, removed []string
+	for _, tool := range discoveredTools {
+		if !previous[tool.Name] {
+			added = append(added, tool.Name)
+		}
+		delete(previous, tool.Name)
+	}
+	for name := range previous {
+		removed = append(removed, name)
+	}
+	if len(added) == 0 && len(removed) == 0 {
+		return
+	}
+	slices.Sort(removed)
+
+	a.recorder.Eventf(
+		toolServer,
+		corev1.EventTypeNormal,
+		"DiscoveredToolsChanged",
+		"Discovered tools changed, added: %v, removed: %v",
+		added,
+		removed,
+	)
+}
+
+const (
+	defaultToolServerRefreshInterval = 60 * time.Second
+	minToolServerRetryInterval       = 5 * time.Second
+	maxToolServerRetryInterval       = 5 * time.Minute
+)
+
+func toolServerRefreshInterval(toolServer *v1alpha1.ToolServer) time.Duration {
+	if toolServer.Spec.RefreshInterval != nil && toolServer.Spec.RefreshInterval.Duration > 0 {
+		return toolServer.Spec.RefreshInterval.Duration
+	}
+	return defaultToolServerRefreshInterval
+}
+
+// toolServerRequeueAfter returns when the tool server should be refreshed next.
+// Failed discoveries are retried with an exponential backoff, capped at the larger of the
+// refresh interval and maxToolServerRetryInterval.
+func toolServerRequeueAfter(toolServer *v1alpha1.ToolServer) time.Duration {
+	refreshInterval := toolServerRefreshInterval(toolServer)
+
+	failures := toolServer.Status.ConsecutiveFailures
+	if failures == 0 {
+		return refreshInterval
+	}
+
+	maxRetryInterval := max(refreshInterval, maxToolServerRetryInterval)
+	retryInterval := minToolServerRetryInterval << min(failures-1, 16)
+	return min(retryInterval, maxRetryInterval)
+}
+
+func (a *autogenReconciler) ReconcileAutogenHttpToolSet(ctx context.Context, req ctrl.Request) error {
+	toolSet := &v1alpha1.HttpToolSet{}
+	if err := a.kube.Get(ctx, req.NamespacedName, toolSet); err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get http tool set %s: %v", req.Name, err)
+	}
+
+	tools, err := a.autogenTranslator.TranslateHttpToolSet(ctx, toolSet)
+	if err := a.reconcileHttpToolSetStatus(ctx, toolSet, tools, err); err != nil {
+		return fmt.Errorf("failed to reconcile http tool set %s: %v", req.Name, err)
 	}
 
+	// the agents using the tool set are enqueued when its status changes
+	return nil
+}
+
+func (a *autogenReconciler) reconcileHttpToolSetStatus(ctx context.Context, toolSet *v1alpha1.HttpToolSet, tools []*api.Component, err error) error {
 	var (
 		status  metav1.ConditionStatus
 		message string
@@ -358,49 +660,104 @@ func (a *autogenReconciler) reconcileToolServerStatus(
 	if err != nil {
 		status = metav1.ConditionFalse
 		message = err.Error()
-		reason = "AgentReconcileFailed"
-		reconcileLog.Error(err, "failed to reconcile agent", "agent", toolServer)
+		reason = "ResolveFailed"
+		reconcileLog.Error(err, "failed to resolve http tool set", "toolSet", toolSet)
 	} else {
 		status = metav1.ConditionTrue
-		reason = "AgentReconciled"
+		reason = "Resolved"
+		message = fmt.Sprintf("generated %d tools", len(tools))
+		toolSet.Status.Tools = nil
+		for _, tool := range tools {
+			toolSet.Status.Tools = append(toolSet.Status.Tools, tool.Label)
+		}
 	}
-	conditionChanged := meta.SetStatusCondition(&toolServer.Status.Conditions, metav1.Condition{
-		Type:               v1alpha1.AgentConditionTypeAccepted,
-		Status:             status,
-		LastTransitionTime: metav1.Now(),
-		Reason:             reason,
-		Message:            message,
+
+	meta.SetStatusCondition(&toolSet.Status.Conditions, metav1.Condition{
+		Type:    v1alpha1.HttpToolSetConditionTypeResolved,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
 	})
+	toolSet.Status.ObservedGeneration = toolSet.Generation
 
-	// only update if the status has changed to prevent looping the reconciler
-	if !conditionChanged &&
-		toolServer.Status.ObservedGeneration == toolServer.Generation &&
-		reflect.DeepEqual(toolServer.Status.DiscoveredTools, discoveredTools) {
-		return nil
+	if err := a.kube.Status().Update(ctx, toolSet); err != nil {
+		return fmt.Errorf("failed to update http tool set status: %v", err)
 	}
 
-	toolServer.Status.ObservedGeneration = toolServer.Generation
-	toolServer.Status.DiscoveredTools = discoveredTools
+	return nil
+}
 
-	if err := a.kube.Status().Update(ctx, toolServer); err != nil {
-		return fmt.Errorf("failed to update agent status: %v", err)
+// defaultRemoteAgentRefreshInterval is how often the agent cards of remote agents are fetched, unless they override it
+const defaultRemoteAgentRefreshInterval = 5 * time.Minute
+
+func remoteAgentRefreshInterval(remoteAgent *v1alpha1.RemoteAgent) time.Duration {
+	if remoteAgent.Spec.RefreshInterval != nil && remoteAgent.Spec.RefreshInterval.Duration > 0 {
+		return remoteAgent.Spec.RefreshInterval.Duration
+	}
+	return defaultRemoteAgentRefreshInterval
+}
+
+func (a *autogenReconciler) ReconcileAutogenRemoteAgent(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	remoteAgent := &v1alpha1.RemoteAgent{}
+	if err := a.kube.Get(ctx, req.NamespacedName, remoteAgent); err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get remote agent %s: %v", req.Name, err)
+	}
+
+	card, err := a.autogenTranslator.FetchRemoteAgentCard(ctx, remoteAgent)
+	if err := a.reconcileRemoteAgentStatus(ctx, remoteAgent, card, err); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile remote agent %s: %v", req.Name, err)
+	}
+
+	// the card is fetched again periodically, which also reports the health of the agent.
+	// The agents using it are enqueued when its status changes.
+	return ctrl.Result{RequeueAfter: remoteAgentRefreshInterval(remoteAgent)}, nil
+}
+
+func (a *autogenReconciler) reconcileRemoteAgentStatus(ctx context.Context, remoteAgent *v1alpha1.RemoteAgent, card *v1alpha1.RemoteAgentCard, err error) error {
+	if err != nil {
+		reconcileLog.Error(err, "failed to fetch agent card", "remoteAgent", remoteAgent)
+		// the last card fetched is kept, so the agents using the remote agent can still be translated
+		meta.SetStatusCondition(&remoteAgent.Status.Conditions, metav1.Condition{
+			Type:    v1alpha1.RemoteAgentConditionTypeReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  "FetchFailed",
+			Message: err.Error(),
+		})
+	} else {
+		now := metav1.Now()
+		remoteAgent.Status.AgentCard = card
+		remoteAgent.Status.LastFetchTime = &now
+		meta.SetStatusCondition(&remoteAgent.Status.Conditions, metav1.Condition{
+			Type:    v1alpha1.RemoteAgentConditionTypeReady,
+			Status:  metav1.ConditionTrue,
+			Reason:  "CardFetched",
+			Message: fmt.Sprintf("agent %s advertises %d skills", card.Name, len(card.Skills)),
+		})
+	}
+	remoteAgent.Status.ObservedGeneration = remoteAgent.Generation
+
+	if err := a.kube.Status().Update(ctx, remoteAgent); err != nil {
+		return fmt.Errorf("failed to update remote agent status: %v", err)
 	}
 
 	return nil
 }
 
+// ReconcileAutogenMemory reports that the memory was observed.
+// The agents using it are enqueued by the watches of their controller, and report their own failures.
 func (a *autogenReconciler) ReconcileAutogenMemory(ctx context.Context, req ctrl.Request) error {
 	memory := &v1alpha1.Memory{}
 	if err := a.kube.Get(ctx, req.NamespacedName, memory); err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return nil
+		}
 		return fmt.Errorf("failed to get memory %s: %v", req.Name, err)
 	}
 
-	agents, err := a.findAgentsUsingMemory(ctx, req)
-	if err != nil {
-		return fmt.Errorf("failed to find agents using memory %s: %v", req.Name, err)
-	}
-
-	return a.reconcileMemoryStatus(ctx, memory, a.reconcileAgents(ctx, agents...))
+	return a.reconcileMemoryStatus(ctx, memory, nil)
 }
 
 func (a *autogenReconciler) reconcileMemoryStatus(ctx context.Context, memory *v1alpha1.Memory, err error) error {
@@ -436,56 +793,82 @@ func (a *autogenReconciler) reconcileMemoryStatus(ctx context.Context, memory *v
 	return nil
 }
 
-func (a *autogenReconciler) reconcileTeams(ctx context.Context, teams ...*v1alpha1.Team) error {
-	errs := map[types.NamespacedName]error{}
-	for _, team := range teams {
-		autogenTeam, err := a.autogenTranslator.TranslateGroupChatForTeam(ctx, team)
-		if err != nil {
-			errs[types.NamespacedName{Name: team.Name, Namespace: team.Namespace}] = fmt.Errorf("failed to translate team %s: %v", team.Name, err)
-			continue
-		}
-		if err := a.upsertTeam(autogenTeam); err != nil {
-			errs[types.NamespacedName{Name: team.Name, Namespace: team.Namespace}] = fmt.Errorf("failed to upsert team %s: %v", team.Name, err)
-			continue
-		}
+// reconcileTeam upserts the autogen team of a team, and returns what was resolved and sent to autogen.
+// The result holds the last status until the stage which failed.
+func (a *autogenReconciler) reconcileTeam(ctx context.Context, team *v1alpha1.Team) (*teamReconcileResult, error) {
+	result := &teamReconcileResult{
+		componentHash: team.Status.ComponentHash,
+		teamID:        team.Status.TeamID,
 	}
-
-	if len(errs) > 0 {
-		return fmt.Errorf("failed to reconcile teams: %v", errs)
+	references, err := a.resolveTeamReferences(ctx, team)
+	if err != nil {
+		return result, resolveError(fmt.Errorf("failed to resolve references of team %s: %w", team.Name, err))
 	}
+	result.references = references
 
-	return nil
+	autogenTeam, err := a.autogenTranslator.TranslateGroupChatForTeam(ctx, team)
+	if err != nil {
+		return result, translateError(fmt.Errorf("failed to translate team %s: %w", team.Name, err))
+	}
+	if autogenTeam.UsesServiceAccountToken {
+		result.requeueAfter = ServiceAccountTokenRefreshInterval
+	}
+	componentHash, err := a.upsertTeam(autogenTeam.Team, team.Status.ComponentHash)
+	if err != nil {
+		return result, syncError(fmt.Errorf("failed to upsert team %s: %w", team.Name, err))
+	}
+	result.componentHash = componentHash
+	// the ID is only known once the team was sent
+	if autogenTeam.Id != 0 {
+		result.teamID = autogenTeam.Id
+	}
+	return result, nil
 }
 
-func (a *autogenReconciler) reconcileAgents(ctx context.Context, agents ...*v1alpha1.Agent) error {
-	errs := map[types.NamespacedName]error{}
-	for _, agent := range agents {
-		autogenTeam, err := a.autogenTranslator.TranslateGroupChatForAgent(ctx, agent)
-		if err != nil {
-			errs[types.NamespacedName{Name: agent.Name, Namespace: agent.Namespace}] = fmt.Errorf("failed to translate agent %s: %v", agent.Name, err)
-			continue
-		}
-		if err := a.reconcileA2A(ctx, autogenTeam, agent); err != nil {
-			errs[types.NamespacedName{Name: agent.Name, Namespace: agent.Namespace}] = fmt.Errorf("failed to reconcile A2A for agent %s: %v", agent.Name, err)
-			continue
-		}
-		if err := a.upsertTeam(autogenTeam); err != nil {
-			errs[types.NamespacedName{Name: agent.Name, Namespace: agent.Namespace}] = fmt.Errorf("failed to upsert agent %s: %v", agent.Name, err)
-			continue
-		}
+// reconcileAgent upserts the autogen team of an agent, and returns what was resolved and sent to autogen.
+// The result holds the last status until the stage which failed.
+func (a *autogenReconciler) reconcileAgent(ctx context.Context, agent *v1alpha1.Agent) (*teamReconcileResult, error) {
+	result := &teamReconcileResult{
+		componentHash: agent.Status.ComponentHash,
+		teamID:        agent.Status.TeamID,
+		a2aURL:        agent.Status.A2AURL,
 	}
-
-	if len(errs) > 0 {
-		return fmt.Errorf("failed to reconcile agents: %v", errs)
+	references, err := a.resolveAgentReferences(ctx, agent)
+	if err != nil {
+		return result, resolveError(fmt.Errorf("failed to resolve references of agent %s: %w", agent.Name, err))
 	}
+	result.references = references
 
-	return nil
+	autogenTeam, err := a.autogenTranslator.TranslateGroupChatForAgent(ctx, agent)
+	if err != nil {
+		return result, translateError(fmt.Errorf("failed to translate agent %s: %w", agent.Name, err))
+	}
+	if autogenTeam.UsesServiceAccountToken {
+		result.requeueAfter = ServiceAccountTokenRefreshInterval
+	}
+	a2aURL, err := a.reconcileA2A(ctx, autogenTeam.Team, agent)
+	if err != nil {
+		return result, &stageError{stage: stageSynced, reason: "A2ARegistrationFailed", err: fmt.Errorf("failed to reconcile A2A for agent %s: %v", agent.Name, err)}
+	}
+	result.a2aURL = a2aURL
+	componentHash, err := a.upsertTeam(autogenTeam.Team, agent.Status.ComponentHash)
+	if err != nil {
+		return result, syncError(fmt.Errorf("failed to upsert agent %s: %w", agent.Name, err))
+	}
+	result.componentHash = componentHash
+	// the ID is only known once the team was sent
+	if autogenTeam.Id != 0 {
+		result.teamID = autogenTeam.Id
+	}
+	return result, nil
 }
 
 func (a *autogenReconciler) reconcileToolServer(ctx context.Context, server *v1alpha1.ToolServer) (int, error) {
 	toolServer, err := a.autogenTranslator.TranslateToolServer(ctx, server)
 	if err != nil {
-		return 0, fmt.Errorf("failed to translate tool server %s: %v", server.Name, err)
+		err = fmt.Errorf("failed to translate tool server %s: %w", server.Name, err)
+		a.recorder.Event(server, corev1.EventTypeWarning, "TranslationFailed", err.Error())
+		return 0, err
 	}
 	serverID, err := a.upsertToolServer(toolServer)
 	if err != nil {
@@ -495,38 +878,79 @@ func (a *autogenReconciler) reconcileToolServer(ctx context.Context, server *v1a
 	return serverID, nil
 }
 
-func (a *autogenReconciler) upsertTeam(team *autogen_client.Team) error {
-	// lock to prevent races
-	a.upsertLock.Lock()
-	defer a.upsertLock.Unlock()
+// upsertTeam sends the team to autogen unless its component hashes to lastHash, i.e. autogen already has it,
+// and returns the hash of its component
+func (a *autogenReconciler) upsertTeam(team *autogen_client.Team, lastHash string) (string, error) {
+	hash, err := componentHash(team.Component)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash team %s: %v", team.Component.Label, err)
+	}
+	if hash == lastHash {
+		return hash, nil
+	}
+
+	// lock to prevent races between the reconciles of the same team
+	defer a.labelLocks.lock(teamLockKey(team.Component.Label))()
+
 	// validate the team
 	req := autogen_client.ValidationRequest{
 		Component: team.Component,
 	}
 	resp, err := a.autogenClient.Validate(&req)
 	if err != nil {
-		return fmt.Errorf("failed to validate team %s: %v", team.Component.Label, err)
+		return "", fmt.Errorf("failed to validate team %s: %v", team.Component.Label, err)
 	}
 	if !resp.IsValid {
-		return fmt.Errorf("team %s is invalid: %v", team.Component.Label, resp.ErrorMsg())
+		return "", &invalidTeamError{label: team.Component.Label, msg: resp.ErrorMsg()}
 	}
 
 	// delete if team exists
 	existingTeam, err := a.autogenClient.GetTeam(team.Component.Label, common.GetGlobalUserID())
 	if err != nil {
-		return fmt.Errorf("failed to get existing team %s: %v", team.Component.Label, err)
+		return "", fmt.Errorf("failed to get existing team %s: %v", team.Component.Label, err)
 	}
 	if existingTeam != nil {
 		team.Id = existingTeam.Id
 	}
 
-	return a.autogenClient.CreateTeam(team)
+	if err := a.autogenClient.CreateTeam(team); err != nil {
+		return "", err
+	}
+	return hash, nil
+}
+
+// invalidTeamError is returned when autogen rejects a team as invalid
+type invalidTeamError struct {
+	label string
+	msg   string
+}
+
+func (e *invalidTeamError) Error() string {
+	return fmt.Sprintf("team %s is invalid: %v", e.label, e.msg)
+}
+
+// componentHash is the hash of the JSON of a component. The JSON is decoded and encoded again
+// so that the keys are sorted, and a component hashes the same once it was read back from autogen.
+func componentHash(component *api.Component) (string, error) {
+	bytes, err := json.Marshal(component)
+	if err != nil {
+		return "", err
+	}
+	var canonical interface{}
+	if err := json.Unmarshal(bytes, &canonical); err != nil {
+		return "", err
+	}
+	bytes, err = json.Marshal(canonical)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(bytes)), nil
 }
 
 func (a *autogenReconciler) upsertToolServer(toolServer *autogen_client.ToolServer) (int, error) {
-	// lock to prevent races
-	a.upsertLock.Lock()
-	defer a.upsertLock.Unlock()
+	// lock to prevent races between the reconciles of the same tool server.
+	// The tool server is always sent as updating it refreshes the tools it provides.
+	defer a.labelLocks.lock(toolServerLockKey(toolServer.Component.Label))()
 
 	// delete if toolServer exists
 	existingToolServer, err := a.autogenClient.GetToolServerByLabel(toolServer.Component.Label, common.GetGlobalUserID())
@@ -550,219 +974,9 @@ func (a *autogenReconciler) upsertToolServer(toolServer *autogen_client.ToolServ
 		}
 	}
 
-	err = a.autogenClient.RefreshToolServer(existingToolServer.Id, common.GetGlobalUserID())
-	if err != nil {
-		return 0, fmt.Errorf("failed to refresh toolServer %s: %v", toolServer.Component.Label, err)
-	}
-
 	return existingToolServer.Id, nil
 }
 
-func (a *autogenReconciler) findAgentsUsingModel(ctx context.Context, req ctrl.Request) ([]*v1alpha1.Agent, error) {
-	var agentsList v1alpha1.AgentList
-	if err := a.kube.List(
-		ctx,
-		&agentsList,
-		client.InNamespace(req.Namespace),
-	); err != nil {
-		return nil, fmt.Errorf("failed to list agents: %v", err)
-	}
-
-	var agents []*v1alpha1.Agent
-	for i := range agentsList.Items {
-		agent := &agentsList.Items[i]
-		if getRefFromString(agent.Spec.ModelConfig, agent.Namespace) == req.NamespacedName {
-			agents = append(agents, agent)
-		}
-	}
-
-	return agents, nil
-}
-
-func (a *autogenReconciler) findAgentsUsingApiKeySecret(ctx context.Context, req ctrl.Request) ([]*v1alpha1.Agent, error) {
-	var modelsList v1alpha1.ModelConfigList
-	if err := a.kube.List(
-		ctx,
-		&modelsList,
-		client.InNamespace(req.Namespace),
-	); err != nil {
-		return nil, fmt.Errorf("failed to list model configs: %v", err)
-	}
-
-	var models []string
-	for _, model := range modelsList.Items {
-		if getRefFromString(model.Spec.APIKeySecretRef, model.Namespace) == req.NamespacedName {
-			models = append(models, model.Name)
-		}
-	}
-
-	var agents []*v1alpha1.Agent
-	uniqueAgents := make(map[string]bool)
-
-	for _, modelName := range models {
-		agentsUsingModel, err := a.findAgentsUsingModel(ctx, ctrl.Request{
-			NamespacedName: types.NamespacedName{
-				Namespace: req.Namespace,
-				Name:      modelName,
-			},
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to find agents for model %s: %v", modelName, err)
-		}
-
-		for _, agent := range agentsUsingModel {
-			key := fmt.Sprintf("%s/%s", agent.Namespace, agent.Name)
-			if !uniqueAgents[key] {
-				uniqueAgents[key] = true
-				agents = append(agents, agent)
-			}
-		}
-	}
-
-	return agents, nil
-}
-
-func (a *autogenReconciler) findAgentsUsingMemory(ctx context.Context, req ctrl.Request) ([]*v1alpha1.Agent, error) {
-	var agentsList v1alpha1.AgentList
-	if err := a.kube.List(
-		ctx,
-		&agentsList,
-		client.InNamespace(req.Namespace),
-	); err != nil {
-		return nil, fmt.Errorf("failed to list agents: %v", err)
-	}
-
-	var agents []*v1alpha1.Agent
-	for i := range agentsList.Items {
-		agent := &agentsList.Items[i]
-		for _, memory := range agent.Spec.Memory {
-			if getRefFromString(memory, agent.Namespace) == req.NamespacedName {
-				agents = append(agents, agent)
-				break
-			}
-		}
-	}
-
-	return agents, nil
-}
-
-func (a *autogenReconciler) findTeamsUsingAgent(ctx context.Context, req ctrl.Request) ([]*v1alpha1.Team, error) {
-	var teamsList v1alpha1.TeamList
-	if err := a.kube.List(
-		ctx,
-		&teamsList,
-		client.InNamespace(req.Namespace),
-	); err != nil {
-		return nil, fmt.Errorf("failed to list teams: %v", err)
-	}
-
-	var teams []*v1alpha1.Team
-	for i := range teamsList.Items {
-		team := &teamsList.Items[i]
-		for _, participant := range team.Spec.Participants {
-			if getRefFromString(participant, team.Namespace) == req.NamespacedName {
-				teams = append(teams, team)
-				break
-			}
-		}
-	}
-
-	return teams, nil
-}
-
-func (a *autogenReconciler) findTeamsUsingModel(ctx context.Context, req ctrl.Request) ([]*v1alpha1.Team, error) {
-	var teamsList v1alpha1.TeamList
-	if err := a.kube.List(
-		ctx,
-		&teamsList,
-		client.InNamespace(req.Namespace),
-	); err != nil {
-		return nil, fmt.Errorf("failed to list teams: %v", err)
-	}
-
-	var teams []*v1alpha1.Team
-	for i := range teamsList.Items {
-		team := &teamsList.Items[i]
-		if getRefFromString(team.Spec.ModelConfig, team.Namespace) == req.NamespacedName {
-			teams = append(teams, team)
-		}
-	}
-
-	return teams, nil
-}
-
-func (a *autogenReconciler) findTeamsUsingApiKeySecret(ctx context.Context, req ctrl.Request) ([]*v1alpha1.Team, error) {
-	var modelsList v1alpha1.ModelConfigList
-	if err := a.kube.List(
-		ctx,
-		&modelsList,
-		client.InNamespace(req.Namespace),
-	); err != nil {
-		return nil, fmt.Errorf("failed to list model configs: %v", err)
-	}
-
-	var models []string
-	for _, model := range modelsList.Items {
-		if getRefFromString(model.Spec.APIKeySecretRef, model.Namespace) == req.NamespacedName {
-			models = append(models, model.Name)
-		}
-	}
-
-	var teams []*v1alpha1.Team
-	uniqueTeams := make(map[string]bool)
-
-	for _, modelName := range models {
-		teamsUsingModel, err := a.findTeamsUsingModel(ctx, ctrl.Request{
-			NamespacedName: types.NamespacedName{
-				Namespace: req.Namespace,
-				Name:      modelName,
-			},
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to find teams for model %s: %v", modelName, err)
-		}
-
-		for _, team := range teamsUsingModel {
-			key := fmt.Sprintf("%s/%s", team.Namespace, team.Name)
-			if !uniqueTeams[key] {
-				uniqueTeams[key] = true
-				teams = append(teams, team)
-			}
-		}
-	}
-
-	return teams, nil
-}
-
-func (a *autogenReconciler) findAgentsUsingToolServer(ctx context.Context, req ctrl.Request) ([]*v1alpha1.Agent, error) {
-	var agentsList v1alpha1.AgentList
-	if err := a.kube.List(
-		ctx,
-		&agentsList,
-		client.InNamespace(req.Namespace),
-	); err != nil {
-		return nil, fmt.Errorf("failed to list agents: %v", err)
-	}
-
-	var agents []*v1alpha1.Agent
-	appendAgentIfUsesToolServer := func(agent *v1alpha1.Agent) {
-		for _, tool := range agent.Spec.Tools {
-			if tool.McpServer != nil && getRefFromString(tool.McpServer.ToolServer, agent.Namespace) == req.NamespacedName {
-				agents = append(agents, agent)
-				return
-			}
-		}
-	}
-
-	for _, agent := range agentsList.Items {
-		agent := agent
-		appendAgentIfUsesToolServer(&agent)
-	}
-
-	return agents, nil
-
-}
-
 func (a *autogenReconciler) getDiscoveredMCPTools(serverID int) ([]*v1alpha1.MCPTool, error) {
 	allTools, err := a.autogenClient.ListTools(common.GetGlobalUserID())
 	if err != nil {
@@ -783,11 +997,50 @@ func (a *autogenReconciler) getDiscoveredMCPTools(serverID int) ([]*v1alpha1.MCP
 	return discoveredTools, nil
 }
 
+func (a *autogenReconciler) getDiscoveredMCPContext(serverID int) ([]*v1alpha1.MCPResource, []*v1alpha1.MCPPrompt, error) {
+	resources, err := a.autogenClient.ListResourcesForServer(serverID, common.GetGlobalUserID())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list resources: %v", err)
+	}
+	prompts, err := a.autogenClient.ListPromptsForServer(serverID, common.GetGlobalUserID())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list prompts: %v", err)
+	}
+
+	var discoveredResources []*v1alpha1.MCPResource
+	for _, resource := range resources {
+		discoveredResources = append(discoveredResources, &v1alpha1.MCPResource{
+			URI:         resource.URI,
+			Name:        resource.Name,
+			Description: resource.Description,
+			MimeType:    resource.MimeType,
+		})
+	}
+
+	var discoveredPrompts []*v1alpha1.MCPPrompt
+	for _, prompt := range prompts {
+		discoveredPrompt := &v1alpha1.MCPPrompt{
+			Name:        prompt.Name,
+			Description: prompt.Description,
+		}
+		for _, argument := range prompt.Arguments {
+			discoveredPrompt.Arguments = append(discoveredPrompt.Arguments, v1alpha1.MCPPromptArgument{
+				Name:        argument.Name,
+				Description: argument.Description,
+				Required:    argument.Required,
+			})
+		}
+		discoveredPrompts = append(discoveredPrompts, discoveredPrompt)
+	}
+
+	return discoveredResources, discoveredPrompts, nil
+}
+
 func (a *autogenReconciler) reconcileA2A(
 	ctx context.Context,
 	team *autogen_client.Team,
 	agent *v1alpha1.Agent,
-) error {
+) (string, error) {
 	return a.a2aReconciler.ReconcileAutogenAgent(ctx, agent, team)
 }
 
@@ -800,7 +1053,11 @@ func convertTool(tool *autogen_client.Tool) (*v1alpha1.MCPTool, error) {
 	if err := unmarshalFromMap(config, &mcpToolConfig); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal tool config: %v", err)
 	}
-	component, err := convertComponentToApiType(tool.Component)
+	// the status is readable by anyone allowed to get the tool server, the credentials
+	// are added again from the tool server when translating the agents using the tool
+	strippedComponent := *tool.Component
+	strippedComponent.Config = withoutServerCredentials(config)
+	component, err := convertComponentToApiType(&strippedComponent)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert component: %v", err)
 	}
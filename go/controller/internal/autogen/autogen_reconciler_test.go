@@ -0,0 +1,1258 @@
+package autogen_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/autogen/api"
+	autogen_client "github.com/kagent-dev/kagent/go/autogen/client"
+	"github.com/kagent-dev/kagent/go/autogen/client/fake"
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"github.com/kagent-dev/kagent/go/controller/internal/a2a"
+	"github.com/kagent-dev/kagent/go/controller/internal/autogen"
+	common "github.com/kagent-dev/kagent/go/controller/internal/utils"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+// the namespace of the resources of the reconciler tests
+const testNamespace = "test-namespace"
+
+// testReconciler is an autogen reconciler with the fake clients it uses
+type testReconciler struct {
+	autogen.AutogenReconciler
+	kubeClient    client.Client
+	autogenClient autogen_client.Client
+	recorder      *record.FakeRecorder
+	availability  *autogen.AutogenAvailability
+}
+
+// newTestReconciler creates a reconciler of the given resources which sends the teams to an in-memory autogen
+func newTestReconciler(t *testing.T, objects ...client.Object) *testReconciler {
+	return newTestReconcilerWithAutogen(t, fake.NewInMemoryAutogenClient(), objects...)
+}
+
+// newTestReconcilerWithAutogen creates a reconciler of the given resources which sends the teams to the given autogen.
+// The default model config and its secret are added unless the resources replace them.
+func newTestReconcilerWithAutogen(t *testing.T, autogenClient autogen_client.Client, objects ...client.Object) *testReconciler {
+	err := v1alpha1.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
+
+	defaultModelConfig := types.NamespacedName{Namespace: testNamespace, Name: "default-model"}
+	defaults := []client.Object{
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "openai-secret", Namespace: testNamespace},
+			Data:       map[string][]byte{"api-key": []byte("sk-test")},
+		},
+		&v1alpha1.ModelConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: defaultModelConfig.Name, Namespace: defaultModelConfig.Namespace},
+			Spec: v1alpha1.ModelConfigSpec{
+				Provider:        v1alpha1.OpenAI,
+				Model:           "gpt-4o",
+				APIKeySecretRef: "openai-secret",
+				APIKeySecretKey: "api-key",
+			},
+		},
+	}
+	for _, defaultObject := range defaults {
+		if !slices.ContainsFunc(objects, func(obj client.Object) bool {
+			return reflect.TypeOf(obj) == reflect.TypeOf(defaultObject) &&
+				client.ObjectKeyFromObject(obj) == client.ObjectKeyFromObject(defaultObject)
+		}) {
+			objects = append(objects, defaultObject)
+		}
+	}
+
+	builder := fakeclient.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithStatusSubresource(&v1alpha1.Agent{}, &v1alpha1.Team{}, &v1alpha1.ToolServer{}, &v1alpha1.RemoteAgent{}).
+		WithObjects(objects...)
+	err = autogen.SetupIndexes(context.Background(), builderIndexer{builder: builder})
+	require.NoError(t, err)
+	kubeClient := builder.Build()
+
+	recorder := record.NewFakeRecorder(10)
+	availability := autogen.NewAutogenAvailability(autogenClient, kubeClient)
+	return &testReconciler{
+		AutogenReconciler: autogen.NewAutogenReconciler(
+			autogen.NewAutogenApiTranslator(kubeClient, defaultModelConfig),
+			kubeClient,
+			autogenClient,
+			defaultModelConfig,
+			a2a.NewAutogenReconciler(autogenClient, a2a.NewA2AHttpMux("/api/a2a"), "http://localhost/api/a2a"),
+			recorder,
+			availability,
+		),
+		kubeClient:    kubeClient,
+		autogenClient: autogenClient,
+		recorder:      recorder,
+		availability:  availability,
+	}
+}
+
+// getAgent returns the agent with the given name in the test namespace
+func (r *testReconciler) getAgent(t *testing.T, name string) *v1alpha1.Agent {
+	agent := &v1alpha1.Agent{}
+	require.NoError(t, r.kubeClient.Get(context.Background(), types.NamespacedName{Name: name, Namespace: testNamespace}, agent))
+	return agent
+}
+
+// newHelperAgent returns an agent named helper using the default model config
+func newHelperAgent() *v1alpha1.Agent {
+	return &v1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "helper", Namespace: testNamespace},
+		Spec: v1alpha1.AgentSpec{
+			SystemMessage: "You are helpful",
+		},
+	}
+}
+
+// agentRequest is the request reconciling the agent with the given name in the test namespace
+func agentRequest(name string) ctrl.Request {
+	return ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: testNamespace}}
+}
+
+func TestReconcileAutogenToolServer(t *testing.T) {
+	ctx := context.Background()
+	namespace := testNamespace
+
+	t.Run("should report connected and discovered tools", func(t *testing.T) {
+		toolServer := &v1alpha1.ToolServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: namespace},
+			Spec: v1alpha1.ToolServerSpec{
+				Config: v1alpha1.ToolServerConfig{
+					Sse: &v1alpha1.SseMcpServerConfig{URL: "http://test-server:8080/sse"},
+				},
+				RefreshInterval: &metav1.Duration{Duration: 5 * time.Minute},
+			},
+		}
+		reconciler := newTestReconciler(t, toolServer)
+		kubeClient := reconciler.kubeClient
+
+		result, err := reconciler.ReconcileAutogenToolServer(ctx, ctrl.Request{
+			NamespacedName: types.NamespacedName{Name: "test-server", Namespace: namespace},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 5*time.Minute, result.RequeueAfter)
+
+		updated := &v1alpha1.ToolServer{}
+		err = kubeClient.Get(ctx, types.NamespacedName{Name: "test-server", Namespace: namespace}, updated)
+		require.NoError(t, err)
+		assert.True(t, meta.IsStatusConditionTrue(updated.Status.Conditions, v1alpha1.ToolServerConditionTypeConnected))
+		assert.True(t, meta.IsStatusConditionTrue(updated.Status.Conditions, v1alpha1.ToolServerConditionTypeToolsDiscovered))
+		assert.NotNil(t, updated.Status.LastConnectedTime)
+		assert.NotNil(t, updated.Status.LastDiscoveryTime)
+		assert.Zero(t, updated.Status.ConsecutiveFailures)
+
+		// refreshing a tool server which didn't change doesn't update its status
+		_, err = reconciler.ReconcileAutogenToolServer(ctx, ctrl.Request{
+			NamespacedName: types.NamespacedName{Name: "test-server", Namespace: namespace},
+		})
+		require.NoError(t, err)
+		refreshed := &v1alpha1.ToolServer{}
+		err = kubeClient.Get(ctx, types.NamespacedName{Name: "test-server", Namespace: namespace}, refreshed)
+		require.NoError(t, err)
+		assert.Equal(t, updated.ResourceVersion, refreshed.ResourceVersion)
+	})
+
+	t.Run("should report discovered tools without credentials", func(t *testing.T) {
+		toolServer := &v1alpha1.ToolServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "private-server", Namespace: namespace},
+			Spec: v1alpha1.ToolServerSpec{
+				Config: v1alpha1.ToolServerConfig{
+					Sse: &v1alpha1.SseMcpServerConfig{URL: "https://private-server:8443/sse"},
+				},
+			},
+		}
+		reconciler := newTestReconciler(t, toolServer)
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "private-server", Namespace: namespace}}
+
+		_, err := reconciler.ReconcileAutogenToolServer(ctx, req)
+		require.NoError(t, err)
+		autogenToolServer, err := reconciler.autogenClient.GetToolServerByLabel("private-server", "")
+		require.NoError(t, err)
+		reconciler.autogenClient.(*fake.InMemoryAutogenClient).AddTool(&autogen_client.Tool{
+			ServerID: &autogenToolServer.Id,
+			Component: &api.Component{
+				Provider:      "kagent.tools.mcp.TlsSseMcpToolAdapter",
+				ComponentType: "tool",
+				Label:         "search",
+				Config: map[string]interface{}{
+					"server_params": map[string]interface{}{
+						"url":     "https://private-server:8443/sse",
+						"headers": map[string]interface{}{"Authorization": "Bearer secret-token"},
+						"tls":     map[string]interface{}{"client_key": "secret-key"},
+					},
+					"tool": map[string]interface{}{"name": "search"},
+				},
+			},
+		})
+		_, err = reconciler.ReconcileAutogenToolServer(ctx, req)
+		require.NoError(t, err)
+
+		updated := &v1alpha1.ToolServer{}
+		require.NoError(t, reconciler.kubeClient.Get(ctx, req.NamespacedName, updated))
+		require.Len(t, updated.Status.DiscoveredTools, 1)
+		assert.Equal(t, "search", updated.Status.DiscoveredTools[0].Name)
+		assert.JSONEq(t, `{"url": "https://private-server:8443/sse"}`,
+			string(updated.Status.DiscoveredTools[0].Component.Config["server_params"].RawMessage))
+	})
+
+	t.Run("should back off while the tool server fails", func(t *testing.T) {
+		toolServer := &v1alpha1.ToolServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "failing-server", Namespace: namespace},
+			Spec: v1alpha1.ToolServerSpec{
+				Config: v1alpha1.ToolServerConfig{
+					Sse: &v1alpha1.SseMcpServerConfig{
+						URL: "http://failing-server:8080/sse",
+						HeadersFrom: []v1alpha1.ValueRef{{
+							Name: "Authorization",
+							ValueFrom: &v1alpha1.ValueSource{
+								Type:     v1alpha1.SecretValueSource,
+								ValueRef: "missing-secret",
+								Key:      "token",
+							},
+						}},
+					},
+				},
+			},
+		}
+		reconciler := newTestReconciler(t, toolServer)
+		kubeClient := reconciler.kubeClient
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "failing-server", Namespace: namespace}}
+
+		var requeues []time.Duration
+		for range 3 {
+			result, err := reconciler.ReconcileAutogenToolServer(ctx, req)
+			require.NoError(t, err)
+			requeues = append(requeues, result.RequeueAfter)
+		}
+		assert.Equal(t, []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second}, requeues)
+
+		updated := &v1alpha1.ToolServer{}
+		require.NoError(t, kubeClient.Get(ctx, req.NamespacedName, updated))
+		assert.Equal(t, int32(3), updated.Status.ConsecutiveFailures)
+
+		connected := meta.FindStatusCondition(updated.Status.Conditions, v1alpha1.ToolServerConditionTypeConnected)
+		require.NotNil(t, connected)
+		assert.Equal(t, metav1.ConditionFalse, connected.Status)
+		assert.Equal(t, "ReconcileFailed", connected.Reason)
+		assert.True(t, meta.IsStatusConditionPresentAndEqual(updated.Status.Conditions, v1alpha1.ToolServerConditionTypeToolsDiscovered, metav1.ConditionUnknown))
+		assert.Nil(t, updated.Status.LastDiscoveryTime)
+	})
+
+	t.Run("should delete the autogen tool server before the tool server", func(t *testing.T) {
+		toolServer := &v1alpha1.ToolServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "deleted-server", Namespace: namespace},
+			Spec: v1alpha1.ToolServerSpec{
+				Config: v1alpha1.ToolServerConfig{
+					Sse: &v1alpha1.SseMcpServerConfig{URL: "http://deleted-server:8080/sse"},
+				},
+			},
+		}
+		reconciler := newTestReconciler(t, toolServer)
+		kubeClient, autogenClient := reconciler.kubeClient, reconciler.autogenClient
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "deleted-server", Namespace: namespace}}
+
+		_, err := reconciler.ReconcileAutogenToolServer(ctx, req)
+		require.NoError(t, err)
+		require.NoError(t, kubeClient.Get(ctx, req.NamespacedName, toolServer))
+		assert.Contains(t, toolServer.Finalizers, autogen.KagentFinalizer)
+		_, err = autogenClient.GetToolServerByLabel("deleted-server", "")
+		require.NoError(t, err)
+
+		require.NoError(t, kubeClient.Delete(ctx, toolServer))
+		_, err = reconciler.ReconcileAutogenToolServer(ctx, req)
+		require.NoError(t, err)
+
+		_, err = autogenClient.GetToolServerByLabel("deleted-server", "")
+		assert.Error(t, err)
+		assert.True(t, k8s_errors.IsNotFound(kubeClient.Get(ctx, req.NamespacedName, &v1alpha1.ToolServer{})))
+	})
+}
+
+// builderIndexer registers field indexes on a fake client builder
+type builderIndexer struct {
+	builder *fakeclient.ClientBuilder
+}
+
+func (b builderIndexer) IndexField(_ context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error {
+	b.builder.WithIndex(obj, field, extractValue)
+	return nil
+}
+
+func TestRequestsForIndex(t *testing.T) {
+	ctx := context.Background()
+	scheme := scheme.Scheme
+	err := v1alpha1.AddToScheme(scheme)
+	require.NoError(t, err)
+
+	namespace := "test-namespace"
+
+	newToolServer := func(name string, valueSource v1alpha1.ValueSource) *v1alpha1.ToolServer {
+		return &v1alpha1.ToolServer{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: v1alpha1.ToolServerSpec{
+				Config: v1alpha1.ToolServerConfig{
+					Stdio: &v1alpha1.StdioMcpServerConfig{
+						Command: "echo",
+						EnvFrom: []v1alpha1.ValueRef{{
+							Name:      "TEST_ENV",
+							ValueFrom: &valueSource,
+						}},
+					},
+				},
+			},
+		}
+	}
+	newAgent := func(name, modelConfig string) *v1alpha1.Agent {
+		return &v1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: v1alpha1.AgentSpec{
+				SystemMessage: "You are " + name,
+				ModelConfig:   modelConfig,
+			},
+		}
+	}
+	newModelConfig := func(name, secret string) *v1alpha1.ModelConfig {
+		return &v1alpha1.ModelConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: v1alpha1.ModelConfigSpec{
+				Provider:        v1alpha1.OpenAI,
+				Model:           "gpt-4o",
+				APIKeySecretRef: secret,
+				APIKeySecretKey: "test-key",
+			},
+		}
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-config", Namespace: namespace},
+		Data:       map[string]string{"test-key": "test-value"},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-config", Namespace: namespace},
+		Data:       map[string][]byte{"test-key": []byte("test-value")},
+	}
+
+	builder := fakeclient.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			newToolServer("configmap-server", v1alpha1.ValueSource{
+				Type:     v1alpha1.ConfigMapValueSource,
+				ValueRef: "test-config",
+				Key:      "test-key",
+			}),
+			newToolServer("other-configmap-server", v1alpha1.ValueSource{
+				Type:     v1alpha1.ConfigMapValueSource,
+				ValueRef: "other-config",
+				Key:      "test-key",
+			}),
+			newToolServer("secret-server", v1alpha1.ValueSource{
+				Type:     v1alpha1.SecretValueSource,
+				ValueRef: "test-namespace/test-config",
+				Key:      "test-key",
+			}),
+			newModelConfig("secret-model", "test-config"),
+			newModelConfig("other-model", "other-secret"),
+			newAgent("secret-agent", "secret-model"),
+			newAgent("other-agent", "other-model"),
+		)
+	err = autogen.SetupIndexes(ctx, builderIndexer{builder: builder})
+	require.NoError(t, err)
+	kubeClient := builder.Build()
+
+	requestedNames := func(mapFunc handler.MapFunc, obj client.Object) []string {
+		var names []string
+		for _, req := range mapFunc(ctx, obj) {
+			names = append(names, req.Name)
+		}
+		return names
+	}
+
+	t.Run("should only enqueue the tool servers referencing the config map", func(t *testing.T) {
+		mapFunc := autogen.RequestsForIndex(kubeClient, &v1alpha1.ToolServerList{}, autogen.ToolServerConfigMapIndex)
+		assert.Equal(t, []string{"configmap-server"}, requestedNames(mapFunc, configMap))
+	})
+
+	t.Run("should only enqueue the tool servers referencing the secret", func(t *testing.T) {
+		mapFunc := autogen.RequestsForIndex(kubeClient, &v1alpha1.ToolServerList{}, autogen.ToolServerSecretIndex)
+		assert.Equal(t, []string{"secret-server"}, requestedNames(mapFunc, secret))
+	})
+
+	t.Run("should enqueue the agents using a model config which uses the secret", func(t *testing.T) {
+		mapFunc := autogen.RequestsForIndexThrough(
+			kubeClient,
+			&v1alpha1.ModelConfigList{}, autogen.ModelConfigSecretIndex,
+			&v1alpha1.AgentList{}, autogen.AgentModelConfigIndex,
+		)
+		assert.Equal(t, []string{"secret-agent"}, requestedNames(mapFunc, secret))
+	})
+}
+
+func TestReconcileAutogenAgentServiceAccount(t *testing.T) {
+	ctx := context.Background()
+	namespace := testNamespace
+
+	reconcileAgent := func(objects ...client.Object) (ctrl.Result, *v1alpha1.Agent, error) {
+		objects = append(objects,
+			&v1alpha1.Agent{
+				ObjectMeta: metav1.ObjectMeta{Name: "k8s-agent", Namespace: namespace},
+				Spec: v1alpha1.AgentSpec{
+					SystemMessage:      "You are a Kubernetes agent.",
+					ServiceAccountName: "k8s-agent",
+					Tools: []*v1alpha1.Tool{{
+						Type:    v1alpha1.ToolProviderType_Builtin,
+						Builtin: &v1alpha1.BuiltinTool{Name: "kagent.tools.k8s.GetResources"},
+					}},
+				},
+			},
+		)
+		reconciler := newTestReconciler(t, objects...)
+
+		result, err := reconciler.ReconcileAutogenAgent(ctx, agentRequest("k8s-agent"))
+		return result, reconciler.getAgent(t, "k8s-agent"), err
+	}
+
+	t.Run("should renew the token of the service account", func(t *testing.T) {
+		result, agent, err := reconcileAgent(&corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{Name: "k8s-agent", Namespace: namespace},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, autogen.ServiceAccountTokenRefreshInterval, result.RequeueAfter)
+		assert.True(t, meta.IsStatusConditionTrue(agent.Status.Conditions, v1alpha1.AgentConditionTypeAccepted))
+		assert.True(t, meta.IsStatusConditionTrue(agent.Status.Conditions, v1alpha1.AgentConditionTypeServiceAccountReady))
+	})
+
+	t.Run("should warn when the service account is missing", func(t *testing.T) {
+		_, agent, err := reconcileAgent()
+		require.Error(t, err)
+		assert.True(t, meta.IsStatusConditionFalse(agent.Status.Conditions, v1alpha1.AgentConditionTypeServiceAccountReady))
+		assert.Equal(t, "ServiceAccountNotFound", meta.FindStatusCondition(agent.Status.Conditions, v1alpha1.AgentConditionTypeServiceAccountReady).Reason)
+	})
+}
+
+func TestReconcileAutogenTeamServiceAccount(t *testing.T) {
+	ctx := context.Background()
+	namespace := testNamespace
+
+	reconcileTeam := func(participant *v1alpha1.Agent) ctrl.Result {
+		team := &v1alpha1.Team{
+			ObjectMeta: metav1.ObjectMeta{Name: "k8s-team", Namespace: namespace},
+			Spec: v1alpha1.TeamSpec{
+				Participants:         []string{participant.Name},
+				Description:          "Kubernetes team",
+				RoundRobinTeamConfig: &v1alpha1.RoundRobinTeamConfig{},
+				TerminationCondition: v1alpha1.TerminationCondition{
+					MaxMessageTermination: &v1alpha1.MaxMessageTermination{MaxMessages: 10},
+				},
+			},
+		}
+		reconciler := newTestReconciler(t, participant, team, &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{Name: "k8s-agent", Namespace: namespace},
+		})
+
+		result, err := reconciler.ReconcileAutogenTeam(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(team)})
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("should renew the token of the service account of a participant", func(t *testing.T) {
+		result := reconcileTeam(&v1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "k8s-agent", Namespace: namespace},
+			Spec: v1alpha1.AgentSpec{
+				SystemMessage:      "You are a Kubernetes agent.",
+				ServiceAccountName: "k8s-agent",
+				Tools: []*v1alpha1.Tool{{
+					Type:    v1alpha1.ToolProviderType_Builtin,
+					Builtin: &v1alpha1.BuiltinTool{Name: "kagent.tools.k8s.GetResources"},
+				}},
+			},
+		})
+		assert.Equal(t, autogen.ServiceAccountTokenRefreshInterval, result.RequeueAfter)
+	})
+
+	t.Run("should not requeue a team without a service account token", func(t *testing.T) {
+		result := reconcileTeam(newHelperAgent())
+		assert.Zero(t, result.RequeueAfter)
+	})
+}
+
+func TestReconcileAutogenAgentToolCycle(t *testing.T) {
+	ctx := context.Background()
+	namespace := testNamespace
+
+	newAgent := func(name, toolRef string) *v1alpha1.Agent {
+		return &v1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: v1alpha1.AgentSpec{
+				SystemMessage: "You are " + name,
+				Tools: []*v1alpha1.Tool{{
+					Type:  v1alpha1.ToolProviderType_Agent,
+					Agent: &v1alpha1.AgentTool{Ref: toolRef},
+				}},
+			},
+		}
+	}
+	reconciler := newTestReconciler(t, newAgent("planner", "executor"), newAgent("executor", "planner"))
+
+	_, err := reconciler.ReconcileAutogenAgent(ctx, agentRequest("planner"))
+	require.Error(t, err)
+
+	agent := reconciler.getAgent(t, "planner")
+	condition := meta.FindStatusCondition(agent.Status.Conditions, v1alpha1.AgentConditionTypeAccepted)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "AgentToolCycleDetected", condition.Reason)
+	assert.Equal(t, "cycle detected in agent tool chain: Agent test-namespace/planner -> "+
+		"Agent test-namespace/executor -> Agent test-namespace/planner", condition.Message)
+}
+
+func TestReconcileAutogenAgentDeletion(t *testing.T) {
+	ctx := context.Background()
+	namespace := testNamespace
+
+	newAgent := func(name string, toolRefs ...string) *v1alpha1.Agent {
+		agent := &v1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: v1alpha1.AgentSpec{
+				SystemMessage: "You are " + name,
+			},
+		}
+		for _, ref := range toolRefs {
+			agent.Spec.Tools = append(agent.Spec.Tools, &v1alpha1.Tool{
+				Type:  v1alpha1.ToolProviderType_Agent,
+				Agent: &v1alpha1.AgentTool{Ref: ref},
+			})
+		}
+		return agent
+	}
+	reconciler := newTestReconciler(t, newAgent("helper"), newAgent("planner", "helper"))
+	kubeClient, autogenClient := reconciler.kubeClient, reconciler.autogenClient
+	helperReq := agentRequest("helper")
+	plannerReq := agentRequest("planner")
+
+	for _, req := range []ctrl.Request{helperReq, plannerReq} {
+		_, err := reconciler.ReconcileAutogenAgent(ctx, req)
+		require.NoError(t, err)
+	}
+
+	helper := &v1alpha1.Agent{}
+	require.NoError(t, kubeClient.Get(ctx, helperReq.NamespacedName, helper))
+	assert.Contains(t, helper.Finalizers, autogen.KagentFinalizer)
+	team, err := autogenClient.GetTeam("helper", "")
+	require.NoError(t, err)
+	require.NotNil(t, team)
+
+	// the finalizer deletes the autogen team while the agent is being deleted
+	require.NoError(t, kubeClient.Delete(ctx, helper))
+	_, err = reconciler.ReconcileAutogenAgent(ctx, helperReq)
+	require.NoError(t, err)
+
+	team, err = autogenClient.GetTeam("helper", "")
+	require.NoError(t, err)
+	assert.Nil(t, team)
+	assert.True(t, k8s_errors.IsNotFound(kubeClient.Get(ctx, helperReq.NamespacedName, &v1alpha1.Agent{})))
+
+	// the agents using the deleted agent are enqueued and report it once it is gone
+	_, err = reconciler.ReconcileAutogenAgent(ctx, helperReq)
+	require.NoError(t, err)
+	requests := autogen.RequestsForIndex(kubeClient, &v1alpha1.AgentList{}, autogen.AgentAgentToolIndex)(ctx, helper)
+	assert.Equal(t, []ctrl.Request{plannerReq}, requests)
+	_, err = reconciler.ReconcileAutogenAgent(ctx, plannerReq)
+	require.Error(t, err)
+
+	planner := &v1alpha1.Agent{}
+	require.NoError(t, kubeClient.Get(ctx, plannerReq.NamespacedName, planner))
+	accepted := meta.FindStatusCondition(planner.Status.Conditions, v1alpha1.AgentConditionTypeAccepted)
+	require.NotNil(t, accepted)
+	assert.Equal(t, metav1.ConditionFalse, accepted.Status)
+	assert.Contains(t, accepted.Message, `agents.kagent.dev "helper" not found`)
+}
+
+func TestReconcileAutogenAgentDeletionAcrossNamespaces(t *testing.T) {
+	ctx := context.Background()
+
+	other := newHelperAgent()
+	other.Namespace = "other-namespace"
+	reconciler := newTestReconciler(t, newHelperAgent(), other)
+	kubeClient, autogenClient := reconciler.kubeClient, reconciler.autogenClient
+	otherReq := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(other)}
+
+	for _, req := range []ctrl.Request{agentRequest("helper"), otherReq} {
+		_, err := reconciler.ReconcileAutogenAgent(ctx, req)
+		require.NoError(t, err)
+	}
+
+	// the autogen team is kept while the agent of the other namespace uses it
+	require.NoError(t, kubeClient.Delete(ctx, reconciler.getAgent(t, "helper")))
+	_, err := reconciler.ReconcileAutogenAgent(ctx, agentRequest("helper"))
+	require.NoError(t, err)
+	team, err := autogenClient.GetTeam("helper", "")
+	require.NoError(t, err)
+	assert.NotNil(t, team)
+
+	// the agent whose team is gone doesn't delete anything once it is gone
+	_, err = reconciler.ReconcileAutogenAgent(ctx, agentRequest("helper"))
+	require.NoError(t, err)
+	team, err = autogenClient.GetTeam("helper", "")
+	require.NoError(t, err)
+	assert.NotNil(t, team)
+
+	require.NoError(t, kubeClient.Get(ctx, otherReq.NamespacedName, other))
+	require.NoError(t, kubeClient.Delete(ctx, other))
+	_, err = reconciler.ReconcileAutogenAgent(ctx, otherReq)
+	require.NoError(t, err)
+	team, err = autogenClient.GetTeam("helper", "")
+	require.NoError(t, err)
+	assert.Nil(t, team)
+}
+
+// countingAutogenClient counts the teams sent to autogen
+type countingAutogenClient struct {
+	autogen_client.Client
+	createdTeams int
+}
+
+func (c *countingAutogenClient) CreateTeam(team *autogen_client.Team) error {
+	c.createdTeams++
+	return c.Client.CreateTeam(team)
+}
+
+func TestReconcileAutogenAgentComponentHash(t *testing.T) {
+	ctx := context.Background()
+
+	autogenClient := &countingAutogenClient{Client: fake.NewInMemoryAutogenClient()}
+	reconciler := newTestReconcilerWithAutogen(t, autogenClient, newHelperAgent())
+	reconcileAgent := func() *v1alpha1.Agent {
+		_, err := reconciler.ReconcileAutogenAgent(ctx, agentRequest("helper"))
+		require.NoError(t, err)
+		return reconciler.getAgent(t, "helper")
+	}
+
+	agent := reconcileAgent()
+	assert.Equal(t, 1, autogenClient.createdTeams)
+	assert.NotEmpty(t, agent.Status.ComponentHash)
+	componentHash := agent.Status.ComponentHash
+
+	t.Run("should not send the agent again while it is unchanged", func(t *testing.T) {
+		agent := reconcileAgent()
+		assert.Equal(t, 1, autogenClient.createdTeams)
+		assert.Equal(t, componentHash, agent.Status.ComponentHash)
+	})
+
+	t.Run("should send the agent again once it changes", func(t *testing.T) {
+		agent.Spec.SystemMessage = "You are very helpful"
+		require.NoError(t, reconciler.kubeClient.Update(ctx, agent))
+
+		agent := reconcileAgent()
+		assert.Equal(t, 2, autogenClient.createdTeams)
+		assert.NotEqual(t, componentHash, agent.Status.ComponentHash)
+	})
+}
+
+func TestReconcileAutogenTeamParticipantToolServer(t *testing.T) {
+	ctx := context.Background()
+
+	newDiscoveredTool := func(name string) *v1alpha1.MCPTool {
+		return &v1alpha1.MCPTool{
+			Name: name,
+			Component: v1alpha1.Component{
+				Provider:      "autogen_ext.tools.mcp.SseMcpToolAdapter",
+				ComponentType: "tool",
+				Version:       1,
+				Label:         name,
+				Config: map[string]v1alpha1.AnyType{
+					"server_params": {RawMessage: []byte(`{"url": "http://k8s-tools:8080/sse"}`)},
+					"tool":          {RawMessage: []byte(`{"name": "` + name + `"}`)},
+				},
+			},
+		}
+	}
+	toolServer := &v1alpha1.ToolServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "k8s-tools", Namespace: testNamespace},
+		Spec: v1alpha1.ToolServerSpec{
+			Config: v1alpha1.ToolServerConfig{Sse: &v1alpha1.SseMcpServerConfig{URL: "http://k8s-tools:8080/sse"}},
+		},
+		Status: v1alpha1.ToolServerStatus{DiscoveredTools: []*v1alpha1.MCPTool{newDiscoveredTool("get_pods")}},
+	}
+	helper := newHelperAgent()
+	helper.Spec.Tools = []*v1alpha1.Tool{{
+		Type:      v1alpha1.ToolProviderType_McpServer,
+		McpServer: &v1alpha1.McpServerTool{ToolServer: "k8s-tools", IncludePatterns: []string{"*"}},
+	}}
+	team := &v1alpha1.Team{
+		ObjectMeta: metav1.ObjectMeta{Name: "k8s-team", Namespace: testNamespace},
+		Spec: v1alpha1.TeamSpec{
+			Participants:         []string{"helper"},
+			Description:          "Kubernetes team",
+			RoundRobinTeamConfig: &v1alpha1.RoundRobinTeamConfig{},
+			TerminationCondition: v1alpha1.TerminationCondition{
+				MaxMessageTermination: &v1alpha1.MaxMessageTermination{MaxMessages: 10},
+			},
+		},
+	}
+	reconciler := newTestReconciler(t, toolServer, helper, team)
+	kubeClient := reconciler.kubeClient
+	teamReq := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(team)}
+
+	_, err := reconciler.ReconcileAutogenAgent(ctx, agentRequest("helper"))
+	require.NoError(t, err)
+	_, err = reconciler.ReconcileAutogenTeam(ctx, teamReq)
+	require.NoError(t, err)
+	teamComponent := func() string {
+		team := &v1alpha1.Team{}
+		require.NoError(t, kubeClient.Get(ctx, teamReq.NamespacedName, team))
+		autogenTeam, err := reconciler.autogenClient.GetTeamByID(team.Status.TeamID, common.GetGlobalUserID())
+		require.NoError(t, err)
+		require.NotNil(t, autogenTeam)
+		component, err := json.Marshal(autogenTeam.Component)
+		require.NoError(t, err)
+		return string(component)
+	}
+	assert.NotContains(t, teamComponent(), "get_services")
+
+	// a tool is discovered on the tool server of the participant, which doesn't change its spec
+	updatedToolServer := &v1alpha1.ToolServer{}
+	require.NoError(t, kubeClient.Get(ctx, client.ObjectKeyFromObject(toolServer), updatedToolServer))
+	updatedToolServer.Status.DiscoveredTools = append(updatedToolServer.Status.DiscoveredTools, newDiscoveredTool("get_services"))
+	require.NoError(t, kubeClient.Status().Update(ctx, updatedToolServer))
+
+	agentRequests := autogen.RequestsForIndex(kubeClient, &v1alpha1.AgentList{}, autogen.AgentToolServerIndex)(ctx, updatedToolServer)
+	require.Equal(t, []ctrl.Request{agentRequest("helper")}, agentRequests)
+	oldHelper := reconciler.getAgent(t, "helper")
+	_, err = reconciler.ReconcileAutogenAgent(ctx, agentRequests[0])
+	require.NoError(t, err)
+	newHelper := reconciler.getAgent(t, "helper")
+	require.Equal(t, oldHelper.Generation, newHelper.Generation)
+
+	// the team is enqueued as the translation of its participant changed, and translated again with the new tool
+	assert.True(t, autogen.AgentTranslationChanged().Update(event.UpdateEvent{ObjectOld: oldHelper, ObjectNew: newHelper}))
+	teamRequests := autogen.RequestsForIndex(kubeClient, &v1alpha1.TeamList{}, autogen.TeamParticipantIndex)(ctx, newHelper)
+	require.Equal(t, []ctrl.Request{teamReq}, teamRequests)
+	_, err = reconciler.ReconcileAutogenTeam(ctx, teamRequests[0])
+	require.NoError(t, err)
+	assert.Contains(t, teamComponent(), "get_services")
+
+	// the status updates which don't change the translation of the participant don't enqueue the team
+	unchangedHelper := newHelper.DeepCopy()
+	unchangedHelper.Status.ObservedGeneration++
+	assert.False(t, autogen.AgentTranslationChanged().Update(event.UpdateEvent{ObjectOld: newHelper, ObjectNew: unchangedHelper}))
+}
+
+func TestReconcileAutogenAgentStatus(t *testing.T) {
+	ctx := context.Background()
+	namespace := testNamespace
+
+	agent := newHelperAgent()
+	agent.Spec.Memory = []string{"notes"}
+	agent.Spec.A2AConfig = &v1alpha1.A2AConfig{
+		Skills: []v1alpha1.AgentSkill{{ID: "help", Name: "Help"}},
+	}
+	reconciler := newTestReconciler(t, agent, &v1alpha1.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "default-model", Namespace: namespace, Generation: 3},
+		Spec: v1alpha1.ModelConfigSpec{
+			Provider:        v1alpha1.OpenAI,
+			Model:           "gpt-4o",
+			APIKeySecretRef: "openai-secret",
+			APIKeySecretKey: "api-key",
+		},
+	})
+	req := agentRequest("helper")
+	getAgent := func() *v1alpha1.Agent {
+		return reconciler.getAgent(t, "helper")
+	}
+	conditionStatuses := func(agent *v1alpha1.Agent) map[string]metav1.ConditionStatus {
+		statuses := map[string]metav1.ConditionStatus{}
+		for _, condition := range agent.Status.Conditions {
+			statuses[condition.Type] = condition.Status
+		}
+		return statuses
+	}
+
+	t.Run("should report the missing references", func(t *testing.T) {
+		_, err := reconciler.ReconcileAutogenAgent(ctx, req)
+		require.Error(t, err)
+
+		agent := getAgent()
+		assert.Equal(t, map[string]metav1.ConditionStatus{
+			v1alpha1.AgentConditionTypeAccepted:     metav1.ConditionFalse,
+			v1alpha1.AgentConditionTypeResolvedRefs: metav1.ConditionFalse,
+			v1alpha1.AgentConditionTypeTranslated:   metav1.ConditionUnknown,
+			v1alpha1.AgentConditionTypeSynced:       metav1.ConditionUnknown,
+			v1alpha1.AgentConditionTypeReady:        metav1.ConditionFalse,
+		}, conditionStatuses(agent))
+		ready := meta.FindStatusCondition(agent.Status.Conditions, v1alpha1.AgentConditionTypeReady)
+		assert.Equal(t, "ReferenceNotFound", ready.Reason)
+		assert.Contains(t, ready.Message, `memories.kagent.dev "notes" not found`)
+		assert.Equal(t, "test-namespace/default-model", agent.Status.ModelConfig)
+		assert.Equal(t, []string{"test-namespace/notes"}, agent.Status.Memory)
+		assert.Zero(t, agent.Status.TeamID)
+		assert.Empty(t, agent.Status.References)
+	})
+
+	t.Run("should report the resolved agent once it is synced", func(t *testing.T) {
+		require.NoError(t, reconciler.kubeClient.Create(ctx, &v1alpha1.Memory{
+			ObjectMeta: metav1.ObjectMeta{Name: "notes", Namespace: namespace, Generation: 2},
+			Spec: v1alpha1.MemorySpec{
+				Provider:        v1alpha1.Pinecone,
+				APIKeySecretRef: "openai-secret",
+				APIKeySecretKey: "api-key",
+				Pinecone:        &v1alpha1.PineconeConfig{IndexHost: "https://notes.pinecone.io", ScoreThreshold: "0.5"},
+			},
+		}))
+		_, err := reconciler.ReconcileAutogenAgent(ctx, req)
+		require.NoError(t, err)
+
+		agent := getAgent()
+		assert.Equal(t, map[string]metav1.ConditionStatus{
+			v1alpha1.AgentConditionTypeAccepted:     metav1.ConditionTrue,
+			v1alpha1.AgentConditionTypeResolvedRefs: metav1.ConditionTrue,
+			v1alpha1.AgentConditionTypeTranslated:   metav1.ConditionTrue,
+			v1alpha1.AgentConditionTypeSynced:       metav1.ConditionTrue,
+			v1alpha1.AgentConditionTypeReady:        metav1.ConditionTrue,
+		}, conditionStatuses(agent))
+
+		team, err := reconciler.autogenClient.GetTeam("helper", common.GetGlobalUserID())
+		require.NoError(t, err)
+		require.NotNil(t, team)
+		assert.Equal(t, team.Id, agent.Status.TeamID)
+		assert.Equal(t, "http://localhost/api/a2a/test-namespace/helper", agent.Status.A2AURL)
+		assert.Equal(t, []v1alpha1.ObservedReference{
+			{Kind: "ModelConfig", Namespace: namespace, Name: "default-model", ObservedGeneration: 3},
+			{Kind: "Memory", Namespace: namespace, Name: "notes", ObservedGeneration: 2},
+		}, agent.Status.References)
+
+		// the team is not sent again, so its ID is kept
+		_, err = reconciler.ReconcileAutogenAgent(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, team.Id, getAgent().Status.TeamID)
+	})
+}
+
+// rejectingAutogenClient rejects the teams as invalid while reject is set
+type rejectingAutogenClient struct {
+	autogen_client.Client
+	reject bool
+}
+
+func (c *rejectingAutogenClient) Validate(req *autogen_client.ValidationRequest) (*autogen_client.ValidationResponse, error) {
+	if c.reject {
+		return &autogen_client.ValidationResponse{
+			Errors: []*autogen_client.ValidationError{{Field: "participants", Error: "no participants"}},
+		}, nil
+	}
+	return c.Client.Validate(req)
+}
+
+func TestReconcileAutogenAgentEvents(t *testing.T) {
+	ctx := context.Background()
+	namespace := testNamespace
+
+	agent := newHelperAgent()
+	agent.Spec.A2AConfig = &v1alpha1.A2AConfig{}
+	autogenClient := &rejectingAutogenClient{Client: fake.NewInMemoryAutogenClient(), reject: true}
+	reconciler := newTestReconcilerWithAutogen(t, autogenClient,
+		agent,
+		&v1alpha1.ModelConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "unauthenticated-model", Namespace: namespace},
+			Spec: v1alpha1.ModelConfigSpec{
+				Provider:        v1alpha1.OpenAI,
+				Model:           "gpt-4o",
+				APIKeySecretRef: "missing-secret",
+				APIKeySecretKey: "api-key",
+			},
+		},
+	)
+	updateAgent := func(update func(agent *v1alpha1.Agent)) {
+		agent := reconciler.getAgent(t, "helper")
+		update(agent)
+		require.NoError(t, reconciler.kubeClient.Update(ctx, agent))
+	}
+	reconcileEvents := func() []string {
+		_, _ = reconciler.ReconcileAutogenAgent(ctx, agentRequest("helper"))
+		var events []string
+		for len(reconciler.recorder.Events) > 0 {
+			events = append(events, <-reconciler.recorder.Events)
+		}
+		return events
+	}
+
+	t.Run("should warn when the A2A server cannot be registered", func(t *testing.T) {
+		events := reconcileEvents()
+		require.Len(t, events, 1)
+		assert.True(t, strings.HasPrefix(events[0], "Warning A2ARegistrationFailed "), events[0])
+	})
+
+	t.Run("should warn when autogen rejects the team", func(t *testing.T) {
+		updateAgent(func(agent *v1alpha1.Agent) {
+			agent.Spec.A2AConfig.Skills = []v1alpha1.AgentSkill{{ID: "help", Name: "Help"}}
+		})
+		events := reconcileEvents()
+		require.Len(t, events, 2)
+		assert.Equal(t, "Normal A2ARegistered Serving A2A at http://localhost/api/a2a/test-namespace/helper", events[0])
+		assert.True(t, strings.HasPrefix(events[1], "Warning ValidationFailed "), events[1])
+		assert.Contains(t, events[1], "no participants")
+	})
+
+	t.Run("should report the team sent", func(t *testing.T) {
+		autogenClient.reject = false
+		events := reconcileEvents()
+		require.Len(t, events, 1)
+		assert.True(t, strings.HasPrefix(events[0], "Normal Synced "), events[0])
+	})
+
+	t.Run("should not report anything while the agent is unchanged", func(t *testing.T) {
+		assert.Empty(t, reconcileEvents())
+	})
+
+	t.Run("should warn when the agent cannot be translated", func(t *testing.T) {
+		updateAgent(func(agent *v1alpha1.Agent) {
+			agent.Spec.ModelConfig = "unauthenticated-model"
+		})
+		events := reconcileEvents()
+		require.Len(t, events, 1)
+		assert.True(t, strings.HasPrefix(events[0], "Warning TranslationFailed "), events[0])
+		assert.Contains(t, events[0], "missing-secret")
+	})
+}
+
+func TestRepairDrift(t *testing.T) {
+	ctx := context.Background()
+
+	reconciler := newTestReconciler(t, newHelperAgent())
+	autogenClient := reconciler.autogenClient
+	_, err := reconciler.ReconcileAutogenAgent(ctx, agentRequest("helper"))
+	require.NoError(t, err)
+
+	team, err := autogenClient.GetTeam("helper", "")
+	require.NoError(t, err)
+	require.NotNil(t, team)
+	desiredComponent := *team.Component
+
+	repairDrift := func() *metav1.Condition {
+		require.NoError(t, reconciler.RepairDrift(ctx))
+		agent := reconciler.getAgent(t, "helper")
+		return meta.FindStatusCondition(agent.Status.Conditions, v1alpha1.AgentConditionTypeDrifted)
+	}
+
+	t.Run("should not repair the team while it is unchanged", func(t *testing.T) {
+		drifted := repairDrift()
+		require.NotNil(t, drifted)
+		assert.Equal(t, metav1.ConditionFalse, drifted.Status)
+	})
+
+	t.Run("should repair the team once it is edited in autogen", func(t *testing.T) {
+		editedComponent := desiredComponent
+		editedComponent.Description = "edited in autogen studio"
+		editedTeam := &autogen_client.Team{Component: &editedComponent}
+		editedTeam.Id = team.Id
+		require.NoError(t, autogenClient.CreateTeam(editedTeam))
+
+		drifted := repairDrift()
+		require.NotNil(t, drifted)
+		assert.Equal(t, metav1.ConditionTrue, drifted.Status)
+		assert.Equal(t, "DriftRepaired", drifted.Reason)
+
+		repairedTeam, err := autogenClient.GetTeam("helper", "")
+		require.NoError(t, err)
+		require.NotNil(t, repairedTeam)
+		assert.Equal(t, desiredComponent.Description, repairedTeam.Component.Description)
+	})
+
+	t.Run("should repair the team once it is deleted in autogen", func(t *testing.T) {
+		require.NoError(t, autogenClient.DeleteTeam(team.Id, ""))
+
+		drifted := repairDrift()
+		require.NotNil(t, drifted)
+		assert.Equal(t, metav1.ConditionTrue, drifted.Status)
+
+		repairedTeam, err := autogenClient.GetTeam("helper", "")
+		require.NoError(t, err)
+		assert.NotNil(t, repairedTeam)
+	})
+
+	t.Run("should report the team in sync once it was repaired", func(t *testing.T) {
+		drifted := repairDrift()
+		require.NotNil(t, drifted)
+		assert.Equal(t, metav1.ConditionFalse, drifted.Status)
+	})
+}
+
+func TestCollectOrphans(t *testing.T) {
+	ctx := context.Background()
+
+	reconciler := newTestReconciler(t, newHelperAgent())
+	autogenClient := reconciler.autogenClient
+	_, err := reconciler.ReconcileAutogenAgent(ctx, agentRequest("helper"))
+	require.NoError(t, err)
+
+	newTeam := func(label string, managed bool) *autogen_client.Team {
+		team := &autogen_client.Team{
+			Component: &api.Component{
+				Provider:      "autogen_agentchat.teams.RoundRobinGroupChat",
+				ComponentType: "team",
+				Label:         label,
+				Config:        map[string]interface{}{},
+			},
+		}
+		team.UserID = common.GetGlobalUserID()
+		if managed {
+			team.Component.Config[autogen.KagentManagedConfigKey] = true
+		}
+		return team
+	}
+	// the team of an agent deleted while the controller wasn't running, and a team created in autogen studio
+	require.NoError(t, autogenClient.CreateTeam(newTeam("deleted-agent", true)))
+	require.NoError(t, autogenClient.CreateTeam(newTeam("studio-team", false)))
+	_, err = autogenClient.CreateToolServer(&autogen_client.ToolServer{
+		UserID: common.GetGlobalUserID(),
+		Component: api.Component{
+			Provider:      "kagent.tool_servers.SseMcpToolServer",
+			ComponentType: "tool_server",
+			Label:         "deleted-tool-server",
+			Config:        map[string]interface{}{autogen.KagentManagedConfigKey: true},
+		},
+	}, common.GetGlobalUserID())
+	require.NoError(t, err)
+
+	teamExists := func(label string) bool {
+		team, err := autogenClient.GetTeam(label, common.GetGlobalUserID())
+		require.NoError(t, err)
+		return team != nil
+	}
+
+	t.Run("should only report the orphans in dry-run mode", func(t *testing.T) {
+		orphans, err := reconciler.CollectOrphans(ctx, true)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"team deleted-agent", "tool server deleted-tool-server"}, orphans)
+		assert.True(t, teamExists("deleted-agent"))
+	})
+
+	t.Run("should delete the orphans", func(t *testing.T) {
+		orphans, err := reconciler.CollectOrphans(ctx, false)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"team deleted-agent", "tool server deleted-tool-server"}, orphans)
+
+		assert.False(t, teamExists("deleted-agent"))
+		_, err = autogenClient.GetToolServerByLabel("deleted-tool-server", common.GetGlobalUserID())
+		assert.Error(t, err)
+
+		// the teams of existing agents, and the ones not created by kagent are kept
+		assert.True(t, teamExists("helper"))
+		assert.True(t, teamExists("studio-team"))
+	})
+}
+
+// unavailableAutogenClient fails like autogen does while it can't be reached
+type unavailableAutogenClient struct {
+	autogen_client.Client
+	unavailable bool
+}
+
+func (c *unavailableAutogenClient) GetVersion() (string, error) {
+	if c.unavailable {
+		return "", errors.New("connection refused")
+	}
+	return c.Client.GetVersion()
+}
+
+func (c *unavailableAutogenClient) Validate(req *autogen_client.ValidationRequest) (*autogen_client.ValidationResponse, error) {
+	if c.unavailable {
+		return nil, errors.New("connection refused")
+	}
+	return c.Client.Validate(req)
+}
+
+func TestReconcileAutogenUnavailable(t *testing.T) {
+	ctx := context.Background()
+
+	autogenClient := &unavailableAutogenClient{Client: fake.NewInMemoryAutogenClient(), unavailable: true}
+	reconciler := newTestReconcilerWithAutogen(t, autogenClient, newHelperAgent())
+	availability := reconciler.availability
+	req := agentRequest("helper")
+
+	var resynced []string
+	availability.OnRecovered(func(ctx context.Context) {
+		resynced = append(resynced, "agents")
+	})
+
+	t.Run("should requeue the reconcile while autogen is unavailable", func(t *testing.T) {
+		result, err := reconciler.ReconcileAutogenAgent(ctx, req)
+		require.NoError(t, err)
+		assert.Positive(t, result.RequeueAfter)
+		assert.ErrorIs(t, availability.Check(nil), autogen.ErrAutogenUnavailable)
+	})
+
+	t.Run("should hold back the reconciles while the circuit is open", func(t *testing.T) {
+		autogenClient.unavailable = false
+
+		result, err := reconciler.ReconcileAutogenAgent(ctx, req)
+		require.NoError(t, err)
+		assert.Positive(t, result.RequeueAfter)
+		team, err := autogenClient.GetTeam("helper", "")
+		require.NoError(t, err)
+		assert.Nil(t, team)
+	})
+
+	t.Run("should resync once autogen is available again", func(t *testing.T) {
+		require.NoError(t, availability.Probe(ctx))
+		assert.NoError(t, availability.Check(nil))
+		assert.Equal(t, []string{"agents"}, resynced)
+
+		_, err := reconciler.ReconcileAutogenAgent(ctx, req)
+		require.NoError(t, err)
+		team, err := autogenClient.GetTeam("helper", "")
+		require.NoError(t, err)
+		assert.NotNil(t, team)
+	})
+}
+
+func TestAutogenAvailabilityResyncWithoutConsumer(t *testing.T) {
+	ctx := context.Background()
+
+	autogenClient := &unavailableAutogenClient{Client: fake.NewInMemoryAutogenClient(), unavailable: true}
+	reconciler := newTestReconcilerWithAutogen(t, autogenClient, newHelperAgent())
+	availability := reconciler.availability
+	// the source is never started, like on the replicas which aren't the leader
+	availability.ResyncSource(&v1alpha1.AgentList{})
+
+	require.Error(t, availability.Probe(ctx))
+	autogenClient.unavailable = false
+
+	probed := make(chan error, 1)
+	go func() {
+		probed <- availability.Probe(ctx)
+	}()
+	select {
+	case err := <-probed:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the probe is blocked by the resync")
+	}
+}
+
+func TestReconcileAutogenRemoteAgent(t *testing.T) {
+	ctx := context.Background()
+	namespace := testNamespace
+
+	available := true
+	cardURL := "/a2a"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !available || r.URL.Path != "/.well-known/agent.json" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer remote-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"name": "billing", "description": "Answers billing questions", "url": "` + cardURL + `", "version": "1.0.0", "capabilities": {}, "skills": [{"id": "invoices", "name": "invoices", "description": "Looks up invoices"}]}`))
+	}))
+	defer server.Close()
+
+	reconciler := newTestReconciler(t,
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "billing-auth", Namespace: namespace},
+			Data:       map[string][]byte{"authorization": []byte("Bearer remote-token")},
+		},
+		&v1alpha1.RemoteAgent{
+			ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: namespace},
+			Spec: v1alpha1.RemoteAgentSpec{
+				URL: server.URL,
+				HeadersFrom: []v1alpha1.ValueRef{{
+					Name: "Authorization",
+					ValueFrom: &v1alpha1.ValueSource{
+						Type:     v1alpha1.SecretValueSource,
+						ValueRef: "billing-auth",
+						Key:      "authorization",
+					},
+				}},
+			},
+		},
+		&v1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "support-agent", Namespace: namespace},
+			Spec: v1alpha1.AgentSpec{
+				SystemMessage: "You are a support agent.",
+				Tools: []*v1alpha1.Tool{{
+					Type:        v1alpha1.ToolProviderType_RemoteAgent,
+					RemoteAgent: &v1alpha1.RemoteAgentTool{Ref: "billing"},
+				}},
+			},
+		},
+	)
+
+	// the agents using the remote agent are enqueued when its status changes
+	reconcileRemoteAgent := func() (ctrl.Result, *v1alpha1.RemoteAgent, *v1alpha1.Agent, error) {
+		result, err := reconciler.ReconcileAutogenRemoteAgent(ctx, agentRequest("billing"))
+		if err == nil {
+			_, err = reconciler.ReconcileAutogenAgent(ctx, agentRequest("support-agent"))
+		}
+
+		remoteAgent := &v1alpha1.RemoteAgent{}
+		require.NoError(t, reconciler.kubeClient.Get(ctx, types.NamespacedName{Name: "billing", Namespace: namespace}, remoteAgent))
+		return result, remoteAgent, reconciler.getAgent(t, "support-agent"), err
+	}
+
+	t.Run("should fetch the agent card and reconcile the agents using it", func(t *testing.T) {
+		result, remoteAgent, agent, err := reconcileRemoteAgent()
+		require.NoError(t, err)
+		assert.Equal(t, 5*time.Minute, result.RequeueAfter)
+
+		assert.True(t, meta.IsStatusConditionTrue(remoteAgent.Status.Conditions, v1alpha1.RemoteAgentConditionTypeReady))
+		require.NotNil(t, remoteAgent.Status.AgentCard)
+		assert.Equal(t, "billing", remoteAgent.Status.AgentCard.Name)
+		assert.Equal(t, server.URL+"/a2a", remoteAgent.Status.AgentCard.URL)
+		assert.Len(t, remoteAgent.Status.AgentCard.Skills, 1)
+		assert.NotNil(t, remoteAgent.Status.LastFetchTime)
+
+		assert.True(t, meta.IsStatusConditionTrue(agent.Status.Conditions, v1alpha1.AgentConditionTypeAccepted))
+		assert.Equal(t, []string{"billing"}, agent.Status.ResolvedTools)
+	})
+
+	t.Run("should keep the last agent card while the agent is unavailable", func(t *testing.T) {
+		available = false
+		defer func() { available = true }()
+
+		_, remoteAgent, agent, err := reconcileRemoteAgent()
+		require.NoError(t, err)
+
+		assert.True(t, meta.IsStatusConditionFalse(remoteAgent.Status.Conditions, v1alpha1.RemoteAgentConditionTypeReady))
+		assert.NotNil(t, remoteAgent.Status.AgentCard)
+		assert.True(t, meta.IsStatusConditionTrue(agent.Status.Conditions, v1alpha1.AgentConditionTypeAccepted))
+	})
+
+	t.Run("should reject agent cards sending the tasks to another server", func(t *testing.T) {
+		cardURL = "https://attacker.example/a2a"
+		defer func() { cardURL = "/a2a" }()
+
+		_, remoteAgent, _, err := reconcileRemoteAgent()
+		require.NoError(t, err)
+
+		ready := meta.FindStatusCondition(remoteAgent.Status.Conditions, v1alpha1.RemoteAgentConditionTypeReady)
+		require.NotNil(t, ready)
+		assert.Equal(t, metav1.ConditionFalse, ready.Status)
+		assert.Contains(t, ready.Message, "does not match the scheme and host")
+		assert.Equal(t, server.URL+"/a2a", remoteAgent.Status.AgentCard.URL)
+	})
+}
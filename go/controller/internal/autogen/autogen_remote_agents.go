@@ -0,0 +1,171 @@
+package autogen
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/autogen/api"
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"trpc.group/trpc-go/trpc-a2a-go/server"
+)
+
+const (
+	remoteAgentToolProvider = "kagent.tools.a2a.RemoteAgentTool"
+
+	// agentCardPath is where A2A servers publish their agent card, relative to their base URL
+	agentCardPath = "/.well-known/agent.json"
+	// maxAgentCardSize limits the size of the agent cards fetched from remote agents
+	maxAgentCardSize = 1 << 20
+)
+
+// remoteAgentHttpClient fetches the agent cards of RemoteAgents
+var remoteAgentHttpClient = &http.Client{Timeout: 30 * time.Second}
+
+func (a *apiTranslator) FetchRemoteAgentCard(ctx context.Context, remoteAgent *v1alpha1.RemoteAgent) (*v1alpha1.RemoteAgentCard, error) {
+	baseURL, err := url.Parse(remoteAgent.Spec.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url for RemoteAgent %s: %v", remoteAgent.Name, err)
+	}
+
+	headers, err := a.resolveHeaders(ctx, remoteAgent.Spec.HeadersFrom, remoteAgent.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve headers of RemoteAgent %s: %v", remoteAgent.Name, err)
+	}
+
+	cardURL := strings.TrimSuffix(remoteAgent.Spec.URL, "/") + agentCardPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cardURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := remoteAgentHttpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch agent card of RemoteAgent %s: %v", remoteAgent.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch agent card of RemoteAgent %s from %s: %s", remoteAgent.Name, cardURL, resp.Status)
+	}
+
+	var card server.AgentCard
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxAgentCardSize)).Decode(&card); err != nil {
+		return nil, fmt.Errorf("invalid agent card of RemoteAgent %s: %v", remoteAgent.Name, err)
+	}
+	if card.Name == "" {
+		return nil, fmt.Errorf("invalid agent card of RemoteAgent %s: missing name", remoteAgent.Name)
+	}
+
+	// tasks are sent to the url of the card, which may be relative to the base URL of the agent
+	taskURL := baseURL
+	if card.URL != "" {
+		cardTaskURL, err := url.Parse(card.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid url in agent card of RemoteAgent %s: %v", remoteAgent.Name, err)
+		}
+		taskURL = baseURL.ResolveReference(cardTaskURL)
+	}
+	// the headers, e.g. credentials, are sent with the tasks, so the card cannot redirect them to another server
+	if !sameOrigin(taskURL, baseURL) {
+		return nil, fmt.Errorf("the url %s in the agent card of RemoteAgent %s does not match the scheme and host of %s",
+			taskURL, remoteAgent.Name, remoteAgent.Spec.URL)
+	}
+
+	remoteAgentCard := &v1alpha1.RemoteAgentCard{
+		Name:    card.Name,
+		URL:     taskURL.String(),
+		Version: card.Version,
+	}
+	if card.Description != nil {
+		remoteAgentCard.Description = *card.Description
+	}
+	for _, skill := range card.Skills {
+		remoteAgentCard.Skills = append(remoteAgentCard.Skills, v1alpha1.AgentSkill(skill))
+	}
+
+	return remoteAgentCard, nil
+}
+
+// translateRemoteAgentTool returns a tool delegating tasks to the RemoteAgent referenced by a RemoteAgent tool of an agent
+func (a *apiTranslator) translateRemoteAgentTool(ctx context.Context, tool *v1alpha1.RemoteAgentTool, agentNamespace string) (*api.Component, error) {
+	remoteAgent := &v1alpha1.RemoteAgent{}
+	if err := fetchObjKube(ctx, a.kube, remoteAgent, tool.Ref, agentNamespace); err != nil {
+		return nil, err
+	}
+
+	// the last card fetched is used while the agent cannot be reached
+	card := remoteAgent.Status.AgentCard
+	if card == nil {
+		return nil, fmt.Errorf("the agent card of RemoteAgent %s has not been fetched yet", remoteAgent.Name)
+	}
+
+	// the url of the spec may have changed since the card was fetched
+	baseURL, err := url.Parse(remoteAgent.Spec.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url for RemoteAgent %s: %v", remoteAgent.Name, err)
+	}
+	taskURL, err := url.Parse(card.URL)
+	if err != nil || !sameOrigin(taskURL, baseURL) {
+		return nil, fmt.Errorf("the url %s in the agent card of RemoteAgent %s does not match the scheme and host of %s",
+			card.URL, remoteAgent.Name, remoteAgent.Spec.URL)
+	}
+
+	headers, err := a.resolveHeaders(ctx, remoteAgent.Spec.HeadersFrom, remoteAgent.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve headers of RemoteAgent %s: %v", remoteAgent.Name, err)
+	}
+
+	name := convertToPythonIdentifier(remoteAgent.Name)
+	description := remoteAgentDescription(remoteAgent)
+	return &api.Component{
+		Provider:      remoteAgentToolProvider,
+		ComponentType: "tool",
+		Version:       1,
+		Description:   description,
+		Label:         name,
+		Config: api.MustToConfig(&api.RemoteAgentToolConfig{
+			Name:        name,
+			Description: description,
+			URL:         card.URL,
+			Headers:     headers,
+		}),
+	}, nil
+}
+
+// sameOrigin returns whether both urls have the same scheme and host, including the port
+func sameOrigin(a, b *url.URL) bool {
+	return strings.EqualFold(a.Scheme, b.Scheme) && strings.EqualFold(a.Host, b.Host)
+}
+
+// remoteAgentDescription describes the remote agent to the model, including the skills advertised by its card
+func remoteAgentDescription(remoteAgent *v1alpha1.RemoteAgent) string {
+	card := remoteAgent.Status.AgentCard
+	description := remoteAgent.Spec.Description
+	if description == "" {
+		description = card.Description
+	}
+	if len(card.Skills) == 0 {
+		return description
+	}
+
+	var sb strings.Builder
+	sb.WriteString(description)
+	sb.WriteString("\nSkills:")
+	for _, skill := range card.Skills {
+		sb.WriteString("\n- ")
+		sb.WriteString(skill.Name)
+		if skill.Description != nil && *skill.Description != "" {
+			sb.WriteString(": ")
+			sb.WriteString(*skill.Description)
+		}
+	}
+	return sb.String()
+}
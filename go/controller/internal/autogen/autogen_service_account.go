@@ -0,0 +1,112 @@
+package autogen
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// ServiceAccountTokenExpiration is how long the tokens minted for the ServiceAccounts of agents are valid
+	ServiceAccountTokenExpiration = 24 * time.Hour
+	// ServiceAccountTokenRefreshInterval is how often agents with a ServiceAccount are reconciled, to renew their token before it expires.
+	// Tokens are reused for half of their lifetime, so they are renewed at the latest three quarters into it.
+	ServiceAccountTokenRefreshInterval = ServiceAccountTokenExpiration / 4
+)
+
+// serviceAccountTokenCache reuses the token minted for the ServiceAccount of each agent until half of its lifetime
+// has passed, so that translating the agent again yields the same team until the token must be renewed
+type serviceAccountTokenCache struct {
+	mu     sync.Mutex
+	tokens map[types.NamespacedName]*cachedServiceAccountToken
+}
+
+type cachedServiceAccountToken struct {
+	// uid of the ServiceAccount the token was minted for
+	serviceAccountUID types.UID
+	token             string
+	renewAt           time.Time
+}
+
+func newServiceAccountTokenCache() *serviceAccountTokenCache {
+	return &serviceAccountTokenCache{
+		tokens: make(map[types.NamespacedName]*cachedServiceAccountToken),
+	}
+}
+
+// token returns the cached token of the agent if it was minted for the ServiceAccount and is not due for renewal,
+// otherwise the one minted with mint
+func (c *serviceAccountTokenCache) token(
+	agent types.NamespacedName,
+	serviceAccount *corev1.ServiceAccount,
+	mint func() (*authenticationv1.TokenRequestStatus, error),
+) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	cached, ok := c.tokens[agent]
+	if ok && cached.serviceAccountUID == serviceAccount.UID && now.Before(cached.renewAt) {
+		return cached.token, nil
+	}
+
+	status, err := mint()
+	if err != nil {
+		return "", err
+	}
+	lifetime := ServiceAccountTokenExpiration
+	if !status.ExpirationTimestamp.IsZero() {
+		// the API server may issue tokens with a different lifetime than the one requested
+		lifetime = status.ExpirationTimestamp.Sub(now)
+	}
+	c.tokens[agent] = &cachedServiceAccountToken{
+		serviceAccountUID: serviceAccount.UID,
+		token:             status.Token,
+		renewAt:           now.Add(lifetime / 2),
+	}
+	return status.Token, nil
+}
+
+// forget drops the cached token of the agent
+func (c *serviceAccountTokenCache) forget(agent types.NamespacedName) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.tokens, agent)
+}
+
+// serviceAccountToken returns a token for the ServiceAccount of the agent, minted or reused from the cache,
+// or an empty token if the agent has none or if the translation makes no remote calls
+func (a *apiTranslator) serviceAccountToken(ctx context.Context, agent *v1alpha1.Agent) (string, error) {
+	agentRef := types.NamespacedName{Namespace: agent.Namespace, Name: agent.Name}
+	if agent.Spec.ServiceAccountName == "" {
+		a.serviceAccountTokens.forget(agentRef)
+		return "", nil
+	}
+
+	serviceAccount := &corev1.ServiceAccount{}
+	if err := a.kube.Get(ctx, types.NamespacedName{Namespace: agent.Namespace, Name: agent.Spec.ServiceAccountName}, serviceAccount); err != nil {
+		return "", fmt.Errorf("failed to get service account %s/%s: %w", agent.Namespace, agent.Spec.ServiceAccountName, err)
+	}
+	if a.withoutRemoteCalls {
+		return "", nil
+	}
+
+	return a.serviceAccountTokens.token(agentRef, serviceAccount, func() (*authenticationv1.TokenRequestStatus, error) {
+		expirationSeconds := int64(ServiceAccountTokenExpiration.Seconds())
+		tokenRequest := &authenticationv1.TokenRequest{
+			Spec: authenticationv1.TokenRequestSpec{
+				ExpirationSeconds: &expirationSeconds,
+			},
+		}
+		if err := a.kube.SubResource("token").Create(ctx, serviceAccount, tokenRequest); err != nil {
+			return nil, fmt.Errorf("failed to create token for service account %s/%s: %w", agent.Namespace, agent.Spec.ServiceAccountName, err)
+		}
+		return &tokenRequest.Status, nil
+	})
+}
@@ -0,0 +1,246 @@
+package autogen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+)
+
+// reconcileStage is a stage of the reconcile of an agent or a team, reported by a condition of its own
+type reconcileStage int
+
+const (
+	stageResolvedRefs reconcileStage = iota
+	stageTranslated
+	stageSynced
+)
+
+// the condition types are the same for agents and teams
+var stageConditionTypes = []string{
+	stageResolvedRefs: v1alpha1.AgentConditionTypeResolvedRefs,
+	stageTranslated:   v1alpha1.AgentConditionTypeTranslated,
+	stageSynced:       v1alpha1.AgentConditionTypeSynced,
+}
+
+// stageError is the error of the stage of a reconcile which failed
+type stageError struct {
+	stage  reconcileStage
+	reason string
+	err    error
+}
+
+func (e *stageError) Error() string {
+	return e.err.Error()
+}
+
+func (e *stageError) Unwrap() error {
+	return e.err
+}
+
+// teamReconcileResult is what the reconcile of an agent or a team resolved and sent to autogen
+type teamReconcileResult struct {
+	componentHash string
+	teamID        int
+	a2aURL        string
+	// references is nil unless all the references were resolved
+	references []v1alpha1.ObservedReference
+	// requeueAfter is set when the team embeds a token which must be renewed before it expires
+	requeueAfter time.Duration
+}
+
+// setStageConditions reports the stage which failed, if any, with the stages before it true and the ones after it
+// unknown, and sums them up in the Ready condition. It returns whether any of the conditions has changed.
+func setStageConditions(conditions *[]metav1.Condition, err error) bool {
+	failedStage := reconcileStage(len(stageConditionTypes))
+	var failure *stageError
+	if errors.As(err, &failure) {
+		failedStage = failure.stage
+	} else if err != nil {
+		failure = &stageError{stage: stageSynced, reason: "SyncFailed", err: err}
+		failedStage = stageSynced
+	}
+
+	changed := false
+	for stage, conditionType := range stageConditionTypes {
+		condition := metav1.Condition{
+			Type:   conditionType,
+			Status: metav1.ConditionTrue,
+			Reason: "Succeeded",
+		}
+		switch {
+		case reconcileStage(stage) == failedStage:
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = failure.reason
+			condition.Message = err.Error()
+		case reconcileStage(stage) > failedStage:
+			condition.Status = metav1.ConditionUnknown
+			condition.Reason = "Pending"
+			condition.Message = fmt.Sprintf("Waiting for %s", stageConditionTypes[failedStage])
+		}
+		changed = meta.SetStatusCondition(conditions, condition) || changed
+	}
+
+	ready := metav1.Condition{
+		Type:   v1alpha1.AgentConditionTypeReady,
+		Status: metav1.ConditionTrue,
+		Reason: "Ready",
+	}
+	if failure != nil {
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = failure.reason
+		ready.Message = err.Error()
+	}
+	return meta.SetStatusCondition(conditions, ready) || changed
+}
+
+// resolveError classifies the error of a reference, e.g. a referenced resource which doesn't exist
+func resolveError(err error) error {
+	reason := "ResolveFailed"
+	var chainErr *AgentToolChainError
+	switch {
+	case errors.As(err, &chainErr):
+		reason = "AgentToolDepthExceeded"
+		if chainErr.IsCycle() {
+			reason = "AgentToolCycleDetected"
+		}
+	case k8s_errors.IsNotFound(err):
+		reason = "ReferenceNotFound"
+	}
+	return &stageError{stage: stageResolvedRefs, reason: reason, err: err}
+}
+
+// translateError classifies the error of a translation. The agent tool chains are only
+// resolved by the translation, so their errors are errors of the references.
+func translateError(err error) error {
+	var chainErr *AgentToolChainError
+	if errors.As(err, &chainErr) {
+		return resolveError(err)
+	}
+	return &stageError{stage: stageTranslated, reason: "TranslationFailed", err: err}
+}
+
+// syncError classifies the error of sending a team to autogen
+func syncError(err error) error {
+	reason := "SyncFailed"
+	var invalidErr *invalidTeamError
+	if errors.As(err, &invalidErr) {
+		reason = "ValidationFailed"
+	}
+	return &stageError{stage: stageSynced, reason: reason, err: err}
+}
+
+// recordReconcileEvents emits a warning event for the stage of the reconcile of an agent or a team which failed,
+// or normal events for what changed in autogen and in the A2A server
+func (a *autogenReconciler) recordReconcileEvents(
+	obj client.Object,
+	lastHash string,
+	lastA2AURL string,
+	result *teamReconcileResult,
+	err error,
+) {
+	// the A2A server is registered before the team is sent, which may still fail
+	if result.a2aURL != lastA2AURL && result.a2aURL != "" {
+		a.recorder.Eventf(obj, corev1.EventTypeNormal, "A2ARegistered", "Serving A2A at %s", result.a2aURL)
+	}
+
+	var failure *stageError
+	switch {
+	case errors.As(err, &failure):
+		a.recorder.Event(obj, corev1.EventTypeWarning, failure.reason, err.Error())
+	case err != nil:
+		a.recorder.Event(obj, corev1.EventTypeWarning, "ReconcileFailed", err.Error())
+	case result.componentHash != lastHash:
+		a.recorder.Eventf(obj, corev1.EventTypeNormal, "Synced", "Sent the autogen team %d", result.teamID)
+	}
+}
+
+// modelConfigRef returns the ModelConfig used by an agent or a team, which is the default one unless it sets one
+func (a *autogenReconciler) modelConfigRef(modelConfig string, namespace string) types.NamespacedName {
+	if modelConfig == "" {
+		return a.defaultModelConfig
+	}
+	return getRefFromString(modelConfig, namespace)
+}
+
+// resolveAgentReferences gets the resources referenced by the agent, and returns them with their generation
+func (a *autogenReconciler) resolveAgentReferences(ctx context.Context, agent *v1alpha1.Agent) ([]v1alpha1.ObservedReference, error) {
+	r := &referenceResolver{kube: a.kube}
+	r.resolve(ctx, &v1alpha1.ModelConfig{}, "ModelConfig", a.modelConfigRef(agent.Spec.ModelConfig, agent.Namespace))
+	for _, memory := range agent.Spec.Memory {
+		r.resolve(ctx, &v1alpha1.Memory{}, "Memory", getRefFromString(memory, agent.Namespace))
+	}
+	for _, tool := range agent.Spec.Tools {
+		switch {
+		case tool.McpServer != nil:
+			r.resolve(ctx, &v1alpha1.ToolServer{}, "ToolServer", getRefFromString(tool.McpServer.ToolServer, agent.Namespace))
+		case tool.Http != nil:
+			r.resolve(ctx, &v1alpha1.HttpToolSet{}, "HttpToolSet", getRefFromString(tool.Http.ToolSet, agent.Namespace))
+		case tool.Agent != nil:
+			r.resolve(ctx, &v1alpha1.Agent{}, "Agent", getRefFromString(tool.Agent.Ref, agent.Namespace))
+		case tool.RemoteAgent != nil:
+			r.resolve(ctx, &v1alpha1.RemoteAgent{}, "RemoteAgent", getRefFromString(tool.RemoteAgent.Ref, agent.Namespace))
+		}
+	}
+	for _, mcpContext := range agent.Spec.McpContext {
+		r.resolve(ctx, &v1alpha1.ToolServer{}, "ToolServer", getRefFromString(mcpContext.ToolServer, agent.Namespace))
+	}
+	return r.references, errors.Join(r.errs...)
+}
+
+// resolveTeamReferences gets the resources referenced by the team, and returns them with their generation
+func (a *autogenReconciler) resolveTeamReferences(ctx context.Context, team *v1alpha1.Team) ([]v1alpha1.ObservedReference, error) {
+	r := &referenceResolver{kube: a.kube}
+	r.resolve(ctx, &v1alpha1.ModelConfig{}, "ModelConfig", a.modelConfigRef(team.Spec.ModelConfig, team.Namespace))
+	for _, participant := range team.Spec.Participants {
+		r.resolve(ctx, &v1alpha1.Agent{}, "Agent", getRefFromString(participant, team.Namespace))
+	}
+	return r.references, errors.Join(r.errs...)
+}
+
+type referenceResolver struct {
+	kube       client.Client
+	seen       map[string]bool
+	references []v1alpha1.ObservedReference
+	errs       []error
+}
+
+// resolve gets a referenced resource once, recording its generation or the error to get it
+func (r *referenceResolver) resolve(ctx context.Context, obj client.Object, kind string, ref types.NamespacedName) {
+	key := kind + "/" + ref.String()
+	if r.seen[key] {
+		return
+	}
+	if r.seen == nil {
+		r.seen = map[string]bool{}
+	}
+	r.seen[key] = true
+	if err := r.kube.Get(ctx, ref, obj); err != nil {
+		r.errs = append(r.errs, err)
+		return
+	}
+	r.references = append(r.references, v1alpha1.ObservedReference{
+		Kind:               kind,
+		Namespace:          ref.Namespace,
+		Name:               ref.Name,
+		ObservedGeneration: obj.GetGeneration(),
+	})
+}
+
+// memoryRefs returns the namespace/name of the Memory resources used by the agent
+func memoryRefs(agent *v1alpha1.Agent) []string {
+	var refs []string
+	for _, memory := range agent.Spec.Memory {
+		refs = append(refs, getRefFromString(memory, agent.Namespace).String())
+	}
+	return refs
+}
@@ -0,0 +1,296 @@
+package autogen
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/autogen/api"
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// tokens are refreshed when they would expire before the next refresh of the tool server plus this margin
+	oauth2TokenExpiryMargin   = 30 * time.Second
+	oauth2TokenRequestTimeout = 30 * time.Second
+	// key of the Authorization header in the secret holding the oauth2 token of a tool server
+	oauth2TokenSecretKey = "authorization"
+)
+
+// toolServerAuthError is returned when the credentials used to connect to a tool server could not be obtained
+type toolServerAuthError struct {
+	err error
+}
+
+func (e *toolServerAuthError) Error() string {
+	return e.err.Error()
+}
+
+func (e *toolServerAuthError) Unwrap() error {
+	return e.err
+}
+
+// oauth2TokenCache reuses the access token of each tool server until it is about to expire
+type oauth2TokenCache struct {
+	mu      sync.Mutex
+	sources map[types.NamespacedName]*cachedTokenSource
+}
+
+type cachedTokenSource struct {
+	// hash of the configuration the token source was created with
+	configHash  string
+	source      oauth2.TokenSource
+	minValidity time.Duration
+
+	mu      sync.Mutex
+	token   *oauth2.Token
+	renewAt time.Time
+}
+
+func newOAuth2TokenCache() *oauth2TokenCache {
+	return &oauth2TokenCache{
+		sources: make(map[types.NamespacedName]*cachedTokenSource),
+	}
+}
+
+// token returns an access token for the tool server which remains valid for at least minValidity,
+// or for at least half of its lifetime if the tokens issued by the server are shorter lived
+func (c *oauth2TokenCache) token(
+	toolServer types.NamespacedName,
+	config *clientcredentials.Config,
+	minValidity time.Duration,
+) (*oauth2.Token, error) {
+	configHash, err := hashOAuth2Config(config, minValidity)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	cached, ok := c.sources[toolServer]
+	if !ok || cached.configHash != configHash {
+		// the token source outlives the reconcile, so it must not use its context
+		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Timeout: oauth2TokenRequestTimeout})
+		cached = &cachedTokenSource{
+			configHash:  configHash,
+			source:      config.TokenSource(ctx),
+			minValidity: minValidity,
+		}
+		c.sources[toolServer] = cached
+	}
+	c.mu.Unlock()
+
+	return cached.Token()
+}
+
+// Token returns the cached token until it is due for renewal, then requests a new one
+func (s *cachedTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	// tokens without expiry are reused until the configuration changes
+	if s.token != nil && (s.token.Expiry.IsZero() || now.Before(s.renewAt)) {
+		return s.token, nil
+	}
+
+	token, err := s.source.Token()
+	if err != nil {
+		return nil, err
+	}
+	// the required validity is capped, as a new token wouldn't remain valid longer than this one
+	requiredValidity := min(s.minValidity, token.Expiry.Sub(now)/2)
+	s.token = token
+	s.renewAt = token.Expiry.Add(-requiredValidity)
+	return token, nil
+}
+
+func hashOAuth2Config(config *clientcredentials.Config, minValidity time.Duration) (string, error) {
+	raw, err := json.Marshal(struct {
+		Config      *clientcredentials.Config
+		MinValidity time.Duration
+	}{config, minValidity})
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(raw)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// translateToolServerAuth returns the headers read from Secrets and the certificates used to connect to the tool server.
+// The credentials are referenced rather than embedded, so that they don't end up in the configs of the tool server
+// and of the agents using its tools. The oauth2 token is only requested and stored if requestOAuth2Token is set,
+// i.e. when translating the tool server itself, otherwise the secret kept up to date by its reconciles is referenced.
+func (a *apiTranslator) translateToolServerAuth(
+	ctx context.Context,
+	toolServer *v1alpha1.ToolServer,
+	auth *v1alpha1.ToolServerAuth,
+	requestOAuth2Token bool,
+) (*api.McpTLSConfig, map[string]api.McpSecretKeyRef, error) {
+	var headersFrom map[string]api.McpSecretKeyRef
+	if auth.OAuth2 != nil {
+		tokenRef := api.McpSecretKeyRef{
+			Namespace: toolServer.Namespace,
+			Name:      OAuth2TokenSecretName(toolServer.Name),
+			Key:       oauth2TokenSecretKey,
+		}
+		if requestOAuth2Token {
+			token, err := a.getOAuth2Token(ctx, toolServer, auth.OAuth2)
+			if err != nil {
+				return nil, nil, &toolServerAuthError{err: fmt.Errorf("failed to obtain oauth2 token: %v", err)}
+			}
+			if err := a.storeOAuth2Token(ctx, toolServer, tokenRef, token); err != nil {
+				return nil, nil, &toolServerAuthError{err: fmt.Errorf("failed to store oauth2 token: %v", err)}
+			}
+		}
+		headersFrom = map[string]api.McpSecretKeyRef{"Authorization": tokenRef}
+	}
+
+	if auth.TLS == nil {
+		return nil, headersFrom, nil
+	}
+
+	tlsConfig, err := a.getToolServerTLSConfig(ctx, auth.TLS, toolServer.Namespace)
+	if err != nil {
+		return nil, nil, &toolServerAuthError{err: fmt.Errorf("failed to resolve client certificate: %v", err)}
+	}
+	return tlsConfig, headersFrom, nil
+}
+
+// OAuth2TokenSecretName returns the name of the Secret holding the Authorization header of the tool server
+func OAuth2TokenSecretName(toolServer string) string {
+	return toolServer + "-oauth2-token"
+}
+
+// storeOAuth2Token writes the Authorization header of the tool server to a Secret it owns,
+// from which the header is read when connecting to the server
+func (a *apiTranslator) storeOAuth2Token(
+	ctx context.Context,
+	toolServer *v1alpha1.ToolServer,
+	ref api.McpSecretKeyRef,
+	token *oauth2.Token,
+) error {
+	if a.withoutRemoteCalls {
+		return nil
+	}
+
+	header := []byte(token.Type() + " " + token.AccessToken)
+	secret := &corev1.Secret{}
+	err := a.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if apierrors.IsNotFound(err) {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ref.Namespace, Name: ref.Name},
+			Data:       map[string][]byte{ref.Key: header},
+		}
+		if err := controllerutil.SetControllerReference(toolServer, secret, a.kube.Scheme()); err != nil {
+			return err
+		}
+		return a.kube.Create(ctx, secret)
+	}
+
+	// never overwrite a secret created by someone else
+	if !metav1.IsControlledBy(secret, toolServer) {
+		return fmt.Errorf("secret %s already exists and is not owned by the tool server", ref.Name)
+	}
+	if bytes.Equal(secret.Data[ref.Key], header) {
+		return nil
+	}
+	secret.Data = map[string][]byte{ref.Key: header}
+	return a.kube.Update(ctx, secret)
+}
+
+func (a *apiTranslator) getOAuth2Token(
+	ctx context.Context,
+	toolServer *v1alpha1.ToolServer,
+	oauth2Config *v1alpha1.OAuth2ClientCredentials,
+) (*oauth2.Token, error) {
+	clientID, err := a.resolveValueSource(ctx, &oauth2Config.ClientIDFrom, toolServer.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve client id: %v", err)
+	}
+	clientSecret, err := a.resolveValueSource(ctx, &oauth2Config.ClientSecretFrom, toolServer.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve client secret: %v", err)
+	}
+
+	if a.withoutRemoteCalls {
+		return &oauth2.Token{AccessToken: "dry-run", TokenType: "Bearer"}, nil
+	}
+
+	config := &clientcredentials.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		TokenURL:     oauth2Config.TokenURL,
+		Scopes:       oauth2Config.Scopes,
+	}
+	if len(oauth2Config.EndpointParams) > 0 {
+		config.EndpointParams = make(map[string][]string, len(oauth2Config.EndpointParams))
+		for k, v := range oauth2Config.EndpointParams {
+			config.EndpointParams.Set(k, v)
+		}
+	}
+
+	// the token is read from its secret until the next refresh, so it must remain valid until then
+	minValidity := toolServerRefreshInterval(toolServer) + oauth2TokenExpiryMargin
+
+	return a.oauth2Tokens.token(
+		types.NamespacedName{Namespace: toolServer.Namespace, Name: toolServer.Name},
+		config,
+		minValidity,
+	)
+}
+
+func (a *apiTranslator) getToolServerTLSConfig(
+	ctx context.Context,
+	tlsConfig *v1alpha1.ToolServerTLS,
+	namespace string,
+) (*api.McpTLSConfig, error) {
+	secret := &corev1.Secret{}
+	if err := fetchObjKube(ctx, a.kube, secret, tlsConfig.SecretRef, namespace); err != nil {
+		return nil, fmt.Errorf("failed to find secret %s: %v", tlsConfig.SecretRef, err)
+	}
+
+	certKey := defaultString(tlsConfig.CertKey, corev1.TLSCertKey)
+	keyKey := defaultString(tlsConfig.KeyKey, corev1.TLSPrivateKeyKey)
+	caKey := defaultString(tlsConfig.CAKey, corev1.ServiceAccountRootCAKey)
+
+	for _, key := range []string{certKey, keyKey} {
+		if _, ok := secret.Data[key]; !ok {
+			return nil, fmt.Errorf("key %s not found in secret %s", key, tlsConfig.SecretRef)
+		}
+	}
+
+	mcpTLSConfig := &api.McpTLSConfig{
+		SecretNamespace: secret.Namespace,
+		SecretName:      secret.Name,
+		CertKey:         certKey,
+		KeyKey:          keyKey,
+	}
+	// the system CAs are used when the secret does not contain a CA certificate
+	if _, ok := secret.Data[caKey]; ok {
+		mcpTLSConfig.CAKey = caKey
+	}
+	return mcpTLSConfig, nil
+}
+
+func defaultString(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,47 @@
+package autogen
+
+import (
+	"context"
+	"fmt"
+
+	autogen_client "github.com/kagent-dev/kagent/go/autogen/client"
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	common "github.com/kagent-dev/kagent/go/controller/internal/utils"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetAutogenToolServer returns the autogen tool server of a ToolServer, looked up by the id recorded on its status.
+// Tool servers aren't looked up by label, as the ToolServers of other namespaces may have the same name, and a
+// tool server shared with a ToolServer of another namespace is refused, as it may be configured by the other one.
+func GetAutogenToolServer(
+	ctx context.Context,
+	kube client.Client,
+	autogenClient autogen_client.Client,
+	toolServer *v1alpha1.ToolServer,
+) (*autogen_client.ToolServer, error) {
+	serverID := toolServer.Status.ServerID
+	if serverID == 0 {
+		return nil, fmt.Errorf("tool server %s/%s was not created in autogen yet", toolServer.Namespace, toolServer.Name)
+	}
+
+	toolServers := &v1alpha1.ToolServerList{}
+	if err := kube.List(ctx, toolServers); err != nil {
+		return nil, fmt.Errorf("failed to list tool servers: %v", err)
+	}
+	for _, other := range toolServers.Items {
+		if sharesLabel(&other, toolServer) && other.Status.ServerID == serverID {
+			return nil, fmt.Errorf("tool server %s/%s has the same name as a tool server of namespace %s",
+				toolServer.Namespace, toolServer.Name, other.Namespace)
+		}
+	}
+
+	autogenToolServer, err := autogenClient.GetToolServer(serverID, common.GetGlobalUserID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tool server %s/%s: %v", toolServer.Namespace, toolServer.Name, err)
+	}
+	// the label is checked too, in case autogen reused the id
+	if autogenToolServer == nil || autogenToolServer.Component.Label != toolServer.Name {
+		return nil, fmt.Errorf("tool server %s/%s not found in autogen", toolServer.Namespace, toolServer.Name)
+	}
+	return autogenToolServer, nil
+}
@@ -2,7 +2,10 @@ package autogen_test
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"testing"
@@ -11,14 +14,19 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/kagent-dev/kagent/go/autogen/api"
 	autogen_client "github.com/kagent-dev/kagent/go/autogen/client"
 	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
 	"github.com/kagent-dev/kagent/go/controller/internal/autogen"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	v1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 )
 
 var (
@@ -181,6 +189,904 @@ func TestConfigMapAndSecretValueResolution(t *testing.T) {
 	})
 }
 
+func TestMcpServerToolResolution(t *testing.T) {
+	ctx := context.Background()
+	scheme := scheme.Scheme
+	err := v1alpha1.AddToScheme(scheme)
+	require.NoError(t, err)
+
+	namespace := "test-namespace"
+	newToolServer := func(name string, toolNames ...string) *v1alpha1.ToolServer {
+		url := "http://" + name + ":8080/sse"
+		toolServer := &v1alpha1.ToolServer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: v1alpha1.ToolServerSpec{
+				Config: v1alpha1.ToolServerConfig{
+					Sse: &v1alpha1.SseMcpServerConfig{
+						URL:         url,
+						HeadersFrom: []v1alpha1.ValueRef{{Name: "X-Api-Key", Value: "current-key"}},
+					},
+				},
+			},
+		}
+		for _, toolName := range toolNames {
+			toolServer.Status.DiscoveredTools = append(toolServer.Status.DiscoveredTools, &v1alpha1.MCPTool{
+				Name: toolName,
+				Component: v1alpha1.Component{
+					Provider:      "autogen_ext.tools.mcp.SseMcpToolAdapter",
+					ComponentType: "tool",
+					Version:       1,
+					Label:         toolName,
+					Config: map[string]v1alpha1.AnyType{
+						"server_params": {RawMessage: json.RawMessage(`{"url": "` + url + `"}`)},
+						"tool":          {RawMessage: json.RawMessage(`{"name": "` + toolName + `"}`)},
+					},
+				},
+			})
+		}
+		return toolServer
+	}
+	modelConfig := &v1alpha1.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-model",
+			Namespace: namespace,
+		},
+		Spec: v1alpha1.ModelConfigSpec{
+			Provider: v1alpha1.OpenAI,
+			Model:    "gpt-4o",
+		},
+	}
+
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		modelConfig,
+		newToolServer("github", "search", "get_issue", "create_issue"),
+		newToolServer("docs", "search", "fetch_page"),
+	).Build()
+	translator := autogen.NewAutogenApiTranslator(kubeClient, types.NamespacedName{
+		Namespace: namespace,
+		Name:      "test-model",
+	})
+
+	newAgent := func(name string, tools ...*v1alpha1.Tool) *v1alpha1.Agent {
+		return &v1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: v1alpha1.AgentSpec{
+				Description:   "Test agent",
+				SystemMessage: "You are a helpful assistant.",
+				ModelConfig:   "test-model",
+				Tools:         tools,
+			},
+		}
+	}
+
+	t.Run("should resolve tools matching patterns with a prefix", func(t *testing.T) {
+		agent := newAgent("pattern-agent", &v1alpha1.Tool{
+			Type: v1alpha1.ToolProviderType_McpServer,
+			McpServer: &v1alpha1.McpServerTool{
+				ToolServer:      "github",
+				IncludePatterns: []string{"*"},
+				ExcludePatterns: []string{"create_*"},
+				NamePrefix:      "github_",
+			},
+		})
+
+		toolNames, err := translator.ResolveAgentTools(ctx, agent)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"github_search", "github_get_issue"}, toolNames)
+	})
+
+	t.Run("should resolve tools matching regex patterns", func(t *testing.T) {
+		agent := newAgent("pattern-agent", &v1alpha1.Tool{
+			Type: v1alpha1.ToolProviderType_McpServer,
+			McpServer: &v1alpha1.McpServerTool{
+				ToolServer:      "github",
+				PatternType:     v1alpha1.ToolPatternType_Regex,
+				IncludePatterns: []string{".*_issue"},
+			},
+		})
+
+		toolNames, err := translator.ResolveAgentTools(ctx, agent)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"get_issue", "create_issue"}, toolNames)
+	})
+
+	t.Run("should resolve builtin tools by the name the model calls them with", func(t *testing.T) {
+		agent := newAgent("builtin-agent",
+			&v1alpha1.Tool{
+				Type:    v1alpha1.ToolProviderType_Builtin,
+				Builtin: &v1alpha1.BuiltinTool{Name: "kagent.tools.k8s.GetResources"},
+			},
+			&v1alpha1.Tool{
+				Type:    v1alpha1.ToolProviderType_Builtin,
+				Builtin: &v1alpha1.BuiltinTool{Name: "kagent.tools.prometheus.QueryTool"},
+			},
+		)
+
+		toolNames, err := translator.ResolveAgentTools(ctx, agent)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"get_resources", "QueryTool"}, toolNames)
+	})
+
+	t.Run("should add the credentials of the tool server to its tools", func(t *testing.T) {
+		agent := newAgent("credentials-agent", &v1alpha1.Tool{
+			Type: v1alpha1.ToolProviderType_McpServer,
+			McpServer: &v1alpha1.McpServerTool{
+				ToolServer: "github",
+				ToolNames:  []string{"search"},
+			},
+		})
+		err := kubeClient.Create(ctx, agent)
+		require.NoError(t, err)
+
+		team, err := translator.TranslateGroupChatForAgent(ctx, agent)
+		require.NoError(t, err)
+
+		participant := team.Component.Config["participants"].([]interface{})[0].(map[string]interface{})
+		tool := participant["config"].(map[string]interface{})["tools"].([]interface{})[0].(map[string]interface{})
+		serverParams := tool["config"].(map[string]interface{})["server_params"].(map[string]interface{})
+		assert.Equal(t, "http://github:8080/sse", serverParams["url"])
+		assert.Equal(t, map[string]interface{}{"X-Api-Key": "current-key"}, serverParams["headers"])
+	})
+
+	t.Run("should fail on an invalid pattern", func(t *testing.T) {
+		agent := newAgent("pattern-agent", &v1alpha1.Tool{
+			Type: v1alpha1.ToolProviderType_McpServer,
+			McpServer: &v1alpha1.McpServerTool{
+				ToolServer:      "github",
+				PatternType:     v1alpha1.ToolPatternType_Regex,
+				IncludePatterns: []string{"("},
+			},
+		})
+
+		_, err := translator.ResolveAgentTools(ctx, agent)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid include pattern")
+	})
+
+	t.Run("should fail when tools from different servers collide", func(t *testing.T) {
+		agent := newAgent("colliding-agent",
+			&v1alpha1.Tool{
+				Type: v1alpha1.ToolProviderType_McpServer,
+				McpServer: &v1alpha1.McpServerTool{
+					ToolServer: "github",
+					ToolNames:  []string{"search"},
+				},
+			},
+			&v1alpha1.Tool{
+				Type: v1alpha1.ToolProviderType_McpServer,
+				McpServer: &v1alpha1.McpServerTool{
+					ToolServer: "docs",
+					ToolNames:  []string{"search"},
+				},
+			},
+		)
+		err := kubeClient.Create(ctx, agent)
+		require.NoError(t, err)
+
+		_, err = translator.TranslateGroupChatForAgent(ctx, agent)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "duplicate tool name search")
+	})
+
+	t.Run("should not collide when a prefix is set", func(t *testing.T) {
+		agent := newAgent("prefixed-agent",
+			&v1alpha1.Tool{
+				Type: v1alpha1.ToolProviderType_McpServer,
+				McpServer: &v1alpha1.McpServerTool{
+					ToolServer: "github",
+					ToolNames:  []string{"search"},
+					NamePrefix: "github_",
+				},
+			},
+			&v1alpha1.Tool{
+				Type: v1alpha1.ToolProviderType_McpServer,
+				McpServer: &v1alpha1.McpServerTool{
+					ToolServer: "docs",
+					ToolNames:  []string{"search"},
+				},
+			},
+		)
+		err := kubeClient.Create(ctx, agent)
+		require.NoError(t, err)
+
+		_, err = translator.TranslateGroupChatForAgent(ctx, agent)
+		require.NoError(t, err)
+	})
+}
+
+func TestMcpContextTranslation(t *testing.T) {
+	ctx := context.Background()
+	scheme := scheme.Scheme
+	err := v1alpha1.AddToScheme(scheme)
+	require.NoError(t, err)
+
+	namespace := "test-namespace"
+	runbooks := &v1alpha1.ToolServer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "runbooks",
+			Namespace: namespace,
+		},
+		Spec: v1alpha1.ToolServerSpec{
+			Config: v1alpha1.ToolServerConfig{
+				Sse: &v1alpha1.SseMcpServerConfig{URL: "http://runbooks.test:8080/sse"},
+			},
+		},
+		Status: v1alpha1.ToolServerStatus{
+			DiscoveredResources: []*v1alpha1.MCPResource{{
+				URI:  "runbook://incidents/oncall",
+				Name: "oncall",
+			}},
+			DiscoveredPrompts: []*v1alpha1.MCPPrompt{{
+				Name:      "sre-persona",
+				Arguments: []v1alpha1.MCPPromptArgument{{Name: "team", Required: true}},
+			}},
+		},
+	}
+	// a server which supports neither resources nor prompts discovers none of them
+	toolsOnly := &v1alpha1.ToolServer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tools-only",
+			Namespace: namespace,
+		},
+		Spec: v1alpha1.ToolServerSpec{
+			Config: v1alpha1.ToolServerConfig{
+				Sse: &v1alpha1.SseMcpServerConfig{URL: "http://tools-only.test:8080/sse"},
+			},
+		},
+	}
+	modelConfig := &v1alpha1.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-model",
+			Namespace: namespace,
+		},
+		Spec: v1alpha1.ModelConfigSpec{
+			Provider: v1alpha1.OpenAI,
+			Model:    "gpt-4o",
+		},
+	}
+
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(modelConfig, runbooks, toolsOnly).Build()
+	translator := autogen.NewAutogenApiTranslator(kubeClient, types.NamespacedName{
+		Namespace: namespace,
+		Name:      "test-model",
+	})
+
+	newAgent := func(t *testing.T, name string, mcpContext *v1alpha1.McpServerContext) *v1alpha1.Agent {
+		agent := &v1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: v1alpha1.AgentSpec{
+				Description:   "Test agent",
+				SystemMessage: "You are a helpful assistant.",
+				ModelConfig:   "test-model",
+				McpContext:    []*v1alpha1.McpServerContext{mcpContext},
+			},
+		}
+		err := kubeClient.Create(ctx, agent)
+		require.NoError(t, err)
+		return agent
+	}
+
+	// memoryConfigs returns the configs of the memories of the agent of a translated team
+	memoryConfigs := func(t *testing.T, team *autogen_client.Team) []api.McpContextMemoryConfig {
+		var component struct {
+			Config struct {
+				Participants []struct {
+					Config struct {
+						Memory []struct {
+							Provider string                     `json:"provider"`
+							Config   api.McpContextMemoryConfig `json:"config"`
+						} `json:"memory"`
+					} `json:"config"`
+				} `json:"participants"`
+			} `json:"config"`
+		}
+		data, err := json.Marshal(team.Component)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(data, &component))
+		require.Len(t, component.Config.Participants, 1)
+
+		var configs []api.McpContextMemoryConfig
+		for _, memory := range component.Config.Participants[0].Config.Memory {
+			assert.Equal(t, "kagent.memory.McpContextMemory", memory.Provider)
+			configs = append(configs, memory.Config)
+		}
+		return configs
+	}
+
+	t.Run("should translate discovered resources and prompts", func(t *testing.T) {
+		agent := newAgent(t, "context-agent", &v1alpha1.McpServerContext{
+			ToolServer: "runbooks",
+			Resources:  []string{"runbook://incidents/oncall"},
+			Prompts: []v1alpha1.McpPromptRef{{
+				Name:      "sre-persona",
+				Arguments: map[string]string{"team": "platform"},
+			}},
+		})
+
+		team, err := translator.TranslateGroupChatForAgent(ctx, agent)
+		require.NoError(t, err)
+		configs := memoryConfigs(t, team.Team)
+		require.Len(t, configs, 1)
+		assert.Equal(t, "SseServerParams", configs[0].ServerParams["type"])
+		assert.Equal(t, "http://runbooks.test:8080/sse", configs[0].ServerParams["url"])
+		assert.Equal(t, []string{"runbook://incidents/oncall"}, configs[0].Resources)
+		assert.Equal(t, []api.McpPromptConfig{{
+			Name:      "sre-persona",
+			Arguments: map[string]string{"team": "platform"},
+		}}, configs[0].Prompts)
+	})
+
+	t.Run("should fail when the resource was not discovered", func(t *testing.T) {
+		agent := newAgent(t, "missing-resource-agent", &v1alpha1.McpServerContext{
+			ToolServer: "runbooks",
+			Resources:  []string{"runbook://incidents/postmortem"},
+		})
+
+		_, err := translator.TranslateGroupChatForAgent(ctx, agent)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "resource runbook://incidents/postmortem not found")
+	})
+
+	t.Run("should fail when the prompt was not discovered", func(t *testing.T) {
+		agent := newAgent(t, "missing-prompt-agent", &v1alpha1.McpServerContext{
+			ToolServer: "runbooks",
+			Prompts:    []v1alpha1.McpPromptRef{{Name: "dba-persona"}},
+		})
+
+		_, err := translator.TranslateGroupChatForAgent(ctx, agent)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "prompt dba-persona not found")
+	})
+
+	t.Run("should fail when a required prompt argument is missing", func(t *testing.T) {
+		agent := newAgent(t, "missing-argument-agent", &v1alpha1.McpServerContext{
+			ToolServer: "runbooks",
+			Prompts:    []v1alpha1.McpPromptRef{{Name: "sre-persona"}},
+		})
+
+		_, err := translator.TranslateGroupChatForAgent(ctx, agent)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "missing required argument team")
+	})
+
+	t.Run("should fail on resources and prompts of a server without them", func(t *testing.T) {
+		agent := newAgent(t, "tools-only-resource-agent", &v1alpha1.McpServerContext{
+			ToolServer: "tools-only",
+			Resources:  []string{"runbook://incidents/oncall"},
+		})
+		_, err := translator.TranslateGroupChatForAgent(ctx, agent)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found in discovered resources in ToolServer tools-only")
+
+		agent = newAgent(t, "tools-only-prompt-agent", &v1alpha1.McpServerContext{
+			ToolServer: "tools-only",
+			Prompts:    []v1alpha1.McpPromptRef{{Name: "sre-persona", Arguments: map[string]string{"team": "platform"}}},
+		})
+		_, err = translator.TranslateGroupChatForAgent(ctx, agent)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found in discovered prompts in ToolServer tools-only")
+	})
+
+	t.Run("should fail when the ToolServer does not exist", func(t *testing.T) {
+		agent := newAgent(t, "missing-server-agent", &v1alpha1.McpServerContext{
+			ToolServer: "missing",
+			Resources:  []string{"runbook://incidents/oncall"},
+		})
+
+		_, err := translator.TranslateGroupChatForAgent(ctx, agent)
+		require.Error(t, err)
+		assert.True(t, k8s_errors.IsNotFound(err), "expected a NotFound error, got %v", err)
+	})
+}
+
+func TestToolServerAuthTranslation(t *testing.T) {
+	ctx := context.Background()
+	scheme := scheme.Scheme
+	err := v1alpha1.AddToScheme(scheme)
+	require.NoError(t, err)
+
+	namespace := "test-namespace"
+	credentials := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "oauth2-credentials",
+			Namespace: namespace,
+		},
+		Data: map[string][]byte{
+			"client-id":     []byte("test-client"),
+			"client-secret": []byte("test-secret"),
+		},
+	}
+	clientCert := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "client-cert",
+			Namespace: namespace,
+		},
+		Data: map[string][]byte{
+			"tls.crt": []byte("test-cert"),
+			"tls.key": []byte("test-key"),
+		},
+	}
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(credentials, clientCert).Build()
+	translator := autogen.NewAutogenApiTranslator(kubeClient, types.NamespacedName{
+		Namespace: "default",
+		Name:      "default-model",
+	})
+
+	var tokenRequests int
+	tokenLifetime := 3600
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		clientID, clientSecret, ok := r.BasicAuth()
+		if !ok || clientID != "test-client" || clientSecret != "test-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"test-token","token_type":"Bearer","expires_in":%d}`, tokenLifetime)
+	}))
+	defer tokenServer.Close()
+
+	oauth2Auth := func(clientSecretKey string) *v1alpha1.ToolServerAuth {
+		return &v1alpha1.ToolServerAuth{
+			OAuth2: &v1alpha1.OAuth2ClientCredentials{
+				TokenURL: tokenServer.URL,
+				ClientIDFrom: v1alpha1.ValueSource{
+					Type:     v1alpha1.SecretValueSource,
+					ValueRef: "oauth2-credentials",
+					Key:      "client-id",
+				},
+				ClientSecretFrom: v1alpha1.ValueSource{
+					Type:     v1alpha1.SecretValueSource,
+					ValueRef: "oauth2-credentials",
+					Key:      clientSecretKey,
+				},
+			},
+		}
+	}
+	newToolServer := func(name string, auth *v1alpha1.ToolServerAuth) *v1alpha1.ToolServer {
+		return &v1alpha1.ToolServer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: v1alpha1.ToolServerSpec{
+				Config: v1alpha1.ToolServerConfig{
+					Sse: &v1alpha1.SseMcpServerConfig{
+						URL:  "https://test-server:8443/sse",
+						Auth: auth,
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("should store and reuse the oauth2 token", func(t *testing.T) {
+		tokenRequests = 0
+		toolServer := newToolServer("oauth2-server", oauth2Auth("client-secret"))
+
+		for range 2 {
+			result, err := translator.TranslateToolServer(ctx, toolServer)
+			require.NoError(t, err)
+
+			assert.Nil(t, result.Component.Config["headers"])
+			headersFrom := result.Component.Config["headers_from"].(map[string]interface{})
+			assert.Equal(t, map[string]interface{}{
+				"namespace": namespace,
+				"name":      "oauth2-server-oauth2-token",
+				"key":       "authorization",
+			}, headersFrom["Authorization"])
+		}
+		assert.Equal(t, 1, tokenRequests)
+
+		tokenSecret := &v1.Secret{}
+		err := kubeClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: "oauth2-server-oauth2-token"}, tokenSecret)
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer test-token", string(tokenSecret.Data["authorization"]))
+		assert.True(t, metav1.IsControlledBy(tokenSecret, toolServer))
+	})
+
+	t.Run("should reuse tokens living shorter than the refresh interval", func(t *testing.T) {
+		tokenRequests = 0
+		tokenLifetime = 30
+		defer func() { tokenLifetime = 3600 }()
+		toolServer := newToolServer("short-lived-oauth2-server", oauth2Auth("client-secret"))
+
+		for range 2 {
+			_, err := translator.TranslateToolServer(ctx, toolServer)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 1, tokenRequests)
+	})
+
+	t.Run("should not overwrite a token secret it does not own", func(t *testing.T) {
+		err := kubeClient.Create(ctx, &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "taken-server-oauth2-token", Namespace: namespace},
+			Data:       map[string][]byte{"authorization": []byte("Bearer other-token")},
+		})
+		require.NoError(t, err)
+		toolServer := newToolServer("taken-server", oauth2Auth("client-secret"))
+
+		_, err = translator.TranslateToolServer(ctx, toolServer)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not owned by the tool server")
+	})
+
+	t.Run("should fail when the token cannot be obtained", func(t *testing.T) {
+		toolServer := newToolServer("unauthorized-server", oauth2Auth("client-id"))
+
+		_, err := translator.TranslateToolServer(ctx, toolServer)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to obtain oauth2 token")
+	})
+
+	t.Run("should translate the client certificate", func(t *testing.T) {
+		toolServer := newToolServer("mtls-server", &v1alpha1.ToolServerAuth{
+			TLS: &v1alpha1.ToolServerTLS{
+				SecretRef: "client-cert",
+			},
+		})
+
+		result, err := translator.TranslateToolServer(ctx, toolServer)
+		require.NoError(t, err)
+
+		// the certificates are read from the secret when connecting
+		assert.Equal(t, map[string]interface{}{
+			"secret_namespace": namespace,
+			"secret_name":      "client-cert",
+			"cert_key":         "tls.crt",
+			"key_key":          "tls.key",
+		}, result.Component.Config["tls"])
+	})
+
+	t.Run("should fail when the client certificate secret is missing", func(t *testing.T) {
+		toolServer := newToolServer("missing-cert-server", &v1alpha1.ToolServerAuth{
+			TLS: &v1alpha1.ToolServerTLS{
+				SecretRef: "missing-cert",
+			},
+		})
+
+		_, err := translator.TranslateToolServer(ctx, toolServer)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to resolve client certificate")
+	})
+}
+
+func TestApplyAgentMode(t *testing.T) {
+	tool := func(provider string, config map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"provider":       provider,
+			"component_type": "tool",
+			"label":          provider,
+			"config":         config,
+		}
+	}
+	assistantAgent := func(tools ...interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"provider":       "autogen_agentchat.agents.AssistantAgent",
+			"component_type": "agent",
+			"config": map[string]interface{}{
+				"tools": tools,
+			},
+		}
+	}
+	newTeam := func() *api.Component {
+		nestedTeam := map[string]interface{}{
+			"provider":       "autogen_agentchat.teams.RoundRobinGroupChat",
+			"component_type": "team",
+			"config": map[string]interface{}{
+				"participants": []interface{}{
+					assistantAgent(tool("kagent.tools.k8s.DeleteResource", map[string]interface{}{})),
+				},
+			},
+		}
+		return &api.Component{
+			Provider:      "autogen_agentchat.teams.RoundRobinGroupChat",
+			ComponentType: "team",
+			Config: map[string]interface{}{
+				"participants": []interface{}{
+					assistantAgent(
+						tool("kagent.tools.k8s.GetResources", map[string]interface{}{}),
+						tool("kagent.tools.k8s.DeleteResource", map[string]interface{}{}),
+						tool("autogen_ext.tools.http.HttpTool", map[string]interface{}{"method": "GET"}),
+						tool("autogen_ext.tools.http.HttpTool", map[string]interface{}{"method": "POST"}),
+						tool("autogen_ext.tools.mcp.SseMcpToolAdapter", map[string]interface{}{
+							"tool": map[string]interface{}{
+								"name":        "get_pods",
+								"annotations": map[string]interface{}{"readOnlyHint": true},
+							},
+						}),
+						tool("autogen_ext.tools.mcp.SseMcpToolAdapter", map[string]interface{}{
+							"tool": map[string]interface{}{"name": "delete_pod"},
+						}),
+						tool("autogen_agentchat.tools.TeamTool", map[string]interface{}{"team": nestedTeam}),
+					),
+				},
+			},
+		}
+	}
+	agentTools := func(agent interface{}) []interface{} {
+		return agent.(map[string]interface{})["config"].(map[string]interface{})["tools"].([]interface{})
+	}
+	providers := func(tools []interface{}) []string {
+		var result []string
+		for _, tool := range tools {
+			result = append(result, tool.(map[string]interface{})["provider"].(string))
+		}
+		return result
+	}
+	nestedAgent := func(teamTool interface{}) interface{} {
+		nestedTeam := teamTool.(map[string]interface{})["config"].(map[string]interface{})["team"]
+		return nestedTeam.(map[string]interface{})["config"].(map[string]interface{})["participants"].([]interface{})[0]
+	}
+
+	t.Run("should remove the mutating tools in readOnly mode", func(t *testing.T) {
+		team := newTeam()
+		require.NoError(t, autogen.ApplyAgentMode(team, v1alpha1.AgentMode_ReadOnly))
+
+		tools := agentTools(team.Config["participants"].([]interface{})[0])
+		assert.Equal(t, []string{
+			"kagent.tools.k8s.GetResources",
+			"autogen_ext.tools.http.HttpTool",
+			"autogen_ext.tools.mcp.SseMcpToolAdapter",
+			"autogen_agentchat.tools.TeamTool",
+		}, providers(tools))
+		assert.Empty(t, agentTools(nestedAgent(tools[3])))
+	})
+
+	t.Run("should wrap the mutating tools in dryRun mode", func(t *testing.T) {
+		team := newTeam()
+		require.NoError(t, autogen.ApplyAgentMode(team, v1alpha1.AgentMode_DryRun))
+
+		tools := agentTools(team.Config["participants"].([]interface{})[0])
+		assert.Equal(t, []string{
+			"kagent.tools.k8s.GetResources",
+			"kagent.tools.common.DryRunTool",
+			"autogen_ext.tools.http.HttpTool",
+			"kagent.tools.common.DryRunTool",
+			"autogen_ext.tools.mcp.SseMcpToolAdapter",
+			"kagent.tools.common.DryRunTool",
+			"autogen_agentchat.tools.TeamTool",
+		}, providers(tools))
+		assert.Equal(t, []string{"kagent.tools.common.DryRunTool"}, providers(agentTools(nestedAgent(tools[6]))))
+
+		// dry-run tools are not mutating, so a read-only override keeps them
+		require.NoError(t, autogen.ApplyAgentMode(team, v1alpha1.AgentMode_ReadOnly))
+		assert.Len(t, agentTools(team.Config["participants"].([]interface{})[0]), 7)
+	})
+
+	t.Run("should not change the tools in readWrite mode", func(t *testing.T) {
+		team := newTeam()
+		require.NoError(t, autogen.ApplyAgentMode(team, ""))
+		assert.Equal(t, newTeam(), team)
+	})
+
+	t.Run("should fail on an unknown mode", func(t *testing.T) {
+		err := autogen.ApplyAgentMode(newTeam(), "writeOnly")
+		assert.Error(t, err)
+	})
+}
+
+func TestAgentToolChain(t *testing.T) {
+	ctx := context.Background()
+	scheme := scheme.Scheme
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	namespace := "test"
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "openai-secret",
+			Namespace: namespace,
+		},
+		Data: map[string][]byte{
+			apikeySecretKey: []byte("sk-test-api-key"),
+		},
+	}
+	modelConfig := &v1alpha1.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "default-model",
+			Namespace: namespace,
+		},
+		Spec: v1alpha1.ModelConfigSpec{
+			Provider:        v1alpha1.OpenAI,
+			Model:           "gpt-4o",
+			APIKeySecretRef: "openai-secret",
+			APIKeySecretKey: apikeySecretKey,
+		},
+	}
+	newAgent := func(name string, toolRefs ...string) *v1alpha1.Agent {
+		agent := &v1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: v1alpha1.AgentSpec{
+				Description:   name,
+				SystemMessage: "You are " + name,
+			},
+		}
+		for _, ref := range toolRefs {
+			agent.Spec.Tools = append(agent.Spec.Tools, &v1alpha1.Tool{
+				Type:  v1alpha1.ToolProviderType_Agent,
+				Agent: &v1alpha1.AgentTool{Ref: ref},
+			})
+		}
+		return agent
+	}
+	newTranslator := func(objects ...client.Object) autogen.ApiTranslator {
+		kubeClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(append(objects, secret, modelConfig)...).
+			Build()
+		return autogen.NewAutogenApiTranslator(kubeClient, types.NamespacedName{
+			Namespace: namespace,
+			Name:      "default-model",
+		})
+	}
+
+	t.Run("should allow agents shared by several tools", func(t *testing.T) {
+		root := newAgent("root", "left", "right")
+		translator := newTranslator(root, newAgent("left", "shared"), newAgent("right", "shared"), newAgent("shared"))
+
+		_, err := translator.TranslateGroupChatForAgent(ctx, root)
+		require.NoError(t, err)
+	})
+
+	t.Run("should report the full chain of a cycle", func(t *testing.T) {
+		root := newAgent("root", "first")
+		translator := newTranslator(root, newAgent("first", "second"), newAgent("second", "root"))
+
+		_, err := translator.TranslateGroupChatForAgent(ctx, root)
+		var chainErr *autogen.AgentToolChainError
+		require.ErrorAs(t, err, &chainErr)
+		assert.True(t, chainErr.IsCycle())
+		assert.Equal(t, []string{"Agent test/root", "Agent test/first", "Agent test/second", "Agent test/root"}, chainErr.Chain)
+		assert.EqualError(t, err, "cycle detected in agent tool chain: "+
+			"Agent test/root -> Agent test/first -> Agent test/second -> Agent test/root")
+	})
+
+	t.Run("should report cycles through the participants of a team", func(t *testing.T) {
+		team := &v1alpha1.Team{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "team",
+				Namespace: namespace,
+			},
+			Spec: v1alpha1.TeamSpec{
+				Participants:         []string{"root"},
+				RoundRobinTeamConfig: &v1alpha1.RoundRobinTeamConfig{},
+				TerminationCondition: v1alpha1.TerminationCondition{
+					MaxMessageTermination: &v1alpha1.MaxMessageTermination{MaxMessages: 10},
+				},
+			},
+		}
+		translator := newTranslator(newAgent("root", "first"), newAgent("first", "root"))
+
+		_, err := translator.TranslateGroupChatForTeam(ctx, team)
+		var chainErr *autogen.AgentToolChainError
+		require.ErrorAs(t, err, &chainErr)
+		assert.Equal(t, []string{"Team test/team", "Agent test/root", "Agent test/first", "Agent test/root"}, chainErr.Chain)
+	})
+
+	t.Run("should limit the depth to the maximum of the agent", func(t *testing.T) {
+		root := newAgent("root", "first")
+		translator := newTranslator(root, newAgent("first", "second"), newAgent("second"))
+
+		_, err := translator.TranslateGroupChatForAgent(ctx, root)
+		require.NoError(t, err)
+
+		maxToolDepth := int32(1)
+		root.Spec.MaxToolDepth = &maxToolDepth
+		_, err = translator.TranslateGroupChatForAgent(ctx, root)
+		var chainErr *autogen.AgentToolChainError
+		require.ErrorAs(t, err, &chainErr)
+		assert.False(t, chainErr.IsCycle())
+		assert.Equal(t, 1, chainErr.MaxDepth)
+		assert.EqualError(t, err, "agent tool chain exceeds the maximum depth of 1: "+
+			"Agent test/root -> Agent test/first -> Agent test/second")
+	})
+
+	t.Run("should limit the depth to the default maximum", func(t *testing.T) {
+		defer func(maxDepth int) { autogen.DefaultMaxAgentToolDepth = maxDepth }(autogen.DefaultMaxAgentToolDepth)
+		autogen.DefaultMaxAgentToolDepth = 1
+
+		root := newAgent("root", "first")
+		translator := newTranslator(root, newAgent("first", "second"), newAgent("second"))
+
+		_, err := translator.TranslateGroupChatForAgent(ctx, root)
+		var chainErr *autogen.AgentToolChainError
+		require.ErrorAs(t, err, &chainErr)
+		assert.Equal(t, 1, chainErr.MaxDepth)
+	})
+}
+
+func TestServiceAccountTokenReuse(t *testing.T) {
+	ctx := context.Background()
+	scheme := scheme.Scheme
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	namespace := "test"
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "openai-secret", Namespace: namespace},
+		Data:       map[string][]byte{apikeySecretKey: []byte("sk-test-api-key")},
+	}
+	modelConfig := &v1alpha1.ModelConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "default-model", Namespace: namespace},
+		Spec: v1alpha1.ModelConfigSpec{
+			Provider:        v1alpha1.OpenAI,
+			Model:           "gpt-4o",
+			APIKeySecretRef: "openai-secret",
+			APIKeySecretKey: apikeySecretKey,
+		},
+	}
+	agent := &v1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "k8s-agent", Namespace: namespace},
+		Spec: v1alpha1.AgentSpec{
+			SystemMessage:      "You are a Kubernetes agent.",
+			ServiceAccountName: "k8s-agent",
+			Tools: []*v1alpha1.Tool{{
+				Type:    v1alpha1.ToolProviderType_Builtin,
+				Builtin: &v1alpha1.BuiltinTool{Name: "kagent.tools.k8s.GetResources"},
+			}},
+		},
+	}
+	serviceAccount := &v1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "k8s-agent", Namespace: namespace, UID: "first"},
+	}
+
+	// newTranslator returns a translator whose tokens are valid for the lifetime, and the number of tokens minted
+	newTranslator := func(lifetime time.Duration) (autogen.ApiTranslator, *int) {
+		tokenRequests := 0
+		kubeClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(secret, modelConfig, serviceAccount, agent).
+			WithInterceptorFuncs(interceptor.Funcs{
+				SubResourceCreate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+					tokenRequests++
+					tokenRequest := subResource.(*authenticationv1.TokenRequest)
+					tokenRequest.Status.Token = fmt.Sprintf("token-%d", tokenRequests)
+					tokenRequest.Status.ExpirationTimestamp = metav1.NewTime(time.Now().Add(lifetime))
+					return nil
+				},
+			}).
+			Build()
+		return autogen.NewAutogenApiTranslator(kubeClient, types.NamespacedName{
+			Namespace: namespace,
+			Name:      "default-model",
+		}), &tokenRequests
+	}
+
+	t.Run("should reuse the token until half its lifetime has passed", func(t *testing.T) {
+		translator, tokenRequests := newTranslator(24 * time.Hour)
+
+		first, err := translator.TranslateGroupChatForAgent(ctx, agent)
+		require.NoError(t, err)
+		second, err := translator.TranslateGroupChatForAgent(ctx, agent)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, *tokenRequests)
+		assert.Equal(t, first.Component, second.Component)
+	})
+
+	t.Run("should mint a new token once half its lifetime has passed", func(t *testing.T) {
+		translator, tokenRequests := newTranslator(0)
+
+		_, err := translator.TranslateGroupChatForAgent(ctx, agent)
+		require.NoError(t, err)
+		_, err = translator.TranslateGroupChatForAgent(ctx, agent)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, *tokenRequests)
+	})
+}
+
 func TestAutogenClient(t *testing.T) {
 	t.Run("should interact with autogen server", func(t *testing.T) {
 		ctx := context.Background()
@@ -317,7 +1223,7 @@ func TestAutogenClient(t *testing.T) {
 		listBefore, err := client.ListTeams(autogenTeam.UserID)
 		require.NoError(t, err)
 
-		err = client.CreateTeam(autogenTeam)
+		err = client.CreateTeam(autogenTeam.Team)
 		require.NoError(t, err)
 
 		list, err := client.ListTeams(autogenTeam.UserID)
@@ -0,0 +1,101 @@
+package autogen
+
+import (
+	"context"
+	"slices"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// RequestsForIndex returns a function mapping a changed object to the objects of the type of the list
+// which reference it through the index, so that only the objects depending on a change are reconciled.
+func RequestsForIndex(kube client.Client, list client.ObjectList, index string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		return requestsForIndex(ctx, kube, list, index, client.ObjectKeyFromObject(obj))
+	}
+}
+
+// RequestsForIndexThrough is like RequestsForIndex for the objects referencing the changed object indirectly,
+// e.g. the agents using a ModelConfig which uses a Secret. The intermediate objects are found through the
+// first index, and the objects referencing them through the second one.
+func RequestsForIndexThrough(
+	kube client.Client,
+	through client.ObjectList,
+	throughIndex string,
+	list client.ObjectList,
+	index string,
+) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var requests []reconcile.Request
+		for _, ref := range requestsForIndex(ctx, kube, through, throughIndex, client.ObjectKeyFromObject(obj)) {
+			for _, req := range requestsForIndex(ctx, kube, list, index, ref.NamespacedName) {
+				if !slices.Contains(requests, req) {
+					requests = append(requests, req)
+				}
+			}
+		}
+		return requests
+	}
+}
+
+func requestsForIndex(
+	ctx context.Context,
+	kube client.Client,
+	list client.ObjectList,
+	index string,
+	ref types.NamespacedName,
+) []reconcile.Request {
+	// the map functions may be called concurrently
+	list = list.DeepCopyObject().(client.ObjectList)
+	if err := kube.List(ctx, list, client.MatchingFields{index: ref.String()}); err != nil {
+		reconcileLog.Error(err, "failed to list objects referencing object", "index", index, "object", ref)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	if err := meta.EachListItem(list, func(item runtime.Object) error {
+		if obj, ok := item.(client.Object); ok {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(obj)})
+		}
+		return nil
+	}); err != nil {
+		reconcileLog.Error(err, "failed to list objects referencing object", "index", index, "object", ref)
+		return nil
+	}
+
+	return requests
+}
+
+// AgentTranslationChanged matches the changes of an agent which change the translation of the teams and agents
+// using it. Besides changes of its spec, these are changes of the resources it references, e.g. the tools
+// discovered on its tool servers, which are reported in its status once the agent has been translated again.
+func AgentTranslationChanged() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldAgent, ok := e.ObjectOld.(*v1alpha1.Agent)
+			if !ok {
+				return true
+			}
+			newAgent, ok := e.ObjectNew.(*v1alpha1.Agent)
+			if !ok {
+				return true
+			}
+			return oldAgent.Generation != newAgent.Generation ||
+				oldAgent.Status.ComponentHash != newAgent.Status.ComponentHash ||
+				!slices.Equal(oldAgent.Status.ResolvedTools, newAgent.Status.ResolvedTools) ||
+				agentAccepted(oldAgent) != agentAccepted(newAgent)
+		},
+	}
+}
+
+func agentAccepted(agent *v1alpha1.Agent) bool {
+	return meta.IsStatusConditionTrue(agent.Status.Conditions, v1alpha1.AgentConditionTypeAccepted)
+}
@@ -0,0 +1,171 @@
+// Package builtintools is the catalog of the builtin tools implemented by the kagent Python package,
+// which agents reference by name in their Builtin tools.
+package builtintools
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	common "github.com/kagent-dev/kagent/go/controller/internal/utils"
+)
+
+// catalog.json is generated from the sources of the tools with `make -C python catalog`
+//
+//go:embed catalog.json
+var catalogJSON []byte
+
+// Tool describes a builtin tool
+type Tool struct {
+	// Name is the Python provider of the tool, e.g. kagent.tools.k8s.GetResources
+	Name string `json:"name"`
+	// Aliases are other names the tool can be referenced with, e.g. k8s.get_resources
+	Aliases []string `json:"aliases,omitempty"`
+	// ToolName is the name the model calls the tool with, e.g. get_resources
+	ToolName    string `json:"toolName,omitempty"`
+	Description string `json:"description"`
+	// ConfigSchema is the JSON schema of the config of the tool
+	ConfigSchema json.RawMessage `json:"configSchema"`
+	// NeedsModelClient is set for tools which get the model client of the agent added to their config
+	NeedsModelClient bool `json:"needsModelClient,omitempty"`
+	// NeedsOpenAIKey is set for tools which get the OpenAI API key of the agent added to their config
+	NeedsOpenAIKey bool `json:"needsOpenAIKey,omitempty"`
+	// NeedsKubeToken is set for tools which access the cluster, which get the service account token of the agent added to their config
+	NeedsKubeToken bool `json:"needsKubeToken,omitempty"`
+	// Mutating is set for tools which change the state of the systems they access,
+	// which are removed from read-only agents and not run by dry-run agents
+	Mutating bool `json:"mutating,omitempty"`
+}
+
+// Catalog looks up builtin tools by name or alias
+type Catalog struct {
+	tools  []*Tool
+	byName map[string]*Tool
+}
+
+// NewCatalog creates a catalog of the given tools. Names and aliases must be unique.
+func NewCatalog(tools []*Tool) (*Catalog, error) {
+	c := &Catalog{
+		tools:  tools,
+		byName: make(map[string]*Tool, len(tools)),
+	}
+	for _, tool := range tools {
+		for _, name := range append([]string{tool.Name}, tool.Aliases...) {
+			if _, exists := c.byName[name]; exists {
+				return nil, fmt.Errorf("duplicate builtin tool name %s", name)
+			}
+			c.byName[name] = tool
+		}
+	}
+	sort.Slice(c.tools, func(i, j int) bool {
+		return c.tools[i].Name < c.tools[j].Name
+	})
+	return c, nil
+}
+
+var (
+	defaultCatalog     *Catalog
+	defaultCatalogOnce sync.Once
+)
+
+// Default returns the catalog of the tools of the kagent Python package
+func Default() *Catalog {
+	defaultCatalogOnce.Do(func() {
+		var tools []*Tool
+		if err := json.Unmarshal(catalogJSON, &tools); err != nil {
+			panic(fmt.Sprintf("invalid builtin tool catalog: %v", err))
+		}
+		catalog, err := NewCatalog(tools)
+		if err != nil {
+			panic(fmt.Sprintf("invalid builtin tool catalog: %v", err))
+		}
+		defaultCatalog = catalog
+	})
+	return defaultCatalog
+}
+
+// List returns all the tools, sorted by name
+func (c *Catalog) List() []*Tool {
+	return c.tools
+}
+
+// Get returns the tool with the given name or alias
+func (c *Catalog) Get(name string) (*Tool, bool) {
+	tool, ok := c.byName[name]
+	return tool, ok
+}
+
+// Validate returns the tool referenced by a Builtin tool of an agent,
+// or an error if there is no such tool or if its config does not match the schema of the tool.
+// Config keys the schema doesn't declare are ignored rather than refused, as agents written for older
+// versions of the tools may still set them, and a warning is returned for each of them.
+func (c *Catalog) Validate(builtin *v1alpha1.BuiltinTool) (*Tool, []string, error) {
+	tool, ok := c.Get(builtin.Name)
+	if !ok {
+		if suggestion := c.suggest(builtin.Name); suggestion != "" {
+			return nil, nil, fmt.Errorf("unknown builtin tool %s, did you mean %s?", builtin.Name, suggestion)
+		}
+		return nil, nil, fmt.Errorf("unknown builtin tool %s", builtin.Name)
+	}
+
+	var schema struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(tool.ConfigSchema, &schema); err != nil {
+		return nil, nil, fmt.Errorf("invalid config schema of builtin tool %s: %v", builtin.Name, err)
+	}
+
+	var warnings []string
+	config := map[string]interface{}{}
+	for key, value := range builtin.Config {
+		var decoded interface{}
+		if err := json.Unmarshal(value.RawMessage, &decoded); err != nil {
+			return nil, nil, fmt.Errorf("invalid config.%s of builtin tool %s: %v", key, builtin.Name, err)
+		}
+		if _, known := schema.Properties[key]; !known {
+			warnings = append(warnings, fmt.Sprintf("unknown config.%s of builtin tool %s is ignored", key, builtin.Name))
+			continue
+		}
+		config[key] = decoded
+	}
+	sort.Strings(warnings)
+	// the controller adds these to the config, so they never come from the agent
+	if tool.NeedsModelClient {
+		delete(config, "model_client")
+	}
+	if tool.NeedsOpenAIKey {
+		delete(config, "openai_api_key")
+	}
+	if tool.NeedsKubeToken {
+		delete(config, "kube_token")
+	}
+	if err := common.ValidateJSONSchema(tool.ConfigSchema, "config", config); err != nil {
+		return nil, nil, fmt.Errorf("invalid config of builtin tool %s: %v", builtin.Name, err)
+	}
+
+	return tool, warnings, nil
+}
+
+// suggest returns the name of a tool which only differs from the given name by its case or its package
+func (c *Catalog) suggest(name string) string {
+	short := name[strings.LastIndex(name, ".")+1:]
+	for _, tool := range c.tools {
+		if strings.EqualFold(tool.Name, name) {
+			return tool.Name
+		}
+	}
+	var candidates []string
+	for _, tool := range c.tools {
+		if strings.EqualFold(tool.Name[strings.LastIndex(tool.Name, ".")+1:], short) {
+			candidates = append(candidates, tool.Name)
+		}
+	}
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+	return ""
+}
@@ -0,0 +1,107 @@
+package builtintools_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"github.com/kagent-dev/kagent/go/controller/internal/builtintools"
+)
+
+func builtinTool(name string, config map[string]string) *v1alpha1.BuiltinTool {
+	tool := &v1alpha1.BuiltinTool{Name: name}
+	if config != nil {
+		tool.Config = map[string]v1alpha1.AnyType{}
+		for key, value := range config {
+			tool.Config[key] = v1alpha1.AnyType{RawMessage: json.RawMessage(value)}
+		}
+	}
+	return tool
+}
+
+func TestValidate(t *testing.T) {
+	catalog := builtintools.Default()
+
+	t.Run("Name", func(t *testing.T) {
+		tool, _, err := catalog.Validate(builtinTool("kagent.tools.k8s.GetResources", nil))
+		require.NoError(t, err)
+		assert.Equal(t, "kagent.tools.k8s.GetResources", tool.Name)
+		assert.NotEmpty(t, tool.Description)
+	})
+
+	t.Run("Alias", func(t *testing.T) {
+		tool, _, err := catalog.Validate(builtinTool("k8s.get_resources", nil))
+		require.NoError(t, err)
+		assert.Equal(t, "kagent.tools.k8s.GetResources", tool.Name)
+	})
+
+	t.Run("LegacyAlias", func(t *testing.T) {
+		for _, name := range []string{"k8s-get-pod", "k8s-get-resources"} {
+			tool, _, err := catalog.Validate(builtinTool(name, nil))
+			require.NoError(t, err)
+			assert.Equal(t, "kagent.tools.k8s.GetResources", tool.Name)
+		}
+	})
+
+	t.Run("UnknownName", func(t *testing.T) {
+		_, _, err := catalog.Validate(builtinTool("kagent.tools.k8s.getresources", nil))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "did you mean kagent.tools.k8s.GetResources?")
+	})
+
+	t.Run("Config", func(t *testing.T) {
+		_, warnings, err := catalog.Validate(builtinTool("kagent.tools.docs.QueryTool", map[string]string{"min_similarity": "0.5"}))
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+	})
+
+	t.Run("UnknownConfigField", func(t *testing.T) {
+		_, warnings, err := catalog.Validate(builtinTool("kagent.tools.docs.QueryTool", map[string]string{"max_results": "5"}))
+		require.NoError(t, err)
+		require.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], "max_results")
+	})
+
+	t.Run("InvalidConfigType", func(t *testing.T) {
+		_, _, err := catalog.Validate(builtinTool("kagent.tools.docs.QueryTool", map[string]string{"min_similarity": `"high"`}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "min_similarity")
+	})
+
+	t.Run("InjectedConfig", func(t *testing.T) {
+		tool, _, err := catalog.Validate(builtinTool("kagent.tools.prometheus.GeneratePromQLTool", map[string]string{"model_client": `"ignored"`}))
+		require.NoError(t, err)
+		assert.True(t, tool.NeedsModelClient)
+	})
+}
+
+func TestNewCatalogDuplicateName(t *testing.T) {
+	_, err := builtintools.NewCatalog([]*builtintools.Tool{
+		{Name: "kagent.tools.k8s.GetPods"},
+		{Name: "kagent.tools.k8s.ListPods", Aliases: []string{"kagent.tools.k8s.GetPods"}},
+	})
+	assert.Error(t, err)
+}
+
+// the agents shipped with the helm chart must only reference tools of the catalog
+func TestHelmAgentTools(t *testing.T) {
+	builtinName := regexp.MustCompile(`builtin:\s*\n\s*name:\s*(\S+)`)
+	files, err := filepath.Glob("../../../../helm/agents/*/templates/*.yaml")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		require.NoError(t, err)
+		for _, match := range builtinName.FindAllStringSubmatch(string(content), -1) {
+			_, ok := builtintools.Default().Get(match[1])
+			assert.True(t, ok, "unknown builtin tool %s in %s", match[1], file)
+		}
+	}
+}
@@ -21,10 +21,14 @@ import (
 
 	"github.com/kagent-dev/kagent/go/controller/internal/autogen"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	agentv1alpha1 "github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
 )
@@ -32,23 +36,57 @@ import (
 // AutogenAgentReconciler reconciles a AutogenAgent object
 type AutogenAgentReconciler struct {
 	client.Client
-	Scheme     *runtime.Scheme
-	Reconciler autogen.AutogenReconciler
+	Scheme       *runtime.Scheme
+	Reconciler   autogen.AutogenReconciler
+	Availability *autogen.AutogenAvailability
 }
 
 // +kubebuilder:rbac:groups=kagent.dev,resources=agents,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=kagent.dev,resources=agents/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=kagent.dev,resources=agents/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=serviceaccounts/token,verbs=create
 
 func (r *AutogenAgentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	_ = log.FromContext(ctx)
-	return ctrl.Result{}, r.Reconciler.ReconcileAutogenAgent(ctx, req)
+	return r.Reconciler.ReconcileAutogenAgent(ctx, req)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *AutogenAgentReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	agentsFor := func(index string) handler.EventHandler {
+		return handler.EnqueueRequestsFromMapFunc(autogen.RequestsForIndex(r.Client, &agentv1alpha1.AgentList{}, index))
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&agentv1alpha1.Agent{}).
+		// the agents are translated again when a resource they reference changes
+		Watches(&agentv1alpha1.ModelConfig{}, agentsFor(autogen.AgentModelConfigIndex),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&agentv1alpha1.Memory{}, agentsFor(autogen.AgentMemoryIndex),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// the agents used as tools are translated with the agents using them
+		Watches(&agentv1alpha1.Agent{}, agentsFor(autogen.AgentAgentToolIndex),
+			builder.WithPredicates(autogen.AgentTranslationChanged())).
+		// the tools discovered on tool servers, the tools generated for http tool sets and
+		// the cards of remote agents are in their status
+		Watches(&agentv1alpha1.ToolServer{}, agentsFor(autogen.AgentToolServerIndex)).
+		Watches(&agentv1alpha1.HttpToolSet{}, agentsFor(autogen.AgentHttpToolSetIndex)).
+		Watches(&agentv1alpha1.RemoteAgent{}, agentsFor(autogen.AgentRemoteAgentIndex)).
+		Watches(&corev1.ServiceAccount{}, agentsFor(autogen.AgentServiceAccountIndex)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(autogen.RequestsForIndexThrough(
+			r.Client,
+			&agentv1alpha1.ModelConfigList{}, autogen.ModelConfigSecretIndex,
+			&agentv1alpha1.AgentList{}, autogen.AgentModelConfigIndex,
+		))).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(autogen.RequestsForIndexThrough(
+			r.Client,
+			&agentv1alpha1.MemoryList{}, autogen.MemorySecretIndex,
+			&agentv1alpha1.AgentList{}, autogen.AgentMemoryIndex,
+		))).
+		// all agents are reconciled again once autogen is available again
+		WatchesRawSource(r.Availability.ResyncSource(&agentv1alpha1.AgentList{})).
 		Named("autogenagent").
 		Complete(r)
 }
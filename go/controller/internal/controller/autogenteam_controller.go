@@ -21,10 +21,14 @@ import (
 
 	"github.com/kagent-dev/kagent/go/controller/internal/autogen"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	agentv1alpha1 "github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
 )
@@ -32,23 +36,42 @@ import (
 // AutogenTeamReconciler reconciles a AutogenTeam object
 type AutogenTeamReconciler struct {
 	client.Client
-	Scheme     *runtime.Scheme
-	Reconciler autogen.AutogenReconciler
+	Scheme       *runtime.Scheme
+	Reconciler   autogen.AutogenReconciler
+	Availability *autogen.AutogenAvailability
 }
 
 // +kubebuilder:rbac:groups=kagent.dev,resources=teams,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=kagent.dev,resources=teams/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=kagent.dev,resources=teams/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 
 func (r *AutogenTeamReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	_ = log.FromContext(ctx)
-	return ctrl.Result{}, r.Reconciler.ReconcileAutogenTeam(ctx, req)
+	return r.Reconciler.ReconcileAutogenTeam(ctx, req)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *AutogenTeamReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	teamsFor := func(index string) handler.EventHandler {
+		return handler.EnqueueRequestsFromMapFunc(autogen.RequestsForIndex(r.Client, &agentv1alpha1.TeamList{}, index))
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&agentv1alpha1.Team{}).
+		// the teams are translated again when their participants or model config change,
+		// including when the resources referenced by a participant change its translation
+		Watches(&agentv1alpha1.Agent{}, teamsFor(autogen.TeamParticipantIndex),
+			builder.WithPredicates(autogen.AgentTranslationChanged())).
+		Watches(&agentv1alpha1.ModelConfig{}, teamsFor(autogen.TeamModelConfigIndex),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(autogen.RequestsForIndexThrough(
+			r.Client,
+			&agentv1alpha1.ModelConfigList{}, autogen.ModelConfigSecretIndex,
+			&agentv1alpha1.TeamList{}, autogen.TeamModelConfigIndex,
+		))).
+		// all teams are reconciled again once autogen is available again
+		WatchesRawSource(r.Availability.ResyncSource(&agentv1alpha1.TeamList{})).
 		Named("autogenteam").
 		Complete(r)
 }
@@ -0,0 +1,67 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"github.com/kagent-dev/kagent/go/controller/internal/autogen"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	agentv1alpha1 "github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+)
+
+// RemoteAgentReconciler reconciles a RemoteAgent object
+type RemoteAgentReconciler struct {
+	client.Client
+	Scheme     *runtime.Scheme
+	Reconciler autogen.AutogenReconciler
+}
+
+// +kubebuilder:rbac:groups=kagent.dev,resources=remoteagents,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=kagent.dev,resources=remoteagents/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=kagent.dev,resources=remoteagents/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=configmaps;secrets,verbs=get;list;watch
+
+func (r *RemoteAgentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	_ = log.FromContext(ctx)
+
+	// the remote agent is requeued after its refresh interval to fetch its agent card again
+	return r.Reconciler.ReconcileAutogenRemoteAgent(ctx, req)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RemoteAgentReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		// the status is updated on every refresh, so only spec changes trigger a reconcile
+		For(&agentv1alpha1.RemoteAgent{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// the values of headers and credentials are resolved from config maps and secrets
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(
+			autogen.RequestsForIndex(r.Client, &agentv1alpha1.RemoteAgentList{}, autogen.RemoteAgentConfigMapIndex))).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(
+			autogen.RequestsForIndex(r.Client, &agentv1alpha1.RemoteAgentList{}, autogen.RemoteAgentSecretIndex))).
+		Named("remoteagent").
+		Complete(r)
+}
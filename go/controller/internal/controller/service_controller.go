@@ -0,0 +1,244 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	agentv1alpha1 "github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+)
+
+const (
+	// MCPPathAnnotation marks a Service as an MCP server, and sets the path of its MCP endpoint
+	MCPPathAnnotation = "kagent.dev/mcp-path"
+	// MCPTransportAnnotation sets the transport used to connect to the MCP server. Defaults to sse.
+	MCPTransportAnnotation = "kagent.dev/mcp-transport"
+	// MCPPortAnnotation sets the name or number of the Service port of the MCP server. Defaults to the first port.
+	MCPPortAnnotation = "kagent.dev/mcp-port"
+	// MCPDescriptionAnnotation sets the description of the created ToolServer
+	MCPDescriptionAnnotation = "kagent.dev/mcp-description"
+	// MCPSchemeAnnotation sets the scheme used to connect to the MCP server, http or https.
+	// Defaults to https for port 443 and to http otherwise.
+	MCPSchemeAnnotation = "kagent.dev/mcp-scheme"
+
+	mcpTransportSse = "sse"
+)
+
+// ServiceReconciler creates a ToolServer for each Service annotated with kagent.dev/mcp-path.
+// The ToolServer is owned by the Service, and is deleted with it or when the annotation is removed.
+type ServiceReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// ClusterDomain is appended to the host names of the services, e.g. cluster.local.
+	// If empty, the host names end with .svc and are completed by the DNS search path of the pods.
+	ClusterDomain string
+}
+
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kagent.dev,resources=toolservers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+func (r *ServiceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	service := &corev1.Service{}
+	if err := r.Get(ctx, req.NamespacedName, service); err != nil {
+		// the owned tool server is garbage collected with the service
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	existing := &agentv1alpha1.ToolServer{}
+	if err := r.Get(ctx, req.NamespacedName, existing); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("failed to get tool server %s: %v", req.Name, err)
+		}
+		existing = nil
+	}
+
+	if existing != nil && !metav1.IsControlledBy(existing, service) {
+		// never take over a tool server created by someone else
+		if _, ok := service.Annotations[MCPPathAnnotation]; ok {
+			r.Recorder.Eventf(service, corev1.EventTypeWarning, "ToolServerConflict",
+				"ToolServer %s already exists and is not owned by this service", existing.Name)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if _, ok := service.Annotations[MCPPathAnnotation]; !ok {
+		if existing == nil {
+			return ctrl.Result{}, nil
+		}
+		logger.Info("Deleting tool server of service without MCP annotation", "toolServer", existing.Name)
+		if err := r.Delete(ctx, existing); err != nil && !k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("failed to delete tool server %s: %v", existing.Name, err)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	config, err := serviceToolServerConfig(service, r.ClusterDomain)
+	if err != nil {
+		// the service is reconciled again when its annotations change
+		r.Recorder.Event(service, corev1.EventTypeWarning, "InvalidMCPAnnotations", err.Error())
+		return ctrl.Result{}, nil
+	}
+
+	toolServer := &agentv1alpha1.ToolServer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      service.Name,
+			Namespace: service.Namespace,
+		},
+	}
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, toolServer, func() error {
+		toolServer.Spec.Description = serviceToolServerDescription(service)
+		toolServer.Spec.Config = *config
+		return controllerutil.SetControllerReference(service, toolServer, r.Scheme)
+	})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to create or update tool server %s: %v", toolServer.Name, err)
+	}
+	if result != controllerutil.OperationResultNone {
+		logger.Info("Reconciled tool server for service", "toolServer", toolServer.Name, "operation", result)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func serviceToolServerConfig(service *corev1.Service, clusterDomain string) (*agentv1alpha1.ToolServerConfig, error) {
+	transport := service.Annotations[MCPTransportAnnotation]
+	if transport == "" {
+		transport = mcpTransportSse
+	}
+	if transport != mcpTransportSse {
+		return nil, fmt.Errorf("unsupported MCP transport %q, only %s is supported", transport, mcpTransportSse)
+	}
+
+	port, err := serviceMCPPort(service)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme, err := serviceMCPScheme(service, port)
+	if err != nil {
+		return nil, err
+	}
+
+	path := service.Annotations[MCPPathAnnotation]
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	host := fmt.Sprintf("%s.%s.svc", service.Name, service.Namespace)
+	if clusterDomain != "" {
+		host += "." + strings.Trim(clusterDomain, ".")
+	}
+
+	return &agentv1alpha1.ToolServerConfig{
+		Sse: &agentv1alpha1.SseMcpServerConfig{
+			URL: fmt.Sprintf("%s://%s:%d%s", scheme, host, port, path),
+		},
+	}, nil
+}
+
+func serviceMCPScheme(service *corev1.Service, port int32) (string, error) {
+	switch scheme := service.Annotations[MCPSchemeAnnotation]; scheme {
+	case "http", "https":
+		return scheme, nil
+	case "":
+		if port == 443 {
+			return "https", nil
+		}
+		return "http", nil
+	default:
+		return "", fmt.Errorf("unsupported MCP scheme %q, only http and https are supported", scheme)
+	}
+}
+
+func serviceMCPPort(service *corev1.Service) (int32, error) {
+	if len(service.Spec.Ports) == 0 {
+		return 0, fmt.Errorf("service %s has no ports", service.Name)
+	}
+
+	portAnnotation := service.Annotations[MCPPortAnnotation]
+	if portAnnotation == "" {
+		return service.Spec.Ports[0].Port, nil
+	}
+
+	for _, port := range service.Spec.Ports {
+		if port.Name == portAnnotation || strconv.Itoa(int(port.Port)) == portAnnotation {
+			return port.Port, nil
+		}
+	}
+
+	return 0, fmt.Errorf("port %s not found in service %s", portAnnotation, service.Name)
+}
+
+func serviceToolServerDescription(service *corev1.Service) string {
+	if description := service.Annotations[MCPDescriptionAnnotation]; description != "" {
+		return description
+	}
+	return fmt.Sprintf("MCP server exposed by service %s/%s", service.Namespace, service.Name)
+}
+
+// hasMCPAnnotation matches services which are or were annotated as MCP servers, so that removing the annotation
+// deletes the tool server
+func hasMCPAnnotation() predicate.Predicate {
+	annotated := func(obj client.Object) bool {
+		_, ok := obj.GetAnnotations()[MCPPathAnnotation]
+		return ok
+	}
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return annotated(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return annotated(e.ObjectOld) || annotated(e.ObjectNew)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return annotated(e.Object)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return annotated(e.Object)
+		},
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Service{}, builder.WithPredicates(hasMCPAnnotation())).
+		// revert changes to the spec of owned tool servers, ignoring their status updates
+		Owns(&agentv1alpha1.ToolServer{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Named("mcpservice").
+		Complete(r)
+}
@@ -0,0 +1,200 @@
+package controller_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"github.com/kagent-dev/kagent/go/controller/internal/controller"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestServiceReconciler(t *testing.T) {
+	ctx := context.Background()
+	scheme := scheme.Scheme
+	err := v1alpha1.AddToScheme(scheme)
+	require.NoError(t, err)
+
+	namespace := "test-namespace"
+
+	newService := func(name string, annotations map[string]string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   namespace,
+				UID:         types.UID(name),
+				Annotations: annotations,
+			},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{
+					{Name: "metrics", Port: 9090},
+					{Name: "mcp", Port: 8080},
+				},
+			},
+		}
+	}
+
+	newReconciler := func(objs ...*corev1.Service) (*controller.ServiceReconciler, *record.FakeRecorder) {
+		builder := fake.NewClientBuilder().WithScheme(scheme)
+		for _, obj := range objs {
+			builder.WithObjects(obj)
+		}
+		recorder := record.NewFakeRecorder(10)
+		return &controller.ServiceReconciler{
+			Client:   builder.Build(),
+			Scheme:   scheme,
+			Recorder: recorder,
+		}, recorder
+	}
+
+	reconcile := func(t *testing.T, r *controller.ServiceReconciler, name string) {
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}})
+		require.NoError(t, err)
+	}
+
+	getToolServer := func(r *controller.ServiceReconciler, name string) (*v1alpha1.ToolServer, error) {
+		toolServer := &v1alpha1.ToolServer{}
+		err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, toolServer)
+		return toolServer, err
+	}
+
+	t.Run("should create an owned tool server for an annotated service", func(t *testing.T) {
+		service := newService("mcp-server", map[string]string{
+			controller.MCPPathAnnotation: "sse",
+			controller.MCPPortAnnotation: "mcp",
+		})
+		r, _ := newReconciler(service)
+
+		reconcile(t, r, "mcp-server")
+
+		toolServer, err := getToolServer(r, "mcp-server")
+		require.NoError(t, err)
+		require.NotNil(t, toolServer.Spec.Config.Sse)
+		assert.Equal(t, "http://mcp-server.test-namespace.svc:8080/sse", toolServer.Spec.Config.Sse.URL)
+		assert.True(t, metav1.IsControlledBy(toolServer, service))
+	})
+
+	t.Run("should update the tool server when the annotations change", func(t *testing.T) {
+		service := newService("mcp-server", map[string]string{controller.MCPPathAnnotation: "/sse"})
+		r, _ := newReconciler(service)
+		reconcile(t, r, "mcp-server")
+
+		service.Annotations[controller.MCPPathAnnotation] = "/mcp/sse"
+		service.Annotations[controller.MCPPortAnnotation] = "8080"
+		err := r.Update(ctx, service)
+		require.NoError(t, err)
+		reconcile(t, r, "mcp-server")
+
+		toolServer, err := getToolServer(r, "mcp-server")
+		require.NoError(t, err)
+		assert.Equal(t, "http://mcp-server.test-namespace.svc:8080/mcp/sse", toolServer.Spec.Config.Sse.URL)
+	})
+
+	t.Run("should delete the tool server when the annotation is removed", func(t *testing.T) {
+		service := newService("mcp-server", map[string]string{controller.MCPPathAnnotation: "/sse"})
+		r, _ := newReconciler(service)
+		reconcile(t, r, "mcp-server")
+
+		service.Annotations = nil
+		err := r.Update(ctx, service)
+		require.NoError(t, err)
+		reconcile(t, r, "mcp-server")
+
+		_, err = getToolServer(r, "mcp-server")
+		assert.True(t, k8serrors.IsNotFound(err))
+	})
+
+	t.Run("should not take over a tool server it does not own", func(t *testing.T) {
+		service := newService("mcp-server", map[string]string{controller.MCPPathAnnotation: "/sse"})
+		r, recorder := newReconciler(service)
+		err := r.Create(ctx, &v1alpha1.ToolServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "mcp-server", Namespace: namespace},
+			Spec: v1alpha1.ToolServerSpec{
+				Config: v1alpha1.ToolServerConfig{
+					Sse: &v1alpha1.SseMcpServerConfig{URL: "http://custom:8080/sse"},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		reconcile(t, r, "mcp-server")
+
+		toolServer, err := getToolServer(r, "mcp-server")
+		require.NoError(t, err)
+		assert.Equal(t, "http://custom:8080/sse", toolServer.Spec.Config.Sse.URL)
+		assert.Contains(t, <-recorder.Events, "ToolServerConflict")
+	})
+
+	t.Run("should report unsupported transports", func(t *testing.T) {
+		service := newService("mcp-server", map[string]string{
+			controller.MCPPathAnnotation:      "/mcp",
+			controller.MCPTransportAnnotation: "websocket",
+		})
+		r, recorder := newReconciler(service)
+
+		reconcile(t, r, "mcp-server")
+
+		_, err := getToolServer(r, "mcp-server")
+		assert.True(t, k8serrors.IsNotFound(err))
+		assert.Contains(t, <-recorder.Events, "InvalidMCPAnnotations")
+	})
+
+	t.Run("should connect with https to port 443 or when annotated", func(t *testing.T) {
+		service := newService("mcp-server", map[string]string{controller.MCPPathAnnotation: "/sse"})
+		service.Spec.Ports = []corev1.ServicePort{{Name: "https", Port: 443}}
+		annotatedService := newService("annotated-mcp-server", map[string]string{
+			controller.MCPPathAnnotation:   "/sse",
+			controller.MCPSchemeAnnotation: "https",
+		})
+		r, _ := newReconciler(service, annotatedService)
+
+		reconcile(t, r, "mcp-server")
+		reconcile(t, r, "annotated-mcp-server")
+
+		toolServer, err := getToolServer(r, "mcp-server")
+		require.NoError(t, err)
+		assert.Equal(t, "https://mcp-server.test-namespace.svc:443/sse", toolServer.Spec.Config.Sse.URL)
+		toolServer, err = getToolServer(r, "annotated-mcp-server")
+		require.NoError(t, err)
+		assert.Equal(t, "https://annotated-mcp-server.test-namespace.svc:9090/sse", toolServer.Spec.Config.Sse.URL)
+	})
+
+	t.Run("should report unsupported schemes", func(t *testing.T) {
+		service := newService("mcp-server", map[string]string{
+			controller.MCPPathAnnotation:   "/sse",
+			controller.MCPSchemeAnnotation: "ftp",
+		})
+		r, recorder := newReconciler(service)
+
+		reconcile(t, r, "mcp-server")
+
+		_, err := getToolServer(r, "mcp-server")
+		assert.True(t, k8serrors.IsNotFound(err))
+		assert.Contains(t, <-recorder.Events, "InvalidMCPAnnotations")
+	})
+
+	t.Run("should append the cluster domain", func(t *testing.T) {
+		service := newService("mcp-server", map[string]string{
+			controller.MCPPathAnnotation: "/sse",
+			controller.MCPPortAnnotation: "mcp",
+		})
+		r, _ := newReconciler(service)
+		r.ClusterDomain = "cluster.example"
+
+		reconcile(t, r, "mcp-server")
+
+		toolServer, err := getToolServer(r, "mcp-server")
+		require.NoError(t, err)
+		assert.Equal(t, "http://mcp-server.test-namespace.svc.cluster.example:8080/sse", toolServer.Spec.Config.Sse.URL)
+	})
+}
@@ -18,14 +18,17 @@ package controller
 
 import (
 	"context"
-	"time"
 
 	"github.com/kagent-dev/kagent/go/controller/internal/autogen"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	agentv1alpha1 "github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
 )
@@ -33,28 +36,38 @@ import (
 // ToolServerReconciler reconciles a ToolServer object
 type ToolServerReconciler struct {
 	client.Client
-	Scheme     *runtime.Scheme
-	Reconciler autogen.AutogenReconciler
+	Scheme       *runtime.Scheme
+	Reconciler   autogen.AutogenReconciler
+	Availability *autogen.AutogenAvailability
 }
 
-// +kubebuilder:rbac:groups=agent.kagent.dev,resources=toolservers,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=agent.kagent.dev,resources=toolservers/status,verbs=get;update;patch
-// +kubebuilder:rbac:groups=agent.kagent.dev,resources=toolservers/finalizers,verbs=update
+// +kubebuilder:rbac:groups=kagent.dev,resources=toolservers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=kagent.dev,resources=toolservers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=kagent.dev,resources=toolservers/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=configmaps;secrets,verbs=get;list;watch
+// the oauth2 token of a tool server is stored in a Secret it owns, from which it is read when connecting
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=create;update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *ToolServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	_ = log.FromContext(ctx)
 
-	return ctrl.Result{
-		// loop forever because we need to refresh tools server status
-		Requeue:      true,
-		RequeueAfter: 60 * time.Second,
-	}, r.Reconciler.ReconcileAutogenToolServer(ctx, req)
+	// the tool server is requeued after its refresh interval, or earlier while discovery fails
+	return r.Reconciler.ReconcileAutogenToolServer(ctx, req)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ToolServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&agentv1alpha1.ToolServer{}).
+		// the status is updated on every refresh, so only spec changes trigger a reconcile
+		For(&agentv1alpha1.ToolServer{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// the values of headers and credentials are resolved from config maps and secrets
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(
+			autogen.RequestsForIndex(r.Client, &agentv1alpha1.ToolServerList{}, autogen.ToolServerConfigMapIndex))).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(
+			autogen.RequestsForIndex(r.Client, &agentv1alpha1.ToolServerList{}, autogen.ToolServerSecretIndex))).
+		// all tool servers are reconciled again once autogen is available again
+		WatchesRawSource(r.Availability.ResyncSource(&agentv1alpha1.ToolServerList{})).
 		Named("toolserver").
 		Complete(r)
 }
@@ -48,6 +48,24 @@ func NewNotFoundError(message string, err error) *APIError {
 	}
 }
 
+// NewUnauthorizedError creates a new unauthorized error
+func NewUnauthorizedError(message string, err error) *APIError {
+	return &APIError{
+		Code:    http.StatusUnauthorized,
+		Message: message,
+		Err:     err,
+	}
+}
+
+// NewForbiddenError creates a new forbidden error
+func NewForbiddenError(message string, err error) *APIError {
+	return &APIError{
+		Code:    http.StatusForbidden,
+		Message: message,
+		Err:     err,
+	}
+}
+
 // NewInternalServerError creates a new internal server error
 func NewInternalServerError(message string, err error) *APIError {
 	return &APIError{
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+
+	autogen_client "github.com/kagent-dev/kagent/go/autogen/client"
+	"github.com/kagent-dev/kagent/go/controller/internal/httpserver/errors"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ApprovalsHandler handles the approval of tool calls which require one
+type ApprovalsHandler struct {
+	*Base
+}
+
+// NewApprovalsHandler creates a new approvals handler
+func NewApprovalsHandler(base *Base) *ApprovalsHandler {
+	return &ApprovalsHandler{Base: base}
+}
+
+// ApprovalDecisionRequest is the optional body of an approval or a denial
+type ApprovalDecisionRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// HandleListApprovals lists the approval requests, optionally filtered by the status query parameter
+func (h *ApprovalsHandler) HandleListApprovals(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("approvals-handler").WithValues("operation", "list")
+
+	status := autogen_client.ApprovalStatus(r.URL.Query().Get("status"))
+	if status != "" && !slices.Contains([]autogen_client.ApprovalStatus{
+		autogen_client.ApprovalStatusPending,
+		autogen_client.ApprovalStatusApproved,
+		autogen_client.ApprovalStatusDenied,
+		autogen_client.ApprovalStatusExpired,
+		autogen_client.ApprovalStatusCancelled,
+	}, status) {
+		w.RespondWithError(errors.NewBadRequestError("Invalid approval status "+string(status), nil))
+		return
+	}
+
+	approvals, err := h.AutogenClient.ListApprovals(status)
+	if err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to list approvals", err))
+		return
+	}
+
+	log.Info("Listed approvals", "count", len(approvals))
+	RespondWithJSON(w, http.StatusOK, approvals)
+}
+
+// HandleApproveToolCall approves a pending tool call
+func (h *ApprovalsHandler) HandleApproveToolCall(w ErrorResponseWriter, r *http.Request) {
+	h.handleDecision(w, r, autogen_client.ApprovalStatusApproved)
+}
+
+// HandleDenyToolCall denies a pending tool call
+func (h *ApprovalsHandler) HandleDenyToolCall(w ErrorResponseWriter, r *http.Request) {
+	h.handleDecision(w, r, autogen_client.ApprovalStatusDenied)
+}
+
+func (h *ApprovalsHandler) handleDecision(w ErrorResponseWriter, r *http.Request, status autogen_client.ApprovalStatus) {
+	log := ctrllog.FromContext(r.Context()).WithName("approvals-handler").WithValues("operation", string(status))
+
+	approvalID, err := GetPathParam(r, "approvalID")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get approval ID from path", err))
+		return
+	}
+	log = log.WithValues("approvalID", approvalID)
+
+	// the decision is recorded with the identity of the approver, so it can't be taken from the request
+	userID, apiErr := h.authenticatedUser(r)
+	if apiErr != nil {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		w.RespondWithError(apiErr)
+		return
+	}
+
+	var decisionReq ApprovalDecisionRequest
+	if r.ContentLength != 0 {
+		if err := DecodeJSONBody(r, &decisionReq); err != nil {
+			w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
+			return
+		}
+	}
+
+	pending, err := h.AutogenClient.ListApprovals(autogen_client.ApprovalStatusPending)
+	if err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to list approvals", err))
+		return
+	}
+	if !slices.ContainsFunc(pending, func(approval *autogen_client.Approval) bool {
+		return approval.ApprovalID == approvalID
+	}) {
+		w.RespondWithError(errors.NewNotFoundError("No pending approval with ID "+approvalID, nil))
+		return
+	}
+
+	decision := &autogen_client.ApprovalDecision{
+		UserID: userID,
+		Reason: decisionReq.Reason,
+	}
+	var approval *autogen_client.Approval
+	if status == autogen_client.ApprovalStatusApproved {
+		approval, err = h.AutogenClient.ApproveToolCall(approvalID, decision)
+	} else {
+		approval, err = h.AutogenClient.DenyToolCall(approvalID, decision)
+	}
+	if err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to decide on the tool call", err))
+		return
+	}
+
+	log.Info("Decided on tool call", "tool", approval.Tool, "agent", approval.Agent, "userID", userID)
+	RespondWithJSON(w, http.StatusOK, approval)
+}
@@ -0,0 +1,147 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+
+	autogen_client "github.com/kagent-dev/kagent/go/autogen/client"
+	"github.com/kagent-dev/kagent/go/autogen/client/fake"
+	"github.com/kagent-dev/kagent/go/controller/internal/httpserver/handlers"
+)
+
+// staticAuthenticator maps tokens to users
+type staticAuthenticator map[string]*authenticationv1.UserInfo
+
+func (a staticAuthenticator) Authenticate(_ context.Context, token string) (*authenticationv1.UserInfo, error) {
+	user, ok := a[token]
+	if !ok {
+		return nil, fmt.Errorf("unknown token")
+	}
+	return user, nil
+}
+
+func TestApprovalsHandler(t *testing.T) {
+	setupHandler := func() (*handlers.ApprovalsHandler, *fake.InMemoryAutogenClient) {
+		autogenClient := fake.NewInMemoryAutogenClient()
+		autogenClient.AddApproval(&autogen_client.Approval{
+			ApprovalID: "pending-1",
+			Agent:      "kagent/k8s-agent",
+			Tool:       "delete_pod",
+			Arguments:  map[string]interface{}{"name": "test-pod"},
+			Status:     autogen_client.ApprovalStatusPending,
+		})
+		autogenClient.AddApproval(&autogen_client.Approval{
+			ApprovalID: "denied-1",
+			Agent:      "kagent/k8s-agent",
+			Tool:       "delete_pod",
+			Status:     autogen_client.ApprovalStatusDenied,
+			DecidedBy:  "alice",
+		})
+		return handlers.NewApprovalsHandler(&handlers.Base{
+			AutogenClient: autogenClient,
+			Authenticator: staticAuthenticator{"bob-token": {Username: "bob"}},
+		}), autogenClient
+	}
+
+	serve := func(handler *handlers.ApprovalsHandler, method, target, token string, body interface{}) *mockErrorResponseWriter {
+		var reqBody bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&reqBody).Encode(body))
+		}
+		req := httptest.NewRequest(method, target, &reqBody)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		responseRecorder := newMockErrorResponseWriter()
+
+		router := mux.NewRouter()
+		router.HandleFunc("/api/approvals", func(w http.ResponseWriter, r *http.Request) {
+			handler.HandleListApprovals(responseRecorder, r)
+		}).Methods("GET")
+		router.HandleFunc("/api/approvals/{approvalID}/approve", func(w http.ResponseWriter, r *http.Request) {
+			handler.HandleApproveToolCall(responseRecorder, r)
+		}).Methods("POST")
+		router.HandleFunc("/api/approvals/{approvalID}/deny", func(w http.ResponseWriter, r *http.Request) {
+			handler.HandleDenyToolCall(responseRecorder, r)
+		}).Methods("POST")
+
+		router.ServeHTTP(responseRecorder, req)
+		return responseRecorder
+	}
+
+	t.Run("ListPendingApprovals", func(t *testing.T) {
+		handler, _ := setupHandler()
+		responseRecorder := serve(handler, "GET", "/api/approvals?status=pending", "", nil)
+
+		require.Equal(t, http.StatusOK, responseRecorder.Code)
+		var approvals []*autogen_client.Approval
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &approvals))
+		require.Len(t, approvals, 1)
+		assert.Equal(t, "pending-1", approvals[0].ApprovalID)
+	})
+
+	t.Run("InvalidStatus", func(t *testing.T) {
+		handler, _ := setupHandler()
+		responseRecorder := serve(handler, "GET", "/api/approvals?status=unknown", "", nil)
+
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	})
+
+	t.Run("Approve", func(t *testing.T) {
+		handler, autogenClient := setupHandler()
+		responseRecorder := serve(handler, "POST", "/api/approvals/pending-1/approve", "bob-token", nil)
+
+		require.Equal(t, http.StatusOK, responseRecorder.Code)
+		var approval autogen_client.Approval
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &approval))
+		assert.Equal(t, autogen_client.ApprovalStatusApproved, approval.Status)
+		assert.Equal(t, "bob", approval.DecidedBy)
+
+		pending, err := autogenClient.ListApprovals(autogen_client.ApprovalStatusPending)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+	})
+
+	t.Run("DenyWithReason", func(t *testing.T) {
+		handler, _ := setupHandler()
+		responseRecorder := serve(handler, "POST", "/api/approvals/pending-1/deny", "bob-token", handlers.ApprovalDecisionRequest{
+			Reason: "wrong namespace",
+		})
+
+		require.Equal(t, http.StatusOK, responseRecorder.Code)
+		var approval autogen_client.Approval
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &approval))
+		assert.Equal(t, autogen_client.ApprovalStatusDenied, approval.Status)
+		assert.Equal(t, "wrong namespace", approval.Reason)
+	})
+
+	t.Run("Unauthenticated", func(t *testing.T) {
+		handler, autogenClient := setupHandler()
+		for _, token := range []string{"", "unknown-token"} {
+			responseRecorder := serve(handler, "POST", "/api/approvals/pending-1/approve?user_id=bob", token, nil)
+			assert.Equal(t, http.StatusUnauthorized, responseRecorder.Code)
+		}
+
+		pending, err := autogenClient.ListApprovals(autogen_client.ApprovalStatusPending)
+		require.NoError(t, err)
+		assert.Len(t, pending, 1)
+	})
+
+	t.Run("AlreadyDecided", func(t *testing.T) {
+		handler, _ := setupHandler()
+		responseRecorder := serve(handler, "POST", "/api/approvals/denied-1/approve", "bob-token", nil)
+
+		assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+	})
+}
@@ -1,10 +1,16 @@
 package handlers
 
 import (
+	"context"
+	"net/http"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	autogen_client "github.com/kagent-dev/kagent/go/autogen/client"
+	"github.com/kagent-dev/kagent/go/controller/internal/httpserver/errors"
 )
 
 // Handlers holds all the HTTP handler components
@@ -20,6 +26,12 @@ type Handlers struct {
 	Invoke      *InvokeHandler
 	Memory      *MemoryHandler
 	Feedback    *FeedbackHandler
+	Approvals   *ApprovalsHandler
+}
+
+// Authenticator authenticates the bearer token of a request, for the handlers which act on behalf of a user
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*authenticationv1.UserInfo, error)
 }
 
 // Base holds common dependencies for all handlers
@@ -27,14 +39,37 @@ type Base struct {
 	KubeClient         client.Client
 	AutogenClient      autogen_client.Client
 	DefaultModelConfig types.NamespacedName
+	Authenticator      Authenticator
+}
+
+// authenticatedUser returns the user the bearer token of the request was issued to
+func (b *Base) authenticatedUser(r *http.Request) (string, *errors.APIError) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return "", errors.NewUnauthorizedError("A bearer token is required", nil)
+	}
+	if b.Authenticator == nil {
+		return "", errors.NewUnauthorizedError("Authentication is not configured", nil)
+	}
+	user, err := b.Authenticator.Authenticate(r.Context(), token)
+	if err != nil {
+		return "", errors.NewUnauthorizedError("Failed to authenticate", err)
+	}
+	return user.Username, nil
 }
 
 // NewHandlers creates a new Handlers instance with all handler components
-func NewHandlers(kubeClient client.Client, autogenClient autogen_client.Client, defaultModelConfig types.NamespacedName) *Handlers {
+func NewHandlers(
+	kubeClient client.Client,
+	autogenClient autogen_client.Client,
+	defaultModelConfig types.NamespacedName,
+	authenticator Authenticator,
+) *Handlers {
 	base := &Base{
 		KubeClient:         kubeClient,
 		AutogenClient:      autogenClient,
 		DefaultModelConfig: defaultModelConfig,
+		Authenticator:      authenticator,
 	}
 
 	return &Handlers{
@@ -49,5 +84,6 @@ func NewHandlers(kubeClient client.Client, autogenClient autogen_client.Client,
 		Invoke:      NewInvokeHandler(base),
 		Memory:      NewMemoryHandler(base),
 		Feedback:    NewFeedbackHandler(base),
+		Approvals:   NewApprovalsHandler(base),
 	}
 }
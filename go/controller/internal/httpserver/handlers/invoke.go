@@ -7,6 +7,8 @@ import (
 
 	"github.com/go-logr/logr"
 	autogen_client "github.com/kagent-dev/kagent/go/autogen/client"
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"github.com/kagent-dev/kagent/go/controller/internal/autogen"
 	"github.com/kagent-dev/kagent/go/controller/internal/httpserver/errors"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -34,6 +36,9 @@ func (h *InvokeHandler) WithClient(client autogen_client.Client) *InvokeHandler
 type InvokeRequest struct {
 	Message string `json:"message"`
 	UserID  string `json:"user_id,omitempty"`
+	// Mode restricts the tools of the agent for this invocation only, e.g. readOnly for on-call exploration.
+	// It cannot lift the restrictions of the mode of the agent.
+	Mode string `json:"mode,omitempty"`
 }
 
 // InvokeResponse contains data returned after an agent invocation.
@@ -61,6 +66,11 @@ func (h *InvokeHandler) HandleInvokeAgent(w ErrorResponseWriter, r *http.Request
 		return
 	}
 
+	if err := autogen.ApplyAgentMode(team.Component, v1alpha1.AgentMode(req.Mode)); err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to apply agent mode", err))
+		return
+	}
+
 	result, err := h.AutogenClient.InvokeTask(&autogen_client.InvokeTaskRequest{
 		Task:       req.Message,
 		TeamConfig: team.Component,
@@ -92,6 +102,11 @@ func (h *InvokeHandler) HandleInvokeAgentStream(w ErrorResponseWriter, r *http.R
 		return
 	}
 
+	if err := autogen.ApplyAgentMode(team.Component, v1alpha1.AgentMode(req.Mode)); err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to apply agent mode", err))
+		return
+	}
+
 	ch, err := h.AutogenClient.InvokeTaskStream(&autogen_client.InvokeTaskRequest{
 		Task:       req.Message,
 		TeamConfig: team.Component,
@@ -132,6 +147,10 @@ func (h *InvokeHandler) extractAgentParams(w ErrorResponseWriter, r *http.Reques
 		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
 		return 0, nil, err
 	}
+	if _, err = autogen.ParseAgentMode(invokeRequest.Mode); err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Invalid mode", err))
+		return 0, nil, err
+	}
 
 	userID := invokeRequest.UserID
 	if userID == "" {
@@ -120,4 +120,77 @@ func TestInvokeHandler(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
 		assert.NotNil(t, responseRecorder.errorReceived)
 	})
+
+	t.Run("ReadOnlyMode", func(t *testing.T) {
+		handler, mockClient, responseRecorder := setupHandler()
+
+		team := &autogen_client.Team{
+			BaseObject: autogen_client.BaseObject{
+				Id: 1,
+			},
+			Component: &api.Component{
+				Provider:      "autogen_agentchat.agents.AssistantAgent",
+				ComponentType: "agent",
+				Config: map[string]interface{}{
+					"tools": []interface{}{
+						map[string]interface{}{
+							"provider":       "kagent.tools.k8s.DeleteResource",
+							"component_type": "tool",
+						},
+					},
+				},
+			},
+		}
+		err := mockClient.CreateTeam(team)
+		require.NoError(t, err)
+
+		reqBody := handlers.InvokeRequest{
+			Message: "Test message",
+			UserID:  "test-user",
+			Mode:    "readOnly",
+		}
+		jsonBody, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest("POST", "/api/agents/1/invoke", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+
+		router := mux.NewRouter()
+		router.HandleFunc("/api/agents/{agentId}/invoke", func(w http.ResponseWriter, r *http.Request) {
+			handler.HandleInvokeAgent(responseRecorder, r)
+		}).Methods("POST")
+
+		router.ServeHTTP(responseRecorder, req)
+
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	})
+
+	t.Run("InvalidMode", func(t *testing.T) {
+		handler, mockClient, responseRecorder := setupHandler()
+
+		err := mockClient.CreateTeam(&autogen_client.Team{
+			BaseObject: autogen_client.BaseObject{
+				Id: 1,
+			},
+			Component: &api.Component{},
+		})
+		require.NoError(t, err)
+
+		reqBody := handlers.InvokeRequest{
+			Message: "Test message",
+			UserID:  "test-user",
+			Mode:    "writeOnly",
+		}
+		jsonBody, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest("POST", "/api/agents/1/invoke", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+
+		router := mux.NewRouter()
+		router.HandleFunc("/api/agents/{agentId}/invoke", func(w http.ResponseWriter, r *http.Request) {
+			handler.HandleInvokeAgent(responseRecorder, r)
+		}).Methods("POST")
+
+		router.ServeHTTP(responseRecorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+		assert.NotNil(t, responseRecorder.errorReceived)
+	})
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/kagent-dev/kagent/go/autogen/api"
 	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"github.com/kagent-dev/kagent/go/controller/internal/builtintools"
 	"github.com/kagent-dev/kagent/go/controller/internal/httpserver/errors"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -80,6 +81,15 @@ func (h *ToolsHandler) HandleListTools(w ErrorResponseWriter, r *http.Request) {
 	RespondWithJSON(w, http.StatusOK, discoveredTools)
 }
 
+// HandleListBuiltinTools handles GET /api/tools/builtin requests
+func (h *ToolsHandler) HandleListBuiltinTools(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("tools-handler").WithValues("operation", "list-builtin")
+
+	tools := builtintools.Default().List()
+	log.Info("Successfully listed builtin tools", "count", len(tools))
+	RespondWithJSON(w, http.StatusOK, tools)
+}
+
 func convertMapToMCPToolConfig(data map[string]v1alpha1.AnyType) (api.MCPToolConfig, error) {
 	var config api.MCPToolConfig
 
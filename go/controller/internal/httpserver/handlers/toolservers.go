@@ -1,11 +1,16 @@
 package handlers
 
 import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
 	"net/http"
 
 	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"github.com/kagent-dev/kagent/go/controller/internal/autogen"
 	"github.com/kagent-dev/kagent/go/controller/internal/httpserver/errors"
 	common "github.com/kagent-dev/kagent/go/controller/internal/utils"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -35,16 +40,189 @@ func (h *ToolServersHandler) HandleListToolServers(w ErrorResponseWriter, r *htt
 	for _, toolServer := range toolServerList.Items {
 		log.V(1).Info("Processing tool server", "toolServerName", toolServer.Name)
 
-		toolServerWithTools = append(toolServerWithTools, map[string]interface{}{
-			"name":            toolServer.Name,
-			"config":          toolServer.Spec.Config,
-			"discoveredTools": toolServer.Status.DiscoveredTools,
-		})
+		toolServerWithTools = append(toolServerWithTools, toolServerResponse(&toolServer))
 	}
 
 	RespondWithJSON(w, http.StatusOK, toolServerWithTools)
 }
 
+// HandleGetToolServer handles GET /api/toolservers/{toolServerName} requests
+func (h *ToolServersHandler) HandleGetToolServer(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("toolservers-handler").WithValues("operation", "get")
+
+	toolServerName, err := GetPathParam(r, "toolServerName")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get tool server name from path", err))
+		return
+	}
+	log = log.WithValues("toolServerName", toolServerName)
+
+	log.V(1).Info("Getting tool server from Kubernetes")
+	toolServer := &v1alpha1.ToolServer{}
+	if err := h.KubeClient.Get(r.Context(), types.NamespacedName{
+		Name:      toolServerName,
+		Namespace: common.GetResourceNamespace(),
+	}, toolServer); err != nil {
+		if k8serrors.IsNotFound(err) {
+			w.RespondWithError(errors.NewNotFoundError("Tool server not found in Kubernetes", err))
+			return
+		}
+		w.RespondWithError(errors.NewInternalServerError("Failed to get tool server from Kubernetes", err))
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, toolServerResponse(toolServer))
+}
+
+// ToolCallRequest is the request body of POST /api/toolservers/{toolServerName}/tools/{toolName}/call
+type ToolCallRequest struct {
+	Arguments map[string]interface{} `json:"arguments"`
+	// Mode restricts the call like the mode of an agent: readOnly refuses mutating tools,
+	// dryRun reports the call instead of running it. Defaults to readWrite.
+	Mode string `json:"mode,omitempty"`
+}
+
+// ToolCallResponse is the raw MCP result of a tool call
+type ToolCallResponse struct {
+	Content []map[string]interface{} `json:"content"`
+	IsError bool                     `json:"isError"`
+}
+
+// HandleCallTool handles POST /api/toolservers/{toolServerName}/tools/{toolName}/call requests
+func (h *ToolServersHandler) HandleCallTool(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("toolservers-handler").WithValues("operation", "call-tool")
+
+	toolServerName, err := GetPathParam(r, "toolServerName")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get tool server name from path", err))
+		return
+	}
+	toolName, err := GetPathParam(r, "toolName")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get tool name from path", err))
+		return
+	}
+	log = log.WithValues("toolServerName", toolServerName, "toolName", toolName)
+
+	// the tools act on the cluster and other systems, so the caller must be known
+	userID, apiErr := h.authenticatedUser(r)
+	if apiErr != nil {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		w.RespondWithError(apiErr)
+		return
+	}
+	log = log.WithValues("caller", userID)
+
+	var toolCallRequest ToolCallRequest
+	if err := DecodeJSONBody(r, &toolCallRequest); err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
+		return
+	}
+	if toolCallRequest.Arguments == nil {
+		toolCallRequest.Arguments = map[string]interface{}{}
+	}
+	mode, err := autogen.ParseAgentMode(toolCallRequest.Mode)
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Invalid mode", err))
+		return
+	}
+
+	toolServer := &v1alpha1.ToolServer{}
+	if err := h.KubeClient.Get(r.Context(), types.NamespacedName{
+		Name:      toolServerName,
+		Namespace: common.GetResourceNamespace(),
+	}, toolServer); err != nil {
+		if k8serrors.IsNotFound(err) {
+			w.RespondWithError(errors.NewNotFoundError("Tool server not found in Kubernetes", err))
+			return
+		}
+		w.RespondWithError(errors.NewInternalServerError("Failed to get tool server from Kubernetes", err))
+		return
+	}
+
+	var discoveredTool *v1alpha1.MCPTool
+	for _, tool := range toolServer.Status.DiscoveredTools {
+		if tool.Name == toolName {
+			discoveredTool = tool
+			break
+		}
+	}
+	if discoveredTool == nil {
+		w.RespondWithError(errors.NewNotFoundError("Tool not discovered on tool server", fmt.Errorf("tool %s not found", toolName)))
+		return
+	}
+
+	if err := common.ValidateJSONSchema(mcpToolInputSchema(discoveredTool), "arguments", toolCallRequest.Arguments); err != nil {
+		w.RespondWithError(errors.NewValidationError("Arguments do not match the input schema of the tool", err))
+		return
+	}
+
+	// tools requiring an approval are refused, as direct calls cannot wait for one
+	dryRun, err := autogen.CheckDirectToolCall(r.Context(), h.KubeClient, toolServer, discoveredTool, mode)
+	if err != nil {
+		var directCallErr *autogen.DirectToolCallError
+		if stderrors.As(err, &directCallErr) {
+			w.RespondWithError(errors.NewForbiddenError("Tool cannot be called directly", err))
+			return
+		}
+		w.RespondWithError(errors.NewInternalServerError("Failed to check tool call", err))
+		return
+	}
+	if dryRun {
+		log.Info("Reporting dry run tool call")
+		RespondWithJSON(w, http.StatusOK, &ToolCallResponse{Content: []map[string]interface{}{{
+			"type": "text",
+			"text": autogen.DryRunToolCallResult(toolName, toolCallRequest.Arguments),
+		}}})
+		return
+	}
+
+	autogenToolServer, err := autogen.GetAutogenToolServer(r.Context(), h.KubeClient, h.AutogenClient, toolServer)
+	if err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to get tool server from Autogen", err))
+		return
+	}
+
+	log.Info("Calling tool")
+	result, err := h.AutogenClient.CallTool(autogenToolServer.Id, toolName, toolCallRequest.Arguments, common.GetGlobalUserID())
+	if err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to call tool", err))
+		return
+	}
+
+	content := result.Content
+	if content == nil {
+		content = []map[string]interface{}{}
+	}
+	log.Info("Successfully called tool", "isError", result.IsError)
+	RespondWithJSON(w, http.StatusOK, &ToolCallResponse{Content: content, IsError: result.IsError})
+}
+
+// mcpToolInputSchema returns the input schema embedded in the config of a discovered MCP tool
+func mcpToolInputSchema(tool *v1alpha1.MCPTool) json.RawMessage {
+	config, ok := tool.Component.Config["tool"]
+	if !ok {
+		return nil
+	}
+	var mcpTool struct {
+		InputSchema json.RawMessage `json:"inputSchema"`
+	}
+	if err := json.Unmarshal(config.RawMessage, &mcpTool); err != nil {
+		return nil
+	}
+	return mcpTool.InputSchema
+}
+
+func toolServerResponse(toolServer *v1alpha1.ToolServer) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                toolServer.Name,
+		"config":              toolServer.Spec.Config,
+		"discoveredTools":     toolServer.Status.DiscoveredTools,
+		"discoveredResources": toolServer.Status.DiscoveredResources,
+		"discoveredPrompts":   toolServer.Status.DiscoveredPrompts,
+	}
+}
+
 // HandleCreateToolServer handles POST /api/toolservers requests
 func (h *ToolServersHandler) HandleCreateToolServer(w ErrorResponseWriter, r *http.Request) {
 	log := ctrllog.FromContext(r.Context()).WithName("toolservers-handler").WithValues("operation", "create")
@@ -0,0 +1,230 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/autogen/api"
+	autogen_client "github.com/kagent-dev/kagent/go/autogen/client"
+	"github.com/kagent-dev/kagent/go/autogen/client/fake"
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"github.com/kagent-dev/kagent/go/controller/internal/httpserver/handlers"
+	common "github.com/kagent-dev/kagent/go/controller/internal/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCallToolHandler(t *testing.T) {
+	err := v1alpha1.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
+
+	setupHandler := func(objects ...client.Object) *handlers.ToolServersHandler {
+		autogenClient := fake.NewInMemoryAutogenClient()
+		autogenToolServer, err := autogenClient.CreateToolServer(&autogen_client.ToolServer{
+			Component: api.Component{Label: "test-server"},
+		}, common.GetGlobalUserID())
+		require.NoError(t, err)
+
+		toolServer := &v1alpha1.ToolServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: common.GetResourceNamespace()},
+			Status: v1alpha1.ToolServerStatus{
+				ServerID: autogenToolServer.Id,
+				DiscoveredTools: []*v1alpha1.MCPTool{{
+					Name: "get_pod",
+					Component: v1alpha1.Component{
+						Config: map[string]v1alpha1.AnyType{
+							"tool": {RawMessage: json.RawMessage(`{"name":"get_pod","inputSchema":{"type":"object","properties":{"name":{"type":"string"}},"required":["name"]}}`)},
+						},
+					},
+				}},
+			},
+		}
+		kubeClient := fakeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(append(objects, toolServer)...).Build()
+
+		return handlers.NewToolServersHandler(&handlers.Base{
+			KubeClient:    kubeClient,
+			AutogenClient: autogenClient,
+			Authenticator: staticAuthenticator{"bob-token": {Username: "bob"}},
+		})
+	}
+
+	callToolWithToken := func(handler *handlers.ToolServersHandler, token, toolName string, arguments map[string]interface{}, mode v1alpha1.AgentMode) *mockErrorResponseWriter {
+		jsonBody, _ := json.Marshal(handlers.ToolCallRequest{Arguments: arguments, Mode: string(mode)})
+		req := httptest.NewRequest("POST", "/api/toolservers/test-server/tools/"+toolName+"/call", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		responseRecorder := newMockErrorResponseWriter()
+
+		router := mux.NewRouter()
+		router.HandleFunc("/api/toolservers/{toolServerName}/tools/{toolName}/call", func(w http.ResponseWriter, r *http.Request) {
+			handler.HandleCallTool(responseRecorder, r)
+		}).Methods("POST")
+
+		router.ServeHTTP(responseRecorder, req)
+		return responseRecorder
+	}
+	callToolWithMode := func(handler *handlers.ToolServersHandler, toolName string, arguments map[string]interface{}, mode v1alpha1.AgentMode) *mockErrorResponseWriter {
+		return callToolWithToken(handler, "bob-token", toolName, arguments, mode)
+	}
+	callTool := func(handler *handlers.ToolServersHandler, toolName string, arguments map[string]interface{}) *mockErrorResponseWriter {
+		return callToolWithMode(handler, toolName, arguments, "")
+	}
+
+	t.Run("CallTool", func(t *testing.T) {
+		responseRecorder := callTool(setupHandler(), "get_pod", map[string]interface{}{"name": "test-pod"})
+
+		require.Equal(t, http.StatusOK, responseRecorder.Code)
+		var response handlers.ToolCallResponse
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.False(t, response.IsError)
+		require.Len(t, response.Content, 1)
+		assert.Equal(t, `{"name":"test-pod"}`, response.Content[0]["text"])
+	})
+
+	t.Run("MissingToken", func(t *testing.T) {
+		responseRecorder := callToolWithToken(setupHandler(), "", "get_pod", map[string]interface{}{"name": "test-pod"}, "")
+
+		assert.Equal(t, http.StatusUnauthorized, responseRecorder.Code)
+		assert.Equal(t, "Bearer", responseRecorder.Header().Get("WWW-Authenticate"))
+	})
+
+	t.Run("InvalidToken", func(t *testing.T) {
+		responseRecorder := callToolWithToken(setupHandler(), "mallory-token", "get_pod", map[string]interface{}{"name": "test-pod"}, "")
+
+		assert.Equal(t, http.StatusUnauthorized, responseRecorder.Code)
+	})
+
+	t.Run("InvalidArguments", func(t *testing.T) {
+		responseRecorder := callTool(setupHandler(), "get_pod", map[string]interface{}{"name": 1})
+
+		assert.Equal(t, http.StatusUnprocessableEntity, responseRecorder.Code)
+		assert.Contains(t, responseRecorder.errorReceived.Error(), "arguments.name")
+	})
+
+	t.Run("MissingRequiredArgument", func(t *testing.T) {
+		responseRecorder := callTool(setupHandler(), "get_pod", nil)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, responseRecorder.Code)
+		assert.Contains(t, responseRecorder.errorReceived.Error(), "name")
+	})
+
+	t.Run("UnknownTool", func(t *testing.T) {
+		responseRecorder := callTool(setupHandler(), "delete_pod", nil)
+
+		assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+	})
+
+	t.Run("RequiresApproval", func(t *testing.T) {
+		responseRecorder := callTool(setupHandler(&v1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "k8s-agent", Namespace: "agents"},
+			Spec: v1alpha1.AgentSpec{
+				Tools: []*v1alpha1.Tool{{
+					Type: v1alpha1.ToolProviderType_McpServer,
+					McpServer: &v1alpha1.McpServerTool{
+						ToolServer:      common.GetResourceNamespace() + "/test-server",
+						IncludePatterns: []string{"get_*"},
+					},
+					RequireApproval: true,
+				}},
+			},
+		}), "get_pod", map[string]interface{}{"name": "test-pod"})
+
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+		assert.Contains(t, responseRecorder.errorReceived.Error(), "agents/k8s-agent")
+	})
+
+	t.Run("ReadOnlyMode", func(t *testing.T) {
+		responseRecorder := callToolWithMode(setupHandler(), "get_pod", map[string]interface{}{"name": "test-pod"}, v1alpha1.AgentMode_ReadOnly)
+
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+	})
+
+	t.Run("DryRunMode", func(t *testing.T) {
+		responseRecorder := callToolWithMode(setupHandler(), "get_pod", map[string]interface{}{"name": "test-pod"}, v1alpha1.AgentMode_DryRun)
+
+		require.Equal(t, http.StatusOK, responseRecorder.Code)
+		var response handlers.ToolCallResponse
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+		require.Len(t, response.Content, 1)
+		assert.Contains(t, response.Content[0]["text"], "Dry run: get_pod was not executed")
+	})
+
+	t.Run("InvalidMode", func(t *testing.T) {
+		responseRecorder := callToolWithMode(setupHandler(), "get_pod", map[string]interface{}{"name": "test-pod"}, "unknown")
+
+		assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	})
+}
+
+func TestGetToolServerHandler(t *testing.T) {
+	err := v1alpha1.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
+
+	toolServer := &v1alpha1.ToolServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: common.GetResourceNamespace()},
+		Status: v1alpha1.ToolServerStatus{
+			DiscoveredTools: []*v1alpha1.MCPTool{{Name: "get_pod"}},
+			DiscoveredResources: []*v1alpha1.MCPResource{{
+				URI:  "file:///runbook.md",
+				Name: "runbook",
+			}},
+			DiscoveredPrompts: []*v1alpha1.MCPPrompt{{
+				Name:      "triage",
+				Arguments: []v1alpha1.MCPPromptArgument{{Name: "service", Required: true}},
+			}},
+		},
+	}
+	handler := handlers.NewToolServersHandler(&handlers.Base{
+		KubeClient: fakeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(toolServer).Build(),
+	})
+
+	getToolServer := func(name string) *mockErrorResponseWriter {
+		req := httptest.NewRequest("GET", "/api/toolservers/"+name, nil)
+		responseRecorder := newMockErrorResponseWriter()
+
+		router := mux.NewRouter()
+		router.HandleFunc("/api/toolservers/{toolServerName}", func(w http.ResponseWriter, r *http.Request) {
+			handler.HandleGetToolServer(responseRecorder, r)
+		}).Methods("GET")
+
+		router.ServeHTTP(responseRecorder, req)
+		return responseRecorder
+	}
+
+	t.Run("GetToolServer", func(t *testing.T) {
+		responseRecorder := getToolServer("test-server")
+
+		require.Equal(t, http.StatusOK, responseRecorder.Code)
+		var response struct {
+			Name                string                  `json:"name"`
+			DiscoveredTools     []*v1alpha1.MCPTool     `json:"discoveredTools"`
+			DiscoveredResources []*v1alpha1.MCPResource `json:"discoveredResources"`
+			DiscoveredPrompts   []*v1alpha1.MCPPrompt   `json:"discoveredPrompts"`
+		}
+		err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, "test-server", response.Name)
+		assert.Equal(t, toolServer.Status.DiscoveredTools, response.DiscoveredTools)
+		assert.Equal(t, toolServer.Status.DiscoveredResources, response.DiscoveredResources)
+		assert.Equal(t, toolServer.Status.DiscoveredPrompts, response.DiscoveredPrompts)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		responseRecorder := getToolServer("missing-server")
+
+		assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+		require.NotNil(t, responseRecorder.errorReceived)
+	})
+}
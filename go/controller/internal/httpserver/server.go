@@ -30,6 +30,8 @@ const (
 	APIPathMemories    = "/api/memories"
 	APIPathA2A         = "/api/a2a"
 	APIPathFeedback    = "/api/feedback"
+	APIPathMCP         = "/api/mcp"
+	APIPathApprovals   = "/api/approvals"
 )
 
 var defaultModelConfig = types.NamespacedName{
@@ -43,6 +45,9 @@ type ServerConfig struct {
 	AutogenClient autogen_client.Client
 	KubeClient    client.Client
 	A2AHandler    a2a.A2AHandlerMux
+	MCPGateway    http.Handler
+	// Authenticator authenticates the users calling tools and approving or denying tool calls
+	Authenticator handlers.Authenticator
 }
 
 // HTTPServer is the structure that manages the HTTP server
@@ -58,7 +63,7 @@ func NewHTTPServer(config ServerConfig) *HTTPServer {
 	return &HTTPServer{
 		config:   config,
 		router:   mux.NewRouter(),
-		handlers: handlers.NewHandlers(config.KubeClient, config.AutogenClient, defaultModelConfig),
+		handlers: handlers.NewHandlers(config.KubeClient, config.AutogenClient, defaultModelConfig, config.Authenticator),
 	}
 }
 
@@ -135,11 +140,14 @@ func (s *HTTPServer) setupRoutes() {
 
 	// Tools
 	s.router.HandleFunc(APIPathTools, adaptHandler(s.handlers.Tools.HandleListTools)).Methods(http.MethodGet)
+	s.router.HandleFunc(APIPathTools+"/builtin", adaptHandler(s.handlers.Tools.HandleListBuiltinTools)).Methods(http.MethodGet)
 
 	// Tool Servers
 	s.router.HandleFunc(APIPathToolServers, adaptHandler(s.handlers.ToolServers.HandleListToolServers)).Methods(http.MethodGet)
 	s.router.HandleFunc(APIPathToolServers, adaptHandler(s.handlers.ToolServers.HandleCreateToolServer)).Methods(http.MethodPost)
+	s.router.HandleFunc(APIPathToolServers+"/{toolServerName}", adaptHandler(s.handlers.ToolServers.HandleGetToolServer)).Methods(http.MethodGet)
 	s.router.HandleFunc(APIPathToolServers+"/{toolServerName}", adaptHandler(s.handlers.ToolServers.HandleDeleteToolServer)).Methods(http.MethodDelete)
+	s.router.HandleFunc(APIPathToolServers+"/{toolServerName}/tools/{toolName}/call", adaptHandler(s.handlers.ToolServers.HandleCallTool)).Methods(http.MethodPost)
 
 	// Teams
 	s.router.HandleFunc(APIPathTeams, adaptHandler(s.handlers.Teams.HandleListTeams)).Methods(http.MethodGet)
@@ -170,9 +178,19 @@ func (s *HTTPServer) setupRoutes() {
 	s.router.HandleFunc(APIPathFeedback, adaptHandler(s.handlers.Feedback.HandleCreateFeedback)).Methods(http.MethodPost)
 	s.router.HandleFunc(APIPathFeedback, adaptHandler(s.handlers.Feedback.HandleListFeedback)).Methods(http.MethodGet)
 
+	// Approvals
+	s.router.HandleFunc(APIPathApprovals, adaptHandler(s.handlers.Approvals.HandleListApprovals)).Methods(http.MethodGet)
+	s.router.HandleFunc(APIPathApprovals+"/{approvalID}/approve", adaptHandler(s.handlers.Approvals.HandleApproveToolCall)).Methods(http.MethodPost)
+	s.router.HandleFunc(APIPathApprovals+"/{approvalID}/deny", adaptHandler(s.handlers.Approvals.HandleDenyToolCall)).Methods(http.MethodPost)
+
 	// A2A
 	s.router.PathPrefix(APIPathA2A).Handler(s.config.A2AHandler)
 
+	// MCP gateway
+	if s.config.MCPGateway != nil {
+		s.router.Handle(APIPathMCP, s.config.MCPGateway)
+	}
+
 	// Use middleware for common functionality
 	s.router.Use(contentTypeMiddleware)
 	s.router.Use(loggingMiddleware)
@@ -0,0 +1,43 @@
+package mcpgateway
+
+import (
+	"context"
+	"fmt"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Authenticator authenticates the bearer token presented to the gateway
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*authenticationv1.UserInfo, error)
+}
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+
+type tokenReviewAuthenticator struct {
+	kube client.Client
+}
+
+// NewTokenReviewAuthenticator authenticates Kubernetes tokens, e.g. created with `kubectl create token`, with a TokenReview
+func NewTokenReviewAuthenticator(kube client.Client) Authenticator {
+	return &tokenReviewAuthenticator{kube: kube}
+}
+
+func (a *tokenReviewAuthenticator) Authenticate(ctx context.Context, token string) (*authenticationv1.UserInfo, error) {
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token: token,
+		},
+	}
+	if err := a.kube.Create(ctx, review); err != nil {
+		return nil, fmt.Errorf("failed to review token: %v", err)
+	}
+	if !review.Status.Authenticated {
+		if review.Status.Error != "" {
+			return nil, fmt.Errorf("token is not authenticated: %s", review.Status.Error)
+		}
+		return nil, fmt.Errorf("token is not authenticated")
+	}
+	return &review.Status.User, nil
+}
@@ -0,0 +1,381 @@
+package mcpgateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	autogen_client "github.com/kagent-dev/kagent/go/autogen/client"
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"github.com/kagent-dev/kagent/go/controller/internal/autogen"
+	common "github.com/kagent-dev/kagent/go/controller/internal/utils"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// the tools of all tool servers are exposed as <namespace>__<toolserver>__<tool>.
+	// Kubernetes names cannot contain underscores, so the name can always be split back.
+	toolNameSeparator = "__"
+
+	serverName = "kagent-mcp-gateway"
+	// the latest protocol version supported by the gateway, used when the client requests an unsupported one
+	latestProtocolVersion = "2025-03-26"
+)
+
+var supportedProtocolVersions = []string{"2024-11-05", latestProtocolVersion}
+
+// JSON-RPC error codes
+const (
+	parseErrorCode     = -32700
+	invalidRequestCode = -32600
+	methodNotFoundCode = -32601
+	invalidParamsCode  = -32602
+	internalErrorCode  = -32603
+)
+
+// Gateway is an MCP server aggregating the tools discovered on all ToolServers, and proxying calls to them.
+// It implements the stateless subset of the streamable HTTP transport: every request is a JSON-RPC POST, answered with JSON.
+type Gateway struct {
+	kube          client.Client
+	autogenClient autogen_client.Client
+	authenticator Authenticator
+}
+
+var _ http.Handler = &Gateway{}
+
+func NewGateway(kube client.Client, autogenClient autogen_client.Client, authenticator Authenticator) *Gateway {
+	return &Gateway{
+		kube:          kube,
+		autogenClient: autogenClient,
+		authenticator: authenticator,
+	}
+}
+
+type jsonRPCRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type jsonRPCResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *jsonRPCError   `json:"error,omitempty"`
+}
+
+type jsonRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type initializeParams struct {
+	ProtocolVersion string `json:"protocolVersion"`
+}
+
+type callToolParams struct {
+	Name      string                 `json:"name"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+}
+
+// Tool is a tool exposed by the gateway
+type Tool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	InputSchema json.RawMessage `json:"inputSchema"`
+}
+
+type callToolResult struct {
+	Content []map[string]interface{} `json:"content"`
+	IsError bool                     `json:"isError,omitempty"`
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		// the gateway never sends messages to the client, so there is no stream to open
+		w.Header().Set("Allow", http.MethodPost)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	user, err := g.authenticator.Authenticate(r.Context(), token)
+	if err != nil {
+		ctrllog.FromContext(r.Context()).Info("MCP gateway authentication failed", "error", err.Error())
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var req jsonRPCRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeResponse(w, &jsonRPCResponse{
+			ID:    json.RawMessage("null"),
+			Error: &jsonRPCError{Code: parseErrorCode, Message: fmt.Sprintf("failed to parse request: %v", err)},
+		})
+		return
+	}
+
+	// notifications and responses from the client need no answer
+	if len(req.ID) == 0 {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	result, rpcErr := g.handleRequest(r.Context(), user, &req)
+	writeResponse(w, &jsonRPCResponse{ID: req.ID, Result: result, Error: rpcErr})
+}
+
+func (g *Gateway) handleRequest(ctx context.Context, user *authenticationv1.UserInfo, req *jsonRPCRequest) (interface{}, *jsonRPCError) {
+	if req.JSONRPC != "2.0" {
+		return nil, &jsonRPCError{Code: invalidRequestCode, Message: "jsonrpc must be 2.0"}
+	}
+
+	switch req.Method {
+	case "initialize":
+		var params initializeParams
+		if err := unmarshalParams(req.Params, &params); err != nil {
+			return nil, err
+		}
+		protocolVersion := latestProtocolVersion
+		if slices.Contains(supportedProtocolVersions, params.ProtocolVersion) {
+			protocolVersion = params.ProtocolVersion
+		}
+		return map[string]interface{}{
+			"protocolVersion": protocolVersion,
+			"capabilities": map[string]interface{}{
+				"tools": map[string]interface{}{},
+			},
+			"serverInfo": map[string]interface{}{
+				"name":    serverName,
+				"version": "v1",
+			},
+		}, nil
+	case "ping":
+		return map[string]interface{}{}, nil
+	case "tools/list":
+		tools, err := g.ListTools(ctx, user)
+		if err != nil {
+			return nil, &jsonRPCError{Code: internalErrorCode, Message: err.Error()}
+		}
+		return map[string]interface{}{"tools": tools}, nil
+	case "tools/call":
+		var params callToolParams
+		if err := unmarshalParams(req.Params, &params); err != nil {
+			return nil, err
+		}
+		return g.callTool(ctx, user, &params)
+	default:
+		return nil, &jsonRPCError{Code: methodNotFoundCode, Message: fmt.Sprintf("method %s not found", req.Method)}
+	}
+}
+
+// ListTools returns the tools the user is allowed to use, across all namespaces
+func (g *Gateway) ListTools(ctx context.Context, user *authenticationv1.UserInfo) ([]*Tool, error) {
+	var toolServers v1alpha1.ToolServerList
+	if err := g.kube.List(ctx, &toolServers); err != nil {
+		return nil, fmt.Errorf("failed to list tool servers: %v", err)
+	}
+
+	policies := map[string]*namespacePolicy{}
+	tools := []*Tool{}
+	for _, toolServer := range toolServers.Items {
+		policy, ok := policies[toolServer.Namespace]
+		if !ok {
+			var err error
+			policy, err = g.getPolicy(ctx, toolServer.Namespace)
+			if err != nil {
+				return nil, err
+			}
+			policies[toolServer.Namespace] = policy
+		}
+		if policy == nil || !policy.allowsUser(user) {
+			continue
+		}
+
+		for _, discoveredTool := range toolServer.Status.DiscoveredTools {
+			if !policy.allowsTool(toolServer.Name, discoveredTool.Name) {
+				continue
+			}
+			// the tools which cannot be called through the gateway are not listed
+			if _, err := autogen.CheckDirectToolCall(ctx, g.kube, &toolServer, discoveredTool, policy.mode); err != nil {
+				var directCallErr *autogen.DirectToolCallError
+				if errors.As(err, &directCallErr) {
+					continue
+				}
+				return nil, err
+			}
+			tools = append(tools, newTool(toolServer.Namespace, toolServer.Name, discoveredTool))
+		}
+	}
+
+	return tools, nil
+}
+
+func (g *Gateway) callTool(ctx context.Context, user *authenticationv1.UserInfo, params *callToolParams) (interface{}, *jsonRPCError) {
+	log := ctrllog.FromContext(ctx).WithName("mcp-gateway").WithValues(
+		"user", user.Username,
+		"tool", params.Name,
+	)
+
+	// unknown tools and tools the user is not allowed to use are indistinguishable to the client
+	unknownToolErr := &jsonRPCError{Code: invalidParamsCode, Message: fmt.Sprintf("unknown tool: %s", params.Name)}
+
+	parts := strings.SplitN(params.Name, toolNameSeparator, 3)
+	if len(parts) != 3 {
+		return nil, unknownToolErr
+	}
+	namespace, toolServerName, toolName := parts[0], parts[1], parts[2]
+
+	policy, err := g.getPolicy(ctx, namespace)
+	if err != nil {
+		return nil, &jsonRPCError{Code: internalErrorCode, Message: err.Error()}
+	}
+	if policy == nil || !policy.allowsUser(user) || !policy.allowsTool(toolServerName, toolName) {
+		log.Info("MCP gateway tool call denied")
+		return nil, unknownToolErr
+	}
+
+	toolServer := &v1alpha1.ToolServer{}
+	if err := g.kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: toolServerName}, toolServer); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, unknownToolErr
+		}
+		return nil, &jsonRPCError{Code: internalErrorCode, Message: fmt.Sprintf("failed to get tool server: %v", err)}
+	}
+	discoveredToolIdx := slices.IndexFunc(toolServer.Status.DiscoveredTools, func(tool *v1alpha1.MCPTool) bool {
+		return tool.Name == toolName
+	})
+	if discoveredToolIdx < 0 {
+		return nil, unknownToolErr
+	}
+
+	discoveredTool := toolServer.Status.DiscoveredTools[discoveredToolIdx]
+	tool := newTool(namespace, toolServerName, discoveredTool)
+	if err := common.ValidateJSONSchema(tool.InputSchema, "arguments", argumentsOrEmpty(params.Arguments)); err != nil {
+		return nil, &jsonRPCError{Code: invalidParamsCode, Message: fmt.Sprintf("invalid arguments: %v", err)}
+	}
+
+	// tools requiring an approval are refused, as gateway calls cannot wait for one
+	dryRun, err := autogen.CheckDirectToolCall(ctx, g.kube, toolServer, discoveredTool, policy.mode)
+	if err != nil {
+		var directCallErr *autogen.DirectToolCallError
+		if errors.As(err, &directCallErr) {
+			log.Info("MCP gateway tool call refused", "reason", err.Error())
+			return nil, &jsonRPCError{Code: invalidParamsCode, Message: err.Error()}
+		}
+		return nil, &jsonRPCError{Code: internalErrorCode, Message: err.Error()}
+	}
+	if dryRun {
+		log.Info("MCP gateway tool call reported as a dry run", "namespace", namespace, "toolServer", toolServerName)
+		return &callToolResult{
+			Content: []map[string]interface{}{{"type": "text", "text": autogen.DryRunToolCallResult(toolName, argumentsOrEmpty(params.Arguments))}},
+		}, nil
+	}
+
+	start := time.Now()
+	result, err := g.proxyToolCall(ctx, toolServer, toolName, params.Arguments)
+	if err != nil {
+		// failing to reach the tool server is reported to the model like any other tool error
+		result = &callToolResult{
+			Content: []map[string]interface{}{{"type": "text", "text": err.Error()}},
+			IsError: true,
+		}
+	}
+
+	log.Info("MCP gateway tool call",
+		"namespace", namespace,
+		"toolServer", toolServerName,
+		"isError", result.IsError,
+		"duration", time.Since(start),
+	)
+
+	return result, nil
+}
+
+func (g *Gateway) proxyToolCall(
+	ctx context.Context,
+	toolServer *v1alpha1.ToolServer,
+	toolName string,
+	arguments map[string]interface{},
+) (*callToolResult, error) {
+	autogenToolServer, err := autogen.GetAutogenToolServer(ctx, g.kube, g.autogenClient, toolServer)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := g.autogenClient.CallTool(autogenToolServer.Id, toolName, argumentsOrEmpty(arguments), common.GetGlobalUserID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to call tool %s: %v", toolName, err)
+	}
+
+	content := result.Content
+	if content == nil {
+		content = []map[string]interface{}{}
+	}
+	return &callToolResult{Content: content, IsError: result.IsError}, nil
+}
+
+// newTool exposes a discovered tool under its namespaced name, with the description and schema of the MCP tool
+func newTool(namespace, toolServer string, discoveredTool *v1alpha1.MCPTool) *Tool {
+	tool := &Tool{
+		Name:        strings.Join([]string{namespace, toolServer, discoveredTool.Name}, toolNameSeparator),
+		Description: discoveredTool.Component.Description,
+		InputSchema: json.RawMessage(`{"type":"object"}`),
+	}
+
+	var mcpTool struct {
+		Description string          `json:"description"`
+		InputSchema json.RawMessage `json:"inputSchema"`
+	}
+	if config, ok := discoveredTool.Component.Config["tool"]; ok && json.Unmarshal(config.RawMessage, &mcpTool) == nil {
+		if mcpTool.Description != "" {
+			tool.Description = mcpTool.Description
+		}
+		if len(mcpTool.InputSchema) > 0 {
+			tool.InputSchema = mcpTool.InputSchema
+		}
+	}
+
+	return tool
+}
+
+func argumentsOrEmpty(arguments map[string]interface{}) map[string]interface{} {
+	if arguments == nil {
+		return map[string]interface{}{}
+	}
+	return arguments
+}
+
+func unmarshalParams(params json.RawMessage, v interface{}) *jsonRPCError {
+	if len(params) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return &jsonRPCError{Code: invalidParamsCode, Message: fmt.Sprintf("invalid params: %v", err)}
+	}
+	return nil
+}
+
+func writeResponse(w http.ResponseWriter, resp *jsonRPCResponse) {
+	resp.JSONRPC = "2.0"
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,328 @@
+package mcpgateway_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kagent-dev/kagent/go/autogen/api"
+	autogen_client "github.com/kagent-dev/kagent/go/autogen/client"
+	"github.com/kagent-dev/kagent/go/autogen/client/fake"
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"github.com/kagent-dev/kagent/go/controller/internal/mcpgateway"
+	common "github.com/kagent-dev/kagent/go/controller/internal/utils"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// staticAuthenticator maps tokens to users
+type staticAuthenticator map[string]*authenticationv1.UserInfo
+
+func (a staticAuthenticator) Authenticate(_ context.Context, token string) (*authenticationv1.UserInfo, error) {
+	user, ok := a[token]
+	if !ok {
+		return nil, fmt.Errorf("unknown token")
+	}
+	return user, nil
+}
+
+func newToolServer(namespace, name string, tools ...string) *v1alpha1.ToolServer {
+	toolServer := &v1alpha1.ToolServer{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+	}
+	for _, tool := range tools {
+		toolServer.Status.DiscoveredTools = append(toolServer.Status.DiscoveredTools, &v1alpha1.MCPTool{
+			Name: tool,
+			Component: v1alpha1.Component{
+				Provider: "autogen_ext.tools.mcp.SseMcpToolAdapter",
+				Config: map[string]v1alpha1.AnyType{
+					"tool": {RawMessage: json.RawMessage(fmt.Sprintf(
+						`{"name":%q,"description":"%s tool","inputSchema":{"type":"object","properties":{"name":{"type":"string"}}}}`,
+						tool, tool,
+					))},
+				},
+			},
+		})
+	}
+	return toolServer
+}
+
+func TestGateway(t *testing.T) {
+	ctx := context.Background()
+	scheme := scheme.Scheme
+	err := v1alpha1.AddToScheme(scheme)
+	require.NoError(t, err)
+
+	autogenClient := fake.NewInMemoryAutogenClient()
+	autogenToolServer, err := autogenClient.CreateToolServer(&autogen_client.ToolServer{
+		Component: api.Component{Label: "k8s-tools"},
+	}, common.GetGlobalUserID())
+	require.NoError(t, err)
+
+	k8sTools := newToolServer("team-a", "k8s-tools", "get_pods", "delete_pod")
+	k8sTools.Status.ServerID = autogenToolServer.Id
+	kubeClient := fakeclient.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			k8sTools,
+			// a tool server of another namespace with the same name, which isn't created in autogen yet
+			newToolServer("team-b", "k8s-tools", "get_pods"),
+			newToolServer("team-b", "prometheus", "query"),
+			newToolServer("team-c", "github", "create_issue"),
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: mcpgateway.PolicyConfigMapName, Namespace: "team-a"},
+				Data: map[string]string{
+					mcpgateway.AllowedToolsKey:    "# read only\nk8s-tools/get_*",
+					mcpgateway.AllowedSubjectsKey: "*",
+				},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: mcpgateway.PolicyConfigMapName, Namespace: "team-b"},
+				Data: map[string]string{
+					mcpgateway.AllowedToolsKey:    "*",
+					mcpgateway.AllowedSubjectsKey: "alice, group:sre",
+				},
+			},
+			// the tools of team-c are allowed to no one, as the policy lists no subjects
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: mcpgateway.PolicyConfigMapName, Namespace: "team-c"},
+				Data: map[string]string{
+					mcpgateway.AllowedToolsKey: "*",
+				},
+			},
+		).
+		Build()
+
+	gateway := mcpgateway.NewGateway(kubeClient, autogenClient, staticAuthenticator{
+		"alice-token": {Username: "alice"},
+		"bob-token":   {Username: "bob", Groups: []string{"dev"}},
+	})
+
+	call := func(t *testing.T, token, body string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		req := httptest.NewRequest(http.MethodPost, "/api/mcp", strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		gateway.ServeHTTP(rec, req.WithContext(ctx))
+
+		var resp map[string]interface{}
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		}
+		return rec, resp
+	}
+
+	listToolNames := func(t *testing.T, token string) []string {
+		_, resp := call(t, token, `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+		require.NotNil(t, resp["result"])
+		var names []string
+		for _, tool := range resp["result"].(map[string]interface{})["tools"].([]interface{}) {
+			names = append(names, tool.(map[string]interface{})["name"].(string))
+		}
+		return names
+	}
+
+	t.Run("should reject unauthenticated requests", func(t *testing.T) {
+		rec, _ := call(t, "", `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+		rec, _ = call(t, "invalid-token", `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("should initialize", func(t *testing.T) {
+		_, resp := call(t, "bob-token", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05"}}`)
+		result := resp["result"].(map[string]interface{})
+		assert.Equal(t, "2024-11-05", result["protocolVersion"])
+		assert.Contains(t, result["capabilities"], "tools")
+
+		rec, _ := call(t, "bob-token", `{"jsonrpc":"2.0","method":"notifications/initialized"}`)
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+	})
+
+	t.Run("should only list allowed tools", func(t *testing.T) {
+		assert.Equal(t, []string{"team-a__k8s-tools__get_pods"}, listToolNames(t, "bob-token"))
+		assert.ElementsMatch(t, []string{
+			"team-a__k8s-tools__get_pods",
+			"team-b__k8s-tools__get_pods",
+			"team-b__prometheus__query",
+		}, listToolNames(t, "alice-token"))
+	})
+
+	t.Run("should expose the schema of the tool", func(t *testing.T) {
+		_, resp := call(t, "bob-token", `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+		tool := resp["result"].(map[string]interface{})["tools"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "get_pods tool", tool["description"])
+		assert.Equal(t, "object", tool["inputSchema"].(map[string]interface{})["type"])
+	})
+
+	t.Run("should proxy calls to allowed tools", func(t *testing.T) {
+		_, resp := call(t, "bob-token", `{"jsonrpc":"2.0","id":"call-1","method":"tools/call","params":{"name":"team-a__k8s-tools__get_pods","arguments":{"name":"test"}}}`)
+		assert.Equal(t, "call-1", resp["id"])
+		require.Nil(t, resp["error"])
+		result := resp["result"].(map[string]interface{})
+		content := result["content"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, `{"name":"test"}`, content["text"])
+		assert.NotContains(t, result, "isError")
+	})
+
+	t.Run("should reject calls to tools which are not allowed", func(t *testing.T) {
+		for _, name := range []string{
+			"team-a__k8s-tools__delete_pod",
+			"team-b__prometheus__query",
+			"team-c__github__create_issue",
+			"team-a__k8s-tools__unknown",
+			"invalid",
+		} {
+			_, resp := call(t, "bob-token", fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":%q}}`, name))
+			require.NotNil(t, resp["error"], name)
+			assert.Equal(t, float64(-32602), resp["error"].(map[string]interface{})["code"], name)
+		}
+	})
+
+	t.Run("should reject arguments not matching the input schema", func(t *testing.T) {
+		_, resp := call(t, "bob-token", `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"team-a__k8s-tools__get_pods","arguments":{"name":1}}}`)
+		require.NotNil(t, resp["error"])
+		assert.Equal(t, float64(-32602), resp["error"].(map[string]interface{})["code"])
+		assert.Contains(t, resp["error"].(map[string]interface{})["message"], "arguments.name")
+	})
+
+	t.Run("should report errors of the tool server in the result", func(t *testing.T) {
+		// prometheus is allowed but was never created in autogen
+		_, resp := call(t, "alice-token", `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"team-b__prometheus__query"}}`)
+		require.Nil(t, resp["error"])
+		assert.Equal(t, true, resp["result"].(map[string]interface{})["isError"])
+	})
+
+	t.Run("should not proxy calls to the tool server of another namespace with the same name", func(t *testing.T) {
+		_, resp := call(t, "alice-token", `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"team-b__k8s-tools__get_pods","arguments":{"name":"test"}}}`)
+		require.Nil(t, resp["error"])
+		result := resp["result"].(map[string]interface{})
+		assert.Equal(t, true, result["isError"])
+		content := result["content"].([]interface{})[0].(map[string]interface{})
+		assert.Contains(t, content["text"], "team-b/k8s-tools was not created in autogen yet")
+	})
+
+	t.Run("should reject unknown methods", func(t *testing.T) {
+		_, resp := call(t, "bob-token", `{"jsonrpc":"2.0","id":1,"method":"resources/list"}`)
+		assert.Equal(t, float64(-32601), resp["error"].(map[string]interface{})["code"])
+	})
+}
+
+func TestGatewayModes(t *testing.T) {
+	ctx := context.Background()
+	scheme := scheme.Scheme
+	err := v1alpha1.AddToScheme(scheme)
+	require.NoError(t, err)
+
+	autogenClient := fake.NewInMemoryAutogenClient()
+	autogenToolServer, err := autogenClient.CreateToolServer(&autogen_client.ToolServer{
+		Component: api.Component{Label: "k8s-tools"},
+	}, common.GetGlobalUserID())
+	require.NoError(t, err)
+
+	toolServer := newToolServer("ops", "k8s-tools", "get_pods", "delete_pod", "restart_pod")
+	toolServer.Status.ServerID = autogenToolServer.Id
+	toolServer.Status.DiscoveredTools[0].Component.Config["tool"] = v1alpha1.AnyType{
+		RawMessage: json.RawMessage(`{"name":"get_pods","annotations":{"readOnlyHint":true}}`),
+	}
+	policy := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: mcpgateway.PolicyConfigMapName, Namespace: "ops"},
+		Data: map[string]string{
+			mcpgateway.AllowedToolsKey:    "*",
+			mcpgateway.AllowedSubjectsKey: "*",
+			mcpgateway.ModeKey:            string(v1alpha1.AgentMode_ReadOnly),
+		},
+	}
+	kubeClient := fakeclient.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			toolServer,
+			policy,
+			// the agents call restart_pod only once it is approved
+			&v1alpha1.Agent{
+				ObjectMeta: metav1.ObjectMeta{Name: "k8s-agent", Namespace: "ops"},
+				Spec: v1alpha1.AgentSpec{
+					Tools: []*v1alpha1.Tool{{
+						Type: v1alpha1.ToolProviderType_McpServer,
+						McpServer: &v1alpha1.McpServerTool{
+							ToolServer: "k8s-tools",
+							ToolNames:  []string{"restart_pod"},
+						},
+						RequireApproval: true,
+					}},
+				},
+			},
+		).
+		Build()
+
+	gateway := mcpgateway.NewGateway(kubeClient, autogenClient, staticAuthenticator{
+		"alice-token": {Username: "alice"},
+	})
+	user := &authenticationv1.UserInfo{Username: "alice"}
+
+	callTool := func(t *testing.T, name string) (interface{}, string) {
+		req := httptest.NewRequest(http.MethodPost, "/api/mcp", strings.NewReader(fmt.Sprintf(
+			`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":%q,"arguments":{"name":"test"}}}`, name)))
+		req.Header.Set("Authorization", "Bearer alice-token")
+		rec := httptest.NewRecorder()
+		gateway.ServeHTTP(rec, req.WithContext(ctx))
+
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		if resp["error"] != nil {
+			return nil, resp["error"].(map[string]interface{})["message"].(string)
+		}
+		result := resp["result"].(map[string]interface{})
+		return result, result["content"].([]interface{})[0].(map[string]interface{})["text"].(string)
+	}
+
+	t.Run("should only expose the read-only tools in readOnly mode", func(t *testing.T) {
+		tools, err := gateway.ListTools(ctx, user)
+		require.NoError(t, err)
+		require.Len(t, tools, 1)
+		assert.Equal(t, "ops__k8s-tools__get_pods", tools[0].Name)
+
+		result, text := callTool(t, "ops__k8s-tools__get_pods")
+		require.NotNil(t, result)
+		assert.Equal(t, `{"name":"test"}`, text)
+
+		result, text = callTool(t, "ops__k8s-tools__delete_pod")
+		assert.Nil(t, result)
+		assert.Contains(t, text, "readOnly mode")
+	})
+
+	t.Run("should report the calls of mutating tools in dryRun mode", func(t *testing.T) {
+		policy.Data[mcpgateway.ModeKey] = string(v1alpha1.AgentMode_DryRun)
+		require.NoError(t, kubeClient.Update(ctx, policy))
+
+		result, text := callTool(t, "ops__k8s-tools__delete_pod")
+		require.NotNil(t, result)
+		assert.Contains(t, text, "Dry run: delete_pod was not executed")
+	})
+
+	t.Run("should refuse the tools requiring an approval", func(t *testing.T) {
+		tools, err := gateway.ListTools(ctx, user)
+		require.NoError(t, err)
+		var names []string
+		for _, tool := range tools {
+			names = append(names, tool.Name)
+		}
+		assert.NotContains(t, names, "ops__k8s-tools__restart_pod")
+
+		result, text := callTool(t, "ops__k8s-tools__restart_pod")
+		assert.Nil(t, result)
+		assert.Contains(t, text, "requires an approval")
+	})
+}
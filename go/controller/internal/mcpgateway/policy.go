@@ -0,0 +1,114 @@
+package mcpgateway
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"github.com/kagent-dev/kagent/go/controller/internal/autogen"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// PolicyConfigMapName is the name of the ConfigMap which allows tools of a namespace to be used through the gateway.
+	// Namespaces without it expose no tools.
+	PolicyConfigMapName = "kagent-mcp-gateway"
+	// AllowedToolsKey lists glob patterns matched against <toolserver>/<tool>, e.g. k8s-tools/get_*, or * to allow all tools
+	AllowedToolsKey = "allowedTools"
+	// AllowedSubjectsKey lists the users, or groups prefixed with group:, allowed to use the tools, or * to allow
+	// any authenticated user. If empty, no one is allowed, so that the tools aren't exposed to all the identities of
+	// the cluster, e.g. every ServiceAccount, by omission.
+	AllowedSubjectsKey = "allowedSubjects"
+	// ModeKey restricts the calls like the mode of an agent: readOnly hides the mutating tools,
+	// dryRun reports their calls instead of running them. Defaults to readWrite.
+	ModeKey = "mode"
+
+	groupSubjectPrefix = "group:"
+)
+
+// namespacePolicy is the allow list of a namespace
+type namespacePolicy struct {
+	tools    []string
+	subjects []string
+	mode     v1alpha1.AgentMode
+}
+
+// getPolicy returns the allow list of the namespace, or nil if it exposes no tools
+func (g *Gateway) getPolicy(ctx context.Context, namespace string) (*namespacePolicy, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := g.kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: PolicyConfigMapName}, configMap); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get gateway policy for namespace %s: %v", namespace, err)
+	}
+
+	policy := &namespacePolicy{
+		tools:    parseList(configMap.Data[AllowedToolsKey]),
+		subjects: parseList(configMap.Data[AllowedSubjectsKey]),
+	}
+	for _, pattern := range policy.tools {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid tool pattern %q in gateway policy for namespace %s: %v", pattern, namespace, err)
+		}
+	}
+	mode, err := autogen.ParseAgentMode(strings.TrimSpace(configMap.Data[ModeKey]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid mode in gateway policy for namespace %s: %v", namespace, err)
+	}
+	policy.mode = mode
+
+	return policy, nil
+}
+
+func (p *namespacePolicy) allowsUser(user *authenticationv1.UserInfo) bool {
+	for _, subject := range p.subjects {
+		if subject == "*" {
+			return true
+		}
+		if group, ok := strings.CutPrefix(subject, groupSubjectPrefix); ok {
+			if slices.Contains(user.Groups, group) {
+				return true
+			}
+		} else if subject == user.Username {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *namespacePolicy) allowsTool(toolServer, tool string) bool {
+	name := toolServer + "/" + tool
+	for _, pattern := range p.tools {
+		if pattern == "*" {
+			return true
+		}
+		// the patterns are validated when the policy is read
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// parseList parses a list separated by newlines or commas, ignoring empty entries and comments
+func parseList(value string) []string {
+	var items []string
+	for _, line := range strings.Split(value, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		for _, item := range strings.Split(line, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
@@ -1,6 +1,11 @@
 package common
 
-import "os"
+import (
+	"os"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+)
 
 func GetResourceNamespace() string {
 	if val := os.Getenv("KAGENT_NAMESPACE"); val != "" {
@@ -20,3 +25,12 @@ func GetGlobalUserID() string {
 func MakePtr[T any](v T) *T {
 	return &v
 }
+
+// ParseRef parses a reference to a resource, which is either the name of a resource in the parent namespace,
+// or a reference to a resource in a different namespace in the form "namespace/name".
+func ParseRef(ref string, parentNamespace string) types.NamespacedName {
+	if parts := strings.Split(ref, "/"); len(parts) == 2 {
+		return types.NamespacedName{Namespace: parts[0], Name: parts[1]}
+	}
+	return types.NamespacedName{Namespace: parentNamespace, Name: ref}
+}
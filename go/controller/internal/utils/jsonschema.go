@@ -0,0 +1,25 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+)
+
+// ValidateJSONSchema validates a decoded JSON value against a JSON schema, such as the input schema of an MCP tool.
+// The name is used as the root of the paths reported in the errors.
+func ValidateJSONSchema(schema json.RawMessage, name string, value interface{}) error {
+	if len(schema) == 0 {
+		return nil
+	}
+
+	s := &spec.Schema{}
+	if err := json.Unmarshal(schema, s); err != nil {
+		return fmt.Errorf("invalid schema: %v", err)
+	}
+
+	return validate.NewSchemaValidator(s, s, name, strfmt.Default).Validate(value).AsError()
+}
@@ -0,0 +1,258 @@
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"github.com/kagent-dev/kagent/go/controller/internal/autogen"
+	"github.com/kagent-dev/kagent/go/controller/internal/builtintools"
+	common "github.com/kagent-dev/kagent/go/controller/internal/utils"
+)
+
+var agentlog = logf.Log.WithName("agent-resource")
+
+// SetupAgentWebhookWithManager registers the webhook for Agent in the manager.
+func SetupAgentWebhookWithManager(mgr ctrl.Manager, defaultModelConfig types.NamespacedName) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&v1alpha1.Agent{}).
+		WithValidator(&AgentCustomValidator{
+			Catalog:            builtintools.Default(),
+			Client:             mgr.GetClient(),
+			DefaultModelConfig: defaultModelConfig,
+		}).
+		WithDefaulter(&AgentCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-kagent-dev-v1alpha1-agent,mutating=true,failurePolicy=fail,sideEffects=None,groups=kagent.dev,resources=agents,verbs=create;update,versions=v1alpha1,name=magent-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// AgentCustomDefaulter sets the type of the tools of Agents from the provider they set.
+type AgentCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &AgentCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter.
+func (d *AgentCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	agent, ok := obj.(*v1alpha1.Agent)
+	if !ok {
+		return fmt.Errorf("expected an Agent object but got %T", obj)
+	}
+	agentlog.V(1).Info("Defaulting for Agent", "name", agent.GetName())
+
+	for _, tool := range agent.Spec.Tools {
+		if tool == nil || tool.Type != "" {
+			continue
+		}
+		if providers := toolProviders(tool); len(providers) == 1 {
+			tool.Type = providers[0]
+		}
+	}
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-kagent-dev-v1alpha1-agent,mutating=false,failurePolicy=fail,sideEffects=None,groups=kagent.dev,resources=agents,verbs=create;update,versions=v1alpha1,name=vagent-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// AgentCustomValidator validates the builtin tools of Agents against the builtin tool catalog, and the resources
+// they reference. Agents are then translated against a dry-run client: the agent tool chains which are cycles
+// or too deep are rejected, while the other translation failures are only warned about, as they may be
+// resolved once the referenced resources are reconciled, e.g. the card of a RemoteAgent is fetched.
+type AgentCustomValidator struct {
+	Catalog            *builtintools.Catalog
+	Client             client.Client
+	DefaultModelConfig types.NamespacedName
+}
+
+var _ webhook.CustomValidator = &AgentCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator.
+func (v *AgentCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	agent, ok := obj.(*v1alpha1.Agent)
+	if !ok {
+		return nil, fmt.Errorf("expected an Agent object but got %T", obj)
+	}
+	agentlog.V(1).Info("Validation for Agent upon creation", "name", agent.GetName())
+
+	return v.validateAgent(ctx, agent)
+}
+
+// ValidateUpdate implements webhook.CustomValidator.
+func (v *AgentCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	agent, ok := newObj.(*v1alpha1.Agent)
+	if !ok {
+		return nil, fmt.Errorf("expected an Agent object for the newObj but got %T", newObj)
+	}
+	oldAgent, ok := oldObj.(*v1alpha1.Agent)
+	if !ok {
+		return nil, fmt.Errorf("expected an Agent object for the oldObj but got %T", oldObj)
+	}
+	agentlog.V(1).Info("Validation for Agent upon update", "name", agent.GetName())
+
+	// the finalizer of an agent is removed even if its references are gone
+	if equality.Semantic.DeepEqual(oldAgent.Spec, agent.Spec) {
+		return nil, nil
+	}
+	return v.validateAgent(ctx, agent)
+}
+
+// ValidateDelete implements webhook.CustomValidator.
+func (v *AgentCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *AgentCustomValidator) validateAgent(ctx context.Context, agent *v1alpha1.Agent) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+	var warnings admission.Warnings
+	specPath := field.NewPath("spec")
+	toolsPath := specPath.Child("tools")
+	for i, tool := range agent.Spec.Tools {
+		if tool == nil {
+			allErrs = append(allErrs, field.Required(toolsPath.Index(i), ""))
+			continue
+		}
+		allErrs = append(allErrs, validateToolProvider(tool, toolsPath.Index(i))...)
+		if tool.Builtin == nil {
+			continue
+		}
+		_, toolWarnings, err := v.Catalog.Validate(tool.Builtin)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(toolsPath.Index(i).Child("builtin"), tool.Builtin.Name, err.Error()))
+		}
+		for _, warning := range toolWarnings {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", toolsPath.Index(i).Child("builtin"), warning))
+		}
+	}
+	// the references are only checked once the agent is well-formed
+	if len(allErrs) > 0 {
+		return warnings, invalid("Agent", agent.Name, allErrs)
+	}
+
+	allErrs = append(allErrs, v.validateAgentReferences(ctx, agent)...)
+	if len(allErrs) > 0 {
+		return warnings, invalid("Agent", agent.Name, allErrs)
+	}
+
+	translator, err := dryRunTranslator(v.Client, v.DefaultModelConfig, agent)
+	if err != nil {
+		return warnings, err
+	}
+	_, err = translator.TranslateGroupChatForAgent(ctx, agent)
+	var chainErr *autogen.AgentToolChainError
+	switch {
+	case errors.As(err, &chainErr):
+		return warnings, invalid("Agent", agent.Name, field.ErrorList{
+			field.Invalid(agentToolChainPath(agent, chainErr, toolsPath), agent.Name, chainErr.Error()),
+		})
+	case err != nil:
+		return append(warnings, fmt.Sprintf("the agent cannot be translated yet: %v", err)), nil
+	}
+	return warnings, nil
+}
+
+func (v *AgentCustomValidator) validateAgentReferences(ctx context.Context, agent *v1alpha1.Agent) field.ErrorList {
+	var allErrs field.ErrorList
+	refs := referenceValidator{kube: v.Client}
+	specPath := field.NewPath("spec")
+	if agent.Spec.ModelConfig != "" {
+		if err := refs.validate(ctx, &v1alpha1.ModelConfig{}, specPath.Child("modelConfig"), agent.Spec.ModelConfig, agent.Namespace); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+	for i, memory := range agent.Spec.Memory {
+		if err := refs.validate(ctx, &v1alpha1.Memory{}, specPath.Child("memory").Index(i), memory, agent.Namespace); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+	for i, tool := range agent.Spec.Tools {
+		toolPath := specPath.Child("tools").Index(i)
+		var err *field.Error
+		switch {
+		case tool.McpServer != nil:
+			err = refs.validate(ctx, &v1alpha1.ToolServer{}, toolPath.Child("mcpServer", "toolServer"), tool.McpServer.ToolServer, agent.Namespace)
+		case tool.Http != nil:
+			err = refs.validate(ctx, &v1alpha1.HttpToolSet{}, toolPath.Child("http", "toolSet"), tool.Http.ToolSet, agent.Namespace)
+		case tool.RemoteAgent != nil:
+			err = refs.validate(ctx, &v1alpha1.RemoteAgent{}, toolPath.Child("remoteAgent", "ref"), tool.RemoteAgent.Ref, agent.Namespace)
+		case tool.Agent != nil:
+			if common.ParseRef(tool.Agent.Ref, agent.Namespace) == (types.NamespacedName{Namespace: agent.Namespace, Name: agent.Name}) {
+				err = field.Invalid(toolPath.Child("agent", "ref"), tool.Agent.Ref, "an agent cannot use itself as a tool")
+			} else {
+				err = refs.validate(ctx, &v1alpha1.Agent{}, toolPath.Child("agent", "ref"), tool.Agent.Ref, agent.Namespace)
+			}
+		}
+		if err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+	for i, mcpContext := range agent.Spec.McpContext {
+		if err := refs.validate(ctx, &v1alpha1.ToolServer{}, specPath.Child("mcpContext").Index(i).Child("toolServer"), mcpContext.ToolServer, agent.Namespace); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+	return allErrs
+}
+
+// toolProviders returns the types of the providers set on a tool
+func toolProviders(tool *v1alpha1.Tool) []v1alpha1.ToolProviderType {
+	var providers []v1alpha1.ToolProviderType
+	if tool.Builtin != nil {
+		providers = append(providers, v1alpha1.ToolProviderType_Builtin)
+	}
+	if tool.McpServer != nil {
+		providers = append(providers, v1alpha1.ToolProviderType_McpServer)
+	}
+	if tool.Agent != nil {
+		providers = append(providers, v1alpha1.ToolProviderType_Agent)
+	}
+	if tool.Http != nil {
+		providers = append(providers, v1alpha1.ToolProviderType_Http)
+	}
+	if tool.RemoteAgent != nil {
+		providers = append(providers, v1alpha1.ToolProviderType_RemoteAgent)
+	}
+	return providers
+}
+
+// validateToolProvider checks that exactly one provider is set on a tool, and that it matches the type of the tool
+func validateToolProvider(tool *v1alpha1.Tool, path *field.Path) field.ErrorList {
+	providers := toolProviders(tool)
+	switch {
+	case len(providers) == 0:
+		return field.ErrorList{field.Required(path, "one of builtin, mcpServer, agent, http or remoteAgent must be set")}
+	case len(providers) > 1:
+		return field.ErrorList{field.Invalid(path, providers, "only one of builtin, mcpServer, agent, http or remoteAgent may be set")}
+	case tool.Type != "" && tool.Type != providers[0]:
+		return field.ErrorList{field.Invalid(path.Child("type"), tool.Type, fmt.Sprintf("the tool sets the %s provider", providers[0]))}
+	}
+	return nil
+}
+
+// agentToolChainPath returns the path of the agent tool starting the chain, or the path of the tools if not found.
+// The chain lists the agent itself, followed by the agent it uses as a tool.
+func agentToolChainPath(agent *v1alpha1.Agent, chainErr *autogen.AgentToolChainError, toolsPath *field.Path) *field.Path {
+	self := fmt.Sprintf("Agent %s/%s", agent.Namespace, agent.Name)
+	for i, link := range chainErr.Chain {
+		if link != self || i+1 >= len(chainErr.Chain) {
+			continue
+		}
+		next := strings.TrimPrefix(chainErr.Chain[i+1], "Agent ")
+		for j, tool := range agent.Spec.Tools {
+			if tool.Agent != nil && common.ParseRef(tool.Agent.Ref, agent.Namespace).String() == next {
+				return toolsPath.Index(j).Child("agent", "ref")
+			}
+		}
+		break
+	}
+	return toolsPath
+}
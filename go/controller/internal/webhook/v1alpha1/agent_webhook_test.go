@@ -0,0 +1,177 @@
+package v1alpha1_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"github.com/kagent-dev/kagent/go/controller/internal/builtintools"
+	webhookv1alpha1 "github.com/kagent-dev/kagent/go/controller/internal/webhook/v1alpha1"
+)
+
+const namespace = "test"
+
+var defaultModelConfig = types.NamespacedName{Namespace: namespace, Name: "default-model"}
+
+// newKubeClient returns a client serving the default model config and its secret, in addition to the objects
+func newKubeClient(t *testing.T, objects ...client.Object) client.Client {
+	require.NoError(t, v1alpha1.AddToScheme(scheme.Scheme))
+	objects = append(objects,
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "openai-secret", Namespace: namespace},
+			Data:       map[string][]byte{"api-key": []byte("sk-test")},
+		},
+		&v1alpha1.ModelConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: defaultModelConfig.Name, Namespace: namespace},
+			Spec: v1alpha1.ModelConfigSpec{
+				Provider:        v1alpha1.OpenAI,
+				Model:           "gpt-4o",
+				APIKeySecretRef: "openai-secret",
+				APIKeySecretKey: "api-key",
+			},
+		},
+	)
+	return fakeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objects...).Build()
+}
+
+func TestAgentValidator(t *testing.T) {
+	newValidator := func(objects ...client.Object) *webhookv1alpha1.AgentCustomValidator {
+		return &webhookv1alpha1.AgentCustomValidator{
+			Catalog:            builtintools.Default(),
+			Client:             newKubeClient(t, objects...),
+			DefaultModelConfig: defaultModelConfig,
+		}
+	}
+	validator := newValidator()
+	agent := func(toolNames ...string) *v1alpha1.Agent {
+		agent := &v1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{Name: "test-agent", Namespace: namespace}}
+		for _, name := range toolNames {
+			agent.Spec.Tools = append(agent.Spec.Tools, &v1alpha1.Tool{
+				Type:    v1alpha1.ToolProviderType_Builtin,
+				Builtin: &v1alpha1.BuiltinTool{Name: name},
+			})
+		}
+		return agent
+	}
+
+	t.Run("ValidTools", func(t *testing.T) {
+		warnings, err := validator.ValidateCreate(context.Background(), agent("kagent.tools.k8s.GetResources", "k8s.get_pod_logs"))
+		assert.NoError(t, err)
+		assert.Empty(t, warnings)
+	})
+
+	t.Run("UnknownTool", func(t *testing.T) {
+		_, err := validator.ValidateUpdate(context.Background(), agent(), agent("kagent.tools.k8s.GetResources", "kagent.tools.k8s.GetPods"))
+		require.Error(t, err)
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.Contains(t, err.Error(), "spec.tools[1].builtin")
+	})
+
+	t.Run("UnknownConfigKey", func(t *testing.T) {
+		legacyAgent := agent("kagent.tools.docs.QueryTool")
+		legacyAgent.Spec.Tools[0].Builtin.Config = map[string]v1alpha1.AnyType{
+			"max_results": {RawMessage: json.RawMessage("5")},
+		}
+		warnings, err := validator.ValidateCreate(context.Background(), legacyAgent)
+		require.NoError(t, err)
+		require.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], "spec.tools[0].builtin")
+		assert.Contains(t, warnings[0], "max_results")
+	})
+
+	t.Run("MismatchedToolType", func(t *testing.T) {
+		invalidAgent := agent("kagent.tools.k8s.GetResources")
+		invalidAgent.Spec.Tools[0].Type = v1alpha1.ToolProviderType_McpServer
+		_, err := validator.ValidateCreate(context.Background(), invalidAgent)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec.tools[0].type")
+	})
+
+	t.Run("MissingReferences", func(t *testing.T) {
+		invalidAgent := agent("kagent.tools.k8s.GetResources")
+		invalidAgent.Spec.ModelConfig = "missing-model"
+		invalidAgent.Spec.Tools = append(invalidAgent.Spec.Tools, &v1alpha1.Tool{
+			Type:      v1alpha1.ToolProviderType_McpServer,
+			McpServer: &v1alpha1.McpServerTool{ToolServer: "other/missing-server"},
+		})
+		_, err := validator.ValidateCreate(context.Background(), invalidAgent)
+		require.Error(t, err)
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.Contains(t, err.Error(), "spec.modelConfig")
+		assert.Contains(t, err.Error(), "spec.tools[1].mcpServer.toolServer")
+	})
+
+	t.Run("ToolCycle", func(t *testing.T) {
+		validator := newValidator(&v1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "helper-agent", Namespace: namespace},
+			Spec: v1alpha1.AgentSpec{Tools: []*v1alpha1.Tool{{
+				Type:  v1alpha1.ToolProviderType_Agent,
+				Agent: &v1alpha1.AgentTool{Ref: "test-agent"},
+			}}},
+		})
+		cyclicAgent := agent("kagent.tools.k8s.GetResources")
+		cyclicAgent.Spec.Tools = append(cyclicAgent.Spec.Tools, &v1alpha1.Tool{
+			Type:  v1alpha1.ToolProviderType_Agent,
+			Agent: &v1alpha1.AgentTool{Ref: "helper-agent"},
+		})
+		_, err := validator.ValidateCreate(context.Background(), cyclicAgent)
+		require.Error(t, err)
+		assert.True(t, apierrors.IsInvalid(err))
+		assert.Contains(t, err.Error(), "spec.tools[1].agent.ref")
+	})
+
+	t.Run("NoOpenAPIDownload", func(t *testing.T) {
+		var downloads atomic.Int32
+		documentServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			downloads.Add(1)
+			w.Write([]byte(`{"openapi": "3.0.0", "paths": {}}`))
+		}))
+		defer documentServer.Close()
+
+		validator := newValidator(&v1alpha1.HttpToolSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "pets", Namespace: namespace},
+			Spec:       v1alpha1.HttpToolSetSpec{OpenAPI: v1alpha1.OpenAPISource{URL: documentServer.URL}},
+		})
+		httpAgent := agent()
+		httpAgent.Spec.Tools = append(httpAgent.Spec.Tools, &v1alpha1.Tool{
+			Type: v1alpha1.ToolProviderType_Http,
+			Http: &v1alpha1.HttpTool{ToolSet: "pets"},
+		})
+		warnings, err := validator.ValidateCreate(context.Background(), httpAgent)
+		require.NoError(t, err)
+		require.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], "was not downloaded yet")
+		assert.Zero(t, downloads.Load())
+	})
+
+	t.Run("UnchangedSpec", func(t *testing.T) {
+		orphanedAgent := agent("kagent.tools.k8s.GetResources")
+		orphanedAgent.Spec.ModelConfig = "deleted-model"
+		_, err := validator.ValidateUpdate(context.Background(), orphanedAgent, orphanedAgent.DeepCopy())
+		assert.NoError(t, err)
+	})
+}
+
+func TestAgentDefaulter(t *testing.T) {
+	agent := &v1alpha1.Agent{Spec: v1alpha1.AgentSpec{Tools: []*v1alpha1.Tool{
+		{McpServer: &v1alpha1.McpServerTool{ToolServer: "server"}},
+		{Agent: &v1alpha1.AgentTool{Ref: "helper-agent"}},
+	}}}
+	require.NoError(t, (&webhookv1alpha1.AgentCustomDefaulter{}).Default(context.Background(), agent))
+	assert.Equal(t, v1alpha1.ToolProviderType_McpServer, agent.Spec.Tools[0].Type)
+	assert.Equal(t, v1alpha1.ToolProviderType_Agent, agent.Spec.Tools[1].Type)
+}
@@ -0,0 +1,138 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+)
+
+var memorylog = logf.Log.WithName("memory-resource")
+
+// SetupMemoryWebhookWithManager registers the webhook for Memory in the manager.
+func SetupMemoryWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&v1alpha1.Memory{}).
+		WithValidator(&MemoryCustomValidator{Client: mgr.GetClient()}).
+		WithDefaulter(&MemoryCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-kagent-dev-v1alpha1-memory,mutating=true,failurePolicy=fail,sideEffects=None,groups=kagent.dev,resources=memories,verbs=create;update,versions=v1alpha1,name=mmemory-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// MemoryCustomDefaulter sets the score threshold of Pinecone memories which don't set one, as the translator
+// can't parse an empty one.
+type MemoryCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &MemoryCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter.
+func (d *MemoryCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	memory, ok := obj.(*v1alpha1.Memory)
+	if !ok {
+		return fmt.Errorf("expected a Memory object but got %T", obj)
+	}
+	memorylog.V(1).Info("Defaulting for Memory", "name", memory.GetName())
+
+	if memory.Spec.Pinecone != nil && memory.Spec.Pinecone.ScoreThreshold == "" {
+		memory.Spec.Pinecone.ScoreThreshold = "0"
+	}
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-kagent-dev-v1alpha1-memory,mutating=false,failurePolicy=fail,sideEffects=None,groups=kagent.dev,resources=memories,verbs=create;update,versions=v1alpha1,name=vmemory-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// MemoryCustomValidator validates the provider config of Memories, and the secret of their API key.
+type MemoryCustomValidator struct {
+	Client client.Client
+}
+
+var _ webhook.CustomValidator = &MemoryCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator.
+func (v *MemoryCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	memory, ok := obj.(*v1alpha1.Memory)
+	if !ok {
+		return nil, fmt.Errorf("expected a Memory object but got %T", obj)
+	}
+	memorylog.V(1).Info("Validation for Memory upon creation", "name", memory.GetName())
+
+	return nil, v.validateMemory(ctx, memory)
+}
+
+// ValidateUpdate implements webhook.CustomValidator.
+func (v *MemoryCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	memory, ok := newObj.(*v1alpha1.Memory)
+	if !ok {
+		return nil, fmt.Errorf("expected a Memory object for the newObj but got %T", newObj)
+	}
+	oldMemory, ok := oldObj.(*v1alpha1.Memory)
+	if !ok {
+		return nil, fmt.Errorf("expected a Memory object for the oldObj but got %T", oldObj)
+	}
+	memorylog.V(1).Info("Validation for Memory upon update", "name", memory.GetName())
+
+	// the metadata of a memory is updated even if its secret is gone
+	if equality.Semantic.DeepEqual(oldMemory.Spec, memory.Spec) {
+		return nil, nil
+	}
+	return nil, v.validateMemory(ctx, memory)
+}
+
+// ValidateDelete implements webhook.CustomValidator.
+func (v *MemoryCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *MemoryCustomValidator) validateMemory(ctx context.Context, memory *v1alpha1.Memory) error {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+	switch memory.Spec.Provider {
+	case v1alpha1.Pinecone:
+		pineconePath := specPath.Child("pinecone")
+		pinecone := memory.Spec.Pinecone
+		if pinecone == nil {
+			allErrs = append(allErrs, field.Required(pineconePath, "the Pinecone provider needs its config"))
+			break
+		}
+		if pinecone.IndexHost == "" {
+			allErrs = append(allErrs, field.Required(pineconePath.Child("indexHost"), ""))
+		}
+		if pinecone.TopK < 0 {
+			allErrs = append(allErrs, field.Invalid(pineconePath.Child("topK"), pinecone.TopK, "must not be negative"))
+		}
+		if _, err := strconv.ParseFloat(pinecone.ScoreThreshold, 32); err != nil {
+			allErrs = append(allErrs, field.Invalid(pineconePath.Child("scoreThreshold"), pinecone.ScoreThreshold, "must be a number"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("provider"), memory.Spec.Provider, []string{string(v1alpha1.Pinecone)}))
+	}
+	if memory.Spec.APIKeySecretRef == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("apiKeySecretRef"), ""))
+	}
+	if len(allErrs) > 0 {
+		return invalid("Memory", memory.Name, allErrs)
+	}
+
+	secret := &corev1.Secret{}
+	refs := referenceValidator{kube: v.Client}
+	if err := refs.validate(ctx, secret, specPath.Child("apiKeySecretRef"), memory.Spec.APIKeySecretRef, memory.Namespace); err != nil {
+		return invalid("Memory", memory.Name, field.ErrorList{err})
+	}
+	if _, ok := secret.Data[memory.Spec.APIKeySecretKey]; !ok {
+		return invalid("Memory", memory.Name, field.ErrorList{
+			field.NotFound(specPath.Child("apiKeySecretKey"), memory.Spec.APIKeySecretKey),
+		})
+	}
+	return nil
+}
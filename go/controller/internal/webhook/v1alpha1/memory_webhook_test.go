@@ -0,0 +1,65 @@
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	webhookv1alpha1 "github.com/kagent-dev/kagent/go/controller/internal/webhook/v1alpha1"
+)
+
+func TestMemoryValidator(t *testing.T) {
+	validator := &webhookv1alpha1.MemoryCustomValidator{Client: newKubeClient(t)}
+	memory := func(pinecone *v1alpha1.PineconeConfig) *v1alpha1.Memory {
+		return &v1alpha1.Memory{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-memory", Namespace: namespace},
+			Spec: v1alpha1.MemorySpec{
+				Provider:        v1alpha1.Pinecone,
+				APIKeySecretRef: "openai-secret",
+				APIKeySecretKey: "api-key",
+				Pinecone:        pinecone,
+			},
+		}
+	}
+
+	t.Run("ValidMemory", func(t *testing.T) {
+		_, err := validator.ValidateCreate(context.Background(), memory(&v1alpha1.PineconeConfig{IndexHost: "https://index.pinecone.io", ScoreThreshold: "0.5"}))
+		assert.NoError(t, err)
+	})
+
+	tests := []struct {
+		name      string
+		memory    *v1alpha1.Memory
+		errorPath string
+	}{
+		{
+			name:      "NoPineconeConfig",
+			memory:    memory(nil),
+			errorPath: "spec.pinecone",
+		},
+		{
+			name:      "InvalidScoreThreshold",
+			memory:    memory(&v1alpha1.PineconeConfig{IndexHost: "https://index.pinecone.io", ScoreThreshold: "high"}),
+			errorPath: "spec.pinecone.scoreThreshold",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validator.ValidateCreate(context.Background(), tt.memory)
+			require.Error(t, err)
+			assert.True(t, apierrors.IsInvalid(err))
+			assert.Contains(t, err.Error(), tt.errorPath)
+		})
+	}
+}
+
+func TestMemoryDefaulter(t *testing.T) {
+	memory := &v1alpha1.Memory{Spec: v1alpha1.MemorySpec{Pinecone: &v1alpha1.PineconeConfig{IndexHost: "https://index.pinecone.io"}}}
+	require.NoError(t, (&webhookv1alpha1.MemoryCustomDefaulter{}).Default(context.Background(), memory))
+	assert.Equal(t, "0", memory.Spec.Pinecone.ScoreThreshold)
+}
@@ -0,0 +1,148 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+)
+
+var modelconfiglog = logf.Log.WithName("modelconfig-resource")
+
+// SetupModelConfigWebhookWithManager registers the webhook for ModelConfig in the manager.
+func SetupModelConfigWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&v1alpha1.ModelConfig{}).
+		WithValidator(&ModelConfigCustomValidator{Client: mgr.GetClient()}).
+		WithDefaulter(&ModelConfigCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-kagent-dev-v1alpha1-modelconfig,mutating=true,failurePolicy=fail,sideEffects=None,groups=kagent.dev,resources=modelconfigs,verbs=create;update,versions=v1alpha1,name=mmodelconfig-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ModelConfigCustomDefaulter defaults the key of the API key of ModelConfigs to <PROVIDER>_API_KEY,
+// the key of the secrets created by the helm chart.
+type ModelConfigCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &ModelConfigCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter.
+func (d *ModelConfigCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	modelConfig, ok := obj.(*v1alpha1.ModelConfig)
+	if !ok {
+		return fmt.Errorf("expected a ModelConfig object but got %T", obj)
+	}
+	modelconfiglog.V(1).Info("Defaulting for ModelConfig", "name", modelConfig.GetName())
+
+	if modelConfig.Spec.APIKeySecretRef != "" && modelConfig.Spec.APIKeySecretKey == "" {
+		modelConfig.Spec.APIKeySecretKey = strings.ToUpper(string(modelConfig.Spec.Provider)) + "_API_KEY"
+	}
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-kagent-dev-v1alpha1-modelconfig,mutating=false,failurePolicy=fail,sideEffects=None,groups=kagent.dev,resources=modelconfigs,verbs=create;update,versions=v1alpha1,name=vmodelconfig-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ModelConfigCustomValidator validates the provider config of ModelConfigs, and the secret of their API key.
+type ModelConfigCustomValidator struct {
+	Client client.Client
+}
+
+var _ webhook.CustomValidator = &ModelConfigCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator.
+func (v *ModelConfigCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	modelConfig, ok := obj.(*v1alpha1.ModelConfig)
+	if !ok {
+		return nil, fmt.Errorf("expected a ModelConfig object but got %T", obj)
+	}
+	modelconfiglog.V(1).Info("Validation for ModelConfig upon creation", "name", modelConfig.GetName())
+
+	return nil, v.validateModelConfig(ctx, modelConfig)
+}
+
+// ValidateUpdate implements webhook.CustomValidator.
+func (v *ModelConfigCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	modelConfig, ok := newObj.(*v1alpha1.ModelConfig)
+	if !ok {
+		return nil, fmt.Errorf("expected a ModelConfig object for the newObj but got %T", newObj)
+	}
+	oldModelConfig, ok := oldObj.(*v1alpha1.ModelConfig)
+	if !ok {
+		return nil, fmt.Errorf("expected a ModelConfig object for the oldObj but got %T", oldObj)
+	}
+	modelconfiglog.V(1).Info("Validation for ModelConfig upon update", "name", modelConfig.GetName())
+
+	// the metadata of a model config is updated even if its secret is gone
+	if equality.Semantic.DeepEqual(oldModelConfig.Spec, modelConfig.Spec) {
+		return nil, nil
+	}
+	return nil, v.validateModelConfig(ctx, modelConfig)
+}
+
+// ValidateDelete implements webhook.CustomValidator.
+func (v *ModelConfigCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *ModelConfigCustomValidator) validateModelConfig(ctx context.Context, modelConfig *v1alpha1.ModelConfig) error {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+	if modelConfig.Spec.Model == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("model"), ""))
+	}
+
+	// the Vertex AI providers authenticate with the credentials in the secret, and need their project
+	var vertexAIConfig *v1alpha1.BaseVertexAIConfig
+	var vertexAIPath *field.Path
+	switch modelConfig.Spec.Provider {
+	case v1alpha1.GeminiVertexAI:
+		vertexAIPath = specPath.Child("geminiVertexAI")
+		if modelConfig.Spec.GeminiVertexAI != nil {
+			vertexAIConfig = &modelConfig.Spec.GeminiVertexAI.BaseVertexAIConfig
+		}
+	case v1alpha1.AnthropicVertexAI:
+		vertexAIPath = specPath.Child("anthropicVertexAI")
+		if modelConfig.Spec.AnthropicVertexAI != nil {
+			vertexAIConfig = &modelConfig.Spec.AnthropicVertexAI.BaseVertexAIConfig
+		}
+	}
+	if vertexAIPath != nil {
+		switch {
+		case vertexAIConfig == nil:
+			allErrs = append(allErrs, field.Required(vertexAIPath, fmt.Sprintf("the %s provider needs its config", modelConfig.Spec.Provider)))
+		case vertexAIConfig.ProjectID == "":
+			allErrs = append(allErrs, field.Required(vertexAIPath.Child("projectID"), ""))
+		}
+		if modelConfig.Spec.APIKeySecretRef == "" {
+			allErrs = append(allErrs, field.Required(specPath.Child("apiKeySecretRef"), "the secret of the Google application credentials is needed"))
+		}
+	}
+	if len(allErrs) > 0 {
+		return invalid("ModelConfig", modelConfig.Name, allErrs)
+	}
+
+	if modelConfig.Spec.APIKeySecretRef == "" {
+		return nil
+	}
+	secret := &corev1.Secret{}
+	refs := referenceValidator{kube: v.Client}
+	if err := refs.validate(ctx, secret, specPath.Child("apiKeySecretRef"), modelConfig.Spec.APIKeySecretRef, modelConfig.Namespace); err != nil {
+		return invalid("ModelConfig", modelConfig.Name, field.ErrorList{err})
+	}
+	if _, ok := secret.Data[modelConfig.Spec.APIKeySecretKey]; !ok {
+		return invalid("ModelConfig", modelConfig.Name, field.ErrorList{
+			field.NotFound(specPath.Child("apiKeySecretKey"), modelConfig.Spec.APIKeySecretKey),
+		})
+	}
+	return nil
+}
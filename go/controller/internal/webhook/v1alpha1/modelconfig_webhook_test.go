@@ -0,0 +1,70 @@
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	webhookv1alpha1 "github.com/kagent-dev/kagent/go/controller/internal/webhook/v1alpha1"
+)
+
+func TestModelConfigValidator(t *testing.T) {
+	validator := &webhookv1alpha1.ModelConfigCustomValidator{Client: newKubeClient(t)}
+	modelConfig := func(spec v1alpha1.ModelConfigSpec) *v1alpha1.ModelConfig {
+		return &v1alpha1.ModelConfig{ObjectMeta: metav1.ObjectMeta{Name: "test-model", Namespace: namespace}, Spec: spec}
+	}
+
+	t.Run("ValidModelConfig", func(t *testing.T) {
+		_, err := validator.ValidateCreate(context.Background(), modelConfig(v1alpha1.ModelConfigSpec{
+			Provider: v1alpha1.OpenAI, Model: "gpt-4o", APIKeySecretRef: "openai-secret", APIKeySecretKey: "api-key",
+		}))
+		assert.NoError(t, err)
+	})
+
+	tests := []struct {
+		name        string
+		modelConfig *v1alpha1.ModelConfig
+		errorPath   string
+	}{
+		{
+			name: "MissingSecret",
+			modelConfig: modelConfig(v1alpha1.ModelConfigSpec{
+				Provider: v1alpha1.OpenAI, Model: "gpt-4o", APIKeySecretRef: "missing-secret", APIKeySecretKey: "api-key",
+			}),
+			errorPath: "spec.apiKeySecretRef",
+		},
+		{
+			name: "MissingSecretKey",
+			modelConfig: modelConfig(v1alpha1.ModelConfigSpec{
+				Provider: v1alpha1.OpenAI, Model: "gpt-4o", APIKeySecretRef: "openai-secret", APIKeySecretKey: "OPENAI_API_KEY",
+			}),
+			errorPath: "spec.apiKeySecretKey",
+		},
+		{
+			name: "MissingVertexAIConfig",
+			modelConfig: modelConfig(v1alpha1.ModelConfigSpec{
+				Provider: v1alpha1.GeminiVertexAI, Model: "gemini-2.0-flash", APIKeySecretRef: "openai-secret", APIKeySecretKey: "api-key",
+			}),
+			errorPath: "spec.geminiVertexAI",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validator.ValidateCreate(context.Background(), tt.modelConfig)
+			require.Error(t, err)
+			assert.True(t, apierrors.IsInvalid(err))
+			assert.Contains(t, err.Error(), tt.errorPath)
+		})
+	}
+}
+
+func TestModelConfigDefaulter(t *testing.T) {
+	modelConfig := &v1alpha1.ModelConfig{Spec: v1alpha1.ModelConfigSpec{Provider: v1alpha1.Anthropic, APIKeySecretRef: "kagent-anthropic"}}
+	require.NoError(t, (&webhookv1alpha1.ModelConfigCustomDefaulter{}).Default(context.Background(), modelConfig))
+	assert.Equal(t, "ANTHROPIC_API_KEY", modelConfig.Spec.APIKeySecretKey)
+}
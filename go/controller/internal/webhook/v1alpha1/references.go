@@ -0,0 +1,82 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"github.com/kagent-dev/kagent/go/controller/internal/autogen"
+	"github.com/kagent-dev/kagent/go/controller/internal/client_wrapper"
+	common "github.com/kagent-dev/kagent/go/controller/internal/utils"
+)
+
+// referenceValidator reports the resources referenced by the validated resource which don't exist
+type referenceValidator struct {
+	kube client.Client
+}
+
+// validate gets the resource referenced at the path, and returns an error if it doesn't exist
+func (r referenceValidator) validate(ctx context.Context, obj client.Object, path *field.Path, ref string, namespace string) *field.Error {
+	if ref == "" {
+		return field.Required(path, "")
+	}
+	if err := r.kube.Get(ctx, common.ParseRef(ref, namespace), obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return field.NotFound(path, ref)
+		}
+		return field.InternalError(path, err)
+	}
+	return nil
+}
+
+// validateValueSource checks that the key of the ConfigMap or Secret a value is read from exists
+func (r referenceValidator) validateValueSource(ctx context.Context, source *v1alpha1.ValueSource, path *field.Path, namespace string) *field.Error {
+	var found bool
+	switch source.Type {
+	case v1alpha1.ConfigMapValueSource:
+		configMap := &corev1.ConfigMap{}
+		if err := r.validate(ctx, configMap, path.Child("valueRef"), source.ValueRef, namespace); err != nil {
+			return err
+		}
+		_, found = configMap.Data[source.Key]
+	case v1alpha1.SecretValueSource:
+		secret := &corev1.Secret{}
+		if err := r.validate(ctx, secret, path.Child("valueRef"), source.ValueRef, namespace); err != nil {
+			return err
+		}
+		_, found = secret.Data[source.Key]
+	default:
+		return field.NotSupported(path.Child("type"), source.Type, []string{string(v1alpha1.ConfigMapValueSource), string(v1alpha1.SecretValueSource)})
+	}
+	if !found {
+		return field.NotFound(path.Child("key"), source.Key)
+	}
+	return nil
+}
+
+// dryRunTranslator returns a translator seeing the validated resources as if they were admitted,
+// which doesn't persist anything it creates and skips the calls to remote systems, e.g. OpenAPI downloads
+func dryRunTranslator(kube client.Client, defaultModelConfig types.NamespacedName, objs ...client.Object) (autogen.ApiTranslator, error) {
+	kubeClientWrapper := client_wrapper.NewKubeClientWrapper(client.NewDryRunClient(kube))
+	for _, obj := range objs {
+		if err := kubeClientWrapper.AddInMemory(obj); err != nil {
+			return nil, fmt.Errorf("failed to add %s/%s to the dry-run client: %v", obj.GetNamespace(), obj.GetName(), err)
+		}
+	}
+	return autogen.NewAutogenApiTranslator(kubeClientWrapper, defaultModelConfig, autogen.WithoutRemoteCalls()), nil
+}
+
+// invalid returns the error rejecting a resource, or nil if there are no errors
+func invalid(kind string, name string, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(schema.GroupKind{Group: v1alpha1.GroupVersion.Group, Kind: kind}, name, errs)
+}
@@ -0,0 +1,218 @@
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	"github.com/kagent-dev/kagent/go/controller/internal/autogen"
+)
+
+var teamlog = logf.Log.WithName("team-resource")
+
+// SetupTeamWebhookWithManager registers the webhook for Team in the manager.
+func SetupTeamWebhookWithManager(mgr ctrl.Manager, defaultModelConfig types.NamespacedName) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&v1alpha1.Team{}).
+		WithValidator(&TeamCustomValidator{
+			Client:             mgr.GetClient(),
+			DefaultModelConfig: defaultModelConfig,
+		}).
+		WithDefaulter(&TeamCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-kagent-dev-v1alpha1-team,mutating=true,failurePolicy=fail,sideEffects=None,groups=kagent.dev,resources=teams,verbs=create;update,versions=v1alpha1,name=mteam-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// TeamCustomDefaulter makes Teams without a team config round robin teams.
+type TeamCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &TeamCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter.
+func (d *TeamCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	team, ok := obj.(*v1alpha1.Team)
+	if !ok {
+		return fmt.Errorf("expected a Team object but got %T", obj)
+	}
+	teamlog.V(1).Info("Defaulting for Team", "name", team.GetName())
+
+	if len(teamConfigs(team)) == 0 {
+		team.Spec.RoundRobinTeamConfig = &v1alpha1.RoundRobinTeamConfig{}
+	}
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-kagent-dev-v1alpha1-team,mutating=false,failurePolicy=fail,sideEffects=None,groups=kagent.dev,resources=teams,verbs=create;update,versions=v1alpha1,name=vteam-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// TeamCustomValidator validates the team config and termination condition of Teams, and the resources they
+// reference. Teams are then translated against a dry-run client like Agents are.
+type TeamCustomValidator struct {
+	Client             client.Client
+	DefaultModelConfig types.NamespacedName
+}
+
+var _ webhook.CustomValidator = &TeamCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator.
+func (v *TeamCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	team, ok := obj.(*v1alpha1.Team)
+	if !ok {
+		return nil, fmt.Errorf("expected a Team object but got %T", obj)
+	}
+	teamlog.V(1).Info("Validation for Team upon creation", "name", team.GetName())
+
+	return v.validateTeam(ctx, team)
+}
+
+// ValidateUpdate implements webhook.CustomValidator.
+func (v *TeamCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	team, ok := newObj.(*v1alpha1.Team)
+	if !ok {
+		return nil, fmt.Errorf("expected a Team object for the newObj but got %T", newObj)
+	}
+	oldTeam, ok := oldObj.(*v1alpha1.Team)
+	if !ok {
+		return nil, fmt.Errorf("expected a Team object for the oldObj but got %T", oldObj)
+	}
+	teamlog.V(1).Info("Validation for Team upon update", "name", team.GetName())
+
+	// the finalizer of a team is removed even if its references are gone
+	if equality.Semantic.DeepEqual(oldTeam.Spec, team.Spec) {
+		return nil, nil
+	}
+	return v.validateTeam(ctx, team)
+}
+
+// ValidateDelete implements webhook.CustomValidator.
+func (v *TeamCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *TeamCustomValidator) validateTeam(ctx context.Context, team *v1alpha1.Team) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+	if len(team.Spec.Participants) == 0 {
+		allErrs = append(allErrs, field.Required(specPath.Child("participants"), "a team needs at least one participant"))
+	}
+	if configs := teamConfigs(team); len(configs) > 1 {
+		allErrs = append(allErrs, field.Invalid(specPath, configs,
+			"only one of roundRobinTeamConfig, selectorTeamConfig, magenticOneTeamConfig or swarmTeamConfig may be set"))
+	}
+	allErrs = append(allErrs, validateTerminationCondition(team.Spec.TerminationCondition, specPath.Child("terminationCondition"))...)
+	if team.Spec.MaxTurns < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("maxTurns"), team.Spec.MaxTurns, "must not be negative"))
+	}
+	if len(allErrs) > 0 {
+		return nil, invalid("Team", team.Name, allErrs)
+	}
+
+	refs := referenceValidator{kube: v.Client}
+	if team.Spec.ModelConfig != "" {
+		if err := refs.validate(ctx, &v1alpha1.ModelConfig{}, specPath.Child("modelConfig"), team.Spec.ModelConfig, team.Namespace); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+	for i, participant := range team.Spec.Participants {
+		if err := refs.validate(ctx, &v1alpha1.Agent{}, specPath.Child("participants").Index(i), participant, team.Namespace); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+	if len(allErrs) > 0 {
+		return nil, invalid("Team", team.Name, allErrs)
+	}
+
+	translator, err := dryRunTranslator(v.Client, v.DefaultModelConfig, team)
+	if err != nil {
+		return nil, err
+	}
+	_, err = translator.TranslateGroupChatForTeam(ctx, team)
+	var chainErr *autogen.AgentToolChainError
+	switch {
+	case errors.As(err, &chainErr):
+		return nil, invalid("Team", team.Name, field.ErrorList{
+			field.Invalid(specPath.Child("participants"), team.Spec.Participants, chainErr.Error()),
+		})
+	case err != nil:
+		return admission.Warnings{fmt.Sprintf("the team cannot be translated yet: %v", err)}, nil
+	}
+	return nil, nil
+}
+
+// teamConfigs returns the names of the team configs set on a team
+func teamConfigs(team *v1alpha1.Team) []string {
+	var configs []string
+	if team.Spec.RoundRobinTeamConfig != nil {
+		configs = append(configs, "roundRobinTeamConfig")
+	}
+	if team.Spec.SelectorTeamConfig != nil {
+		configs = append(configs, "selectorTeamConfig")
+	}
+	if team.Spec.MagenticOneTeamConfig != nil {
+		configs = append(configs, "magenticOneTeamConfig")
+	}
+	if team.Spec.SwarmTeamConfig != nil {
+		configs = append(configs, "swarmTeamConfig")
+	}
+	return configs
+}
+
+// validateTerminationCondition checks that exactly one termination condition is set, including in an orTermination
+func validateTerminationCondition(condition v1alpha1.TerminationCondition, path *field.Path) field.ErrorList {
+	var set []string
+	if condition.MaxMessageTermination != nil {
+		set = append(set, "maxMessageTermination")
+	}
+	if condition.TextMentionTermination != nil {
+		set = append(set, "textMentionTermination")
+	}
+	if condition.TextMessageTermination != nil {
+		set = append(set, "textMessageTermination")
+	}
+	if condition.StopMessageTermination != nil {
+		set = append(set, "stopMessageTermination")
+	}
+	if condition.OrTermination != nil {
+		set = append(set, "orTermination")
+	}
+	switch {
+	case len(set) == 0:
+		return field.ErrorList{field.Required(path,
+			"one of maxMessageTermination, textMentionTermination, textMessageTermination, stopMessageTermination or orTermination must be set")}
+	case len(set) > 1:
+		return field.ErrorList{field.Invalid(path, set, "only one termination condition may be set, use orTermination to combine them")}
+	}
+
+	var allErrs field.ErrorList
+	if condition.MaxMessageTermination != nil && condition.MaxMessageTermination.MaxMessages <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxMessageTermination", "maxMessages"), condition.MaxMessageTermination.MaxMessages, "must be positive"))
+	}
+	if condition.OrTermination == nil {
+		return allErrs
+	}
+	conditionsPath := path.Child("orTermination", "conditions")
+	if len(condition.OrTermination.Conditions) == 0 {
+		allErrs = append(allErrs, field.Required(conditionsPath, ""))
+	}
+	for i, orCondition := range condition.OrTermination.Conditions {
+		switch {
+		case orCondition.MaxMessageTermination == nil && orCondition.TextMentionTermination == nil:
+			allErrs = append(allErrs, field.Required(conditionsPath.Index(i), "one of maxMessageTermination or textMentionTermination must be set"))
+		case orCondition.MaxMessageTermination != nil && orCondition.TextMentionTermination != nil:
+			allErrs = append(allErrs, field.Invalid(conditionsPath.Index(i), orCondition, "only one of maxMessageTermination or textMentionTermination may be set"))
+		case orCondition.MaxMessageTermination != nil && orCondition.MaxMessageTermination.MaxMessages <= 0:
+			allErrs = append(allErrs, field.Invalid(conditionsPath.Index(i).Child("maxMessageTermination", "maxMessages"), orCondition.MaxMessageTermination.MaxMessages, "must be positive"))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,98 @@
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	webhookv1alpha1 "github.com/kagent-dev/kagent/go/controller/internal/webhook/v1alpha1"
+)
+
+func TestTeamValidator(t *testing.T) {
+	validator := &webhookv1alpha1.TeamCustomValidator{
+		Client: newKubeClient(t, &v1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "k8s-agent", Namespace: namespace},
+			Spec: v1alpha1.AgentSpec{Tools: []*v1alpha1.Tool{{
+				Type:    v1alpha1.ToolProviderType_Builtin,
+				Builtin: &v1alpha1.BuiltinTool{Name: "kagent.tools.k8s.GetResources"},
+			}}},
+		}),
+		DefaultModelConfig: defaultModelConfig,
+	}
+	team := func(terminationCondition v1alpha1.TerminationCondition, participants ...string) *v1alpha1.Team {
+		return &v1alpha1.Team{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-team", Namespace: namespace},
+			Spec: v1alpha1.TeamSpec{
+				Participants:         participants,
+				RoundRobinTeamConfig: &v1alpha1.RoundRobinTeamConfig{},
+				TerminationCondition: terminationCondition,
+			},
+		}
+	}
+	maxMessages := v1alpha1.TerminationCondition{MaxMessageTermination: &v1alpha1.MaxMessageTermination{MaxMessages: 10}}
+
+	t.Run("ValidTeam", func(t *testing.T) {
+		warnings, err := validator.ValidateCreate(context.Background(), team(maxMessages, "k8s-agent"))
+		assert.NoError(t, err)
+		assert.Empty(t, warnings)
+	})
+
+	tests := []struct {
+		name      string
+		team      *v1alpha1.Team
+		errorPath string
+	}{
+		{
+			name:      "NoTerminationCondition",
+			team:      team(v1alpha1.TerminationCondition{}, "k8s-agent"),
+			errorPath: "spec.terminationCondition",
+		},
+		{
+			name: "SeveralTerminationConditions",
+			team: team(v1alpha1.TerminationCondition{
+				MaxMessageTermination:  &v1alpha1.MaxMessageTermination{MaxMessages: 10},
+				TextMentionTermination: &v1alpha1.TextMentionTermination{Text: "TERMINATE"},
+			}, "k8s-agent"),
+			errorPath: "spec.terminationCondition",
+		},
+		{
+			name: "EmptyOrTerminationCondition",
+			team: team(v1alpha1.TerminationCondition{
+				OrTermination: &v1alpha1.OrTermination{Conditions: []v1alpha1.OrTerminationCondition{
+					{MaxMessageTermination: &v1alpha1.MaxMessageTermination{MaxMessages: 10}},
+					{},
+				}},
+			}, "k8s-agent"),
+			errorPath: "spec.terminationCondition.orTermination.conditions[1]",
+		},
+		{
+			name:      "NoParticipants",
+			team:      team(maxMessages),
+			errorPath: "spec.participants",
+		},
+		{
+			name:      "MissingParticipant",
+			team:      team(maxMessages, "k8s-agent", "missing-agent"),
+			errorPath: "spec.participants[1]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validator.ValidateCreate(context.Background(), tt.team)
+			require.Error(t, err)
+			assert.True(t, apierrors.IsInvalid(err))
+			assert.Contains(t, err.Error(), tt.errorPath)
+		})
+	}
+}
+
+func TestTeamDefaulter(t *testing.T) {
+	team := &v1alpha1.Team{}
+	require.NoError(t, (&webhookv1alpha1.TeamCustomDefaulter{}).Default(context.Background(), team))
+	assert.NotNil(t, team.Spec.RoundRobinTeamConfig)
+}
@@ -0,0 +1,213 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+)
+
+var toolserverlog = logf.Log.WithName("toolserver-resource")
+
+// defaultToolServerRefreshInterval matches the interval the reconciler falls back to
+const defaultToolServerRefreshInterval = 60 * time.Second
+
+// SetupToolServerWebhookWithManager registers the webhook for ToolServer in the manager.
+func SetupToolServerWebhookWithManager(mgr ctrl.Manager, defaultModelConfig types.NamespacedName) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&v1alpha1.ToolServer{}).
+		WithValidator(&ToolServerCustomValidator{
+			Client:             mgr.GetClient(),
+			DefaultModelConfig: defaultModelConfig,
+		}).
+		WithDefaulter(&ToolServerCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-kagent-dev-v1alpha1-toolserver,mutating=true,failurePolicy=fail,sideEffects=None,groups=kagent.dev,resources=toolservers,verbs=create;update,versions=v1alpha1,name=mtoolserver-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ToolServerCustomDefaulter sets the refresh interval of ToolServers which don't set one.
+type ToolServerCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &ToolServerCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter.
+func (d *ToolServerCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	toolServer, ok := obj.(*v1alpha1.ToolServer)
+	if !ok {
+		return fmt.Errorf("expected a ToolServer object but got %T", obj)
+	}
+	toolserverlog.V(1).Info("Defaulting for ToolServer", "name", toolServer.GetName())
+
+	if toolServer.Spec.RefreshInterval == nil {
+		toolServer.Spec.RefreshInterval = &metav1.Duration{Duration: defaultToolServerRefreshInterval}
+	}
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-kagent-dev-v1alpha1-toolserver,mutating=false,failurePolicy=fail,sideEffects=None,groups=kagent.dev,resources=toolservers,verbs=create;update,versions=v1alpha1,name=vtoolserver-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ToolServerCustomValidator validates the config of ToolServers, and the ConfigMaps and Secrets their
+// environment, headers and credentials are read from. ToolServers are then translated against a dry-run client,
+// except the ones authenticating with OAuth2, whose token endpoint isn't called on admission.
+type ToolServerCustomValidator struct {
+	Client             client.Client
+	DefaultModelConfig types.NamespacedName
+}
+
+var _ webhook.CustomValidator = &ToolServerCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator.
+func (v *ToolServerCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	toolServer, ok := obj.(*v1alpha1.ToolServer)
+	if !ok {
+		return nil, fmt.Errorf("expected a ToolServer object but got %T", obj)
+	}
+	toolserverlog.V(1).Info("Validation for ToolServer upon creation", "name", toolServer.GetName())
+
+	return v.validateToolServer(ctx, toolServer)
+}
+
+// ValidateUpdate implements webhook.CustomValidator.
+func (v *ToolServerCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	toolServer, ok := newObj.(*v1alpha1.ToolServer)
+	if !ok {
+		return nil, fmt.Errorf("expected a ToolServer object for the newObj but got %T", newObj)
+	}
+	oldToolServer, ok := oldObj.(*v1alpha1.ToolServer)
+	if !ok {
+		return nil, fmt.Errorf("expected a ToolServer object for the oldObj but got %T", oldObj)
+	}
+	toolserverlog.V(1).Info("Validation for ToolServer upon update", "name", toolServer.GetName())
+
+	// the finalizer of a tool server is removed even if its secrets are gone
+	if equality.Semantic.DeepEqual(oldToolServer.Spec, toolServer.Spec) {
+		return nil, nil
+	}
+	return v.validateToolServer(ctx, toolServer)
+}
+
+// ValidateDelete implements webhook.CustomValidator.
+func (v *ToolServerCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *ToolServerCustomValidator) validateToolServer(ctx context.Context, toolServer *v1alpha1.ToolServer) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+	configPath := specPath.Child("config")
+	config := toolServer.Spec.Config
+	switch {
+	case config.Stdio == nil && config.Sse == nil:
+		allErrs = append(allErrs, field.Required(configPath, "one of stdio or sse must be set"))
+	case config.Stdio != nil && config.Sse != nil:
+		allErrs = append(allErrs, field.Invalid(configPath, []string{"stdio", "sse"}, "only one of stdio or sse may be set"))
+	case config.Stdio != nil:
+		if config.Stdio.Command == "" {
+			allErrs = append(allErrs, field.Required(configPath.Child("stdio", "command"), ""))
+		}
+	case config.Sse != nil:
+		allErrs = append(allErrs, validateSseConfig(config.Sse, configPath.Child("sse"))...)
+	}
+	if toolServer.Spec.RefreshInterval != nil && toolServer.Spec.RefreshInterval.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("refreshInterval"), toolServer.Spec.RefreshInterval.Duration.String(), "must be positive"))
+	}
+	if len(allErrs) > 0 {
+		return nil, invalid("ToolServer", toolServer.Name, allErrs)
+	}
+
+	allErrs = append(allErrs, v.validateToolServerReferences(ctx, toolServer)...)
+	if len(allErrs) > 0 {
+		return nil, invalid("ToolServer", toolServer.Name, allErrs)
+	}
+
+	translator, err := dryRunTranslator(v.Client, v.DefaultModelConfig, toolServer)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := translator.TranslateToolServer(ctx, toolServer); err != nil {
+		return admission.Warnings{fmt.Sprintf("the tool server cannot be translated yet: %v", err)}, nil
+	}
+	return nil, nil
+}
+
+func validateSseConfig(sse *v1alpha1.SseMcpServerConfig, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if sse.URL == "" {
+		allErrs = append(allErrs, field.Required(path.Child("url"), ""))
+	} else if u, err := url.Parse(sse.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		allErrs = append(allErrs, field.Invalid(path.Child("url"), sse.URL, "must be an absolute http or https URL"))
+	}
+	if sse.Timeout != "" {
+		if _, err := time.ParseDuration(sse.Timeout); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("timeout"), sse.Timeout, err.Error()))
+		}
+	}
+	if sse.SseReadTimeout != "" {
+		if _, err := time.ParseDuration(sse.SseReadTimeout); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("sse_read_timeout"), sse.SseReadTimeout, err.Error()))
+		}
+	}
+	if sse.Auth != nil && sse.Auth.OAuth2 != nil {
+		if u, err := url.Parse(sse.Auth.OAuth2.TokenURL); err != nil || u.Scheme == "" || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(path.Child("auth", "oauth2", "tokenURL"), sse.Auth.OAuth2.TokenURL, "must be an absolute URL"))
+		}
+	}
+	return allErrs
+}
+
+func (v *ToolServerCustomValidator) validateToolServerReferences(ctx context.Context, toolServer *v1alpha1.ToolServer) field.ErrorList {
+	var allErrs field.ErrorList
+	refs := referenceValidator{kube: v.Client}
+	configPath := field.NewPath("spec", "config")
+	validateValueRefs := func(valueRefs []v1alpha1.ValueRef, path *field.Path) {
+		for i, valueRef := range valueRefs {
+			if valueRef.ValueFrom == nil {
+				continue
+			}
+			if err := refs.validateValueSource(ctx, valueRef.ValueFrom, path.Index(i).Child("valueFrom"), toolServer.Namespace); err != nil {
+				allErrs = append(allErrs, err)
+			}
+		}
+	}
+
+	if stdio := toolServer.Spec.Config.Stdio; stdio != nil {
+		validateValueRefs(stdio.EnvFrom, configPath.Child("stdio", "envFrom"))
+	}
+	sse := toolServer.Spec.Config.Sse
+	if sse == nil {
+		return allErrs
+	}
+	validateValueRefs(sse.HeadersFrom, configPath.Child("sse", "headersFrom"))
+	if sse.Auth == nil {
+		return allErrs
+	}
+	authPath := configPath.Child("sse", "auth")
+	if oauth2 := sse.Auth.OAuth2; oauth2 != nil {
+		if err := refs.validateValueSource(ctx, &oauth2.ClientIDFrom, authPath.Child("oauth2", "clientIDFrom"), toolServer.Namespace); err != nil {
+			allErrs = append(allErrs, err)
+		}
+		if err := refs.validateValueSource(ctx, &oauth2.ClientSecretFrom, authPath.Child("oauth2", "clientSecretFrom"), toolServer.Namespace); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+	if tls := sse.Auth.TLS; tls != nil {
+		if err := refs.validate(ctx, &corev1.Secret{}, authPath.Child("tls", "secretRef"), tls.SecretRef, toolServer.Namespace); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,126 @@
+package v1alpha1_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kagent-dev/kagent/go/controller/api/v1alpha1"
+	webhookv1alpha1 "github.com/kagent-dev/kagent/go/controller/internal/webhook/v1alpha1"
+)
+
+func TestToolServerValidator(t *testing.T) {
+	validator := &webhookv1alpha1.ToolServerCustomValidator{
+		Client: newKubeClient(t, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "server-secret", Namespace: namespace},
+			Data:       map[string][]byte{"token": []byte("Bearer test")},
+		}),
+		DefaultModelConfig: defaultModelConfig,
+	}
+	toolServer := func(config v1alpha1.ToolServerConfig) *v1alpha1.ToolServer {
+		return &v1alpha1.ToolServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-server", Namespace: namespace},
+			Spec:       v1alpha1.ToolServerSpec{Config: config},
+		}
+	}
+	header := func(secret, key string) []v1alpha1.ValueRef {
+		return []v1alpha1.ValueRef{{
+			Name:      "Authorization",
+			ValueFrom: &v1alpha1.ValueSource{Type: v1alpha1.SecretValueSource, ValueRef: secret, Key: key},
+		}}
+	}
+
+	t.Run("ValidToolServer", func(t *testing.T) {
+		warnings, err := validator.ValidateCreate(context.Background(), toolServer(v1alpha1.ToolServerConfig{
+			Sse: &v1alpha1.SseMcpServerConfig{URL: "http://server:8080/sse", HeadersFrom: header("server-secret", "token"), Timeout: "30s"},
+		}))
+		assert.NoError(t, err)
+		assert.Empty(t, warnings)
+	})
+
+	t.Run("NoTokenRequest", func(t *testing.T) {
+		var tokenRequests atomic.Int32
+		tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tokenRequests.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer tokenServer.Close()
+
+		secretKey := v1alpha1.ValueSource{Type: v1alpha1.SecretValueSource, ValueRef: "server-secret", Key: "token"}
+		warnings, err := validator.ValidateCreate(context.Background(), toolServer(v1alpha1.ToolServerConfig{
+			Sse: &v1alpha1.SseMcpServerConfig{
+				URL: "http://server:8080/sse",
+				Auth: &v1alpha1.ToolServerAuth{OAuth2: &v1alpha1.OAuth2ClientCredentials{
+					TokenURL:         tokenServer.URL,
+					ClientIDFrom:     secretKey,
+					ClientSecretFrom: secretKey,
+				}},
+			},
+		}))
+		assert.NoError(t, err)
+		assert.Empty(t, warnings)
+		assert.Zero(t, tokenRequests.Load())
+	})
+
+	tests := []struct {
+		name       string
+		toolServer *v1alpha1.ToolServer
+		errorPath  string
+	}{
+		{
+			name:       "NoConfig",
+			toolServer: toolServer(v1alpha1.ToolServerConfig{}),
+			errorPath:  "spec.config",
+		},
+		{
+			name:       "RelativeURL",
+			toolServer: toolServer(v1alpha1.ToolServerConfig{Sse: &v1alpha1.SseMcpServerConfig{URL: "server:8080/sse"}}),
+			errorPath:  "spec.config.sse.url",
+		},
+		{
+			name: "InvalidTimeout",
+			toolServer: toolServer(v1alpha1.ToolServerConfig{
+				Sse: &v1alpha1.SseMcpServerConfig{URL: "http://server:8080/sse", SseReadTimeout: "5 minutes"},
+			}),
+			errorPath: "spec.config.sse.sse_read_timeout",
+		},
+		{
+			name: "MissingSecret",
+			toolServer: toolServer(v1alpha1.ToolServerConfig{
+				Sse: &v1alpha1.SseMcpServerConfig{URL: "http://server:8080/sse", HeadersFrom: header("missing-secret", "token")},
+			}),
+			errorPath: "spec.config.sse.headersFrom[0].valueFrom.valueRef",
+		},
+		{
+			name: "MissingSecretKey",
+			toolServer: toolServer(v1alpha1.ToolServerConfig{
+				Sse: &v1alpha1.SseMcpServerConfig{URL: "http://server:8080/sse", HeadersFrom: header("server-secret", "api-key")},
+			}),
+			errorPath: "spec.config.sse.headersFrom[0].valueFrom.key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validator.ValidateCreate(context.Background(), tt.toolServer)
+			require.Error(t, err)
+			assert.True(t, apierrors.IsInvalid(err))
+			assert.Contains(t, err.Error(), tt.errorPath)
+		})
+	}
+}
+
+func TestToolServerDefaulter(t *testing.T) {
+	toolServer := &v1alpha1.ToolServer{}
+	require.NoError(t, (&webhookv1alpha1.ToolServerCustomDefaulter{}).Default(context.Background(), toolServer))
+	require.NotNil(t, toolServer.Spec.RefreshInterval)
+	assert.Equal(t, 60*time.Second, toolServer.Spec.RefreshInterval.Duration)
+}
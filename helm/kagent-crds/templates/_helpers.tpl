@@ -0,0 +1,42 @@
+{{/*
+The namespace of the kagent chart serving the conversion webhook
+*/}}
+{{- define "kagent-crds.webhookNamespace" -}}
+{{- default .Release.Namespace .Values.webhooks.namespace }}
+{{- end }}
+
+{{/*
+The annotations of the CRDs converted by the conversion webhook
+*/}}
+{{- define "kagent-crds.conversionAnnotations" -}}
+{{- if and .Values.webhooks.enabled .Values.webhooks.certManager.enabled }}
+cert-manager.io/inject-ca-from: {{ include "kagent-crds.webhookNamespace" . }}/{{ .Values.webhooks.serviceName }}
+{{- end }}
+{{- end }}
+
+{{/*
+The conversion of the CRDs converted by the conversion webhook of the controller
+*/}}
+{{- define "kagent-crds.conversion" -}}
+{{- if .Values.webhooks.enabled }}
+{{- $namespace := include "kagent-crds.webhookNamespace" . }}
+{{- $caBundle := .Values.webhooks.caBundle }}
+{{- if and (not $caBundle) (not .Values.webhooks.certManager.enabled) }}
+{{- $secret := lookup "v1" "Secret" $namespace (printf "%s-cert" .Values.webhooks.serviceName) }}
+{{- $caBundle = index ($secret.data | default dict) "ca.crt" | default "" }}
+{{- end }}
+conversion:
+  strategy: Webhook
+  webhook:
+    clientConfig:
+      service:
+        namespace: {{ $namespace }}
+        name: {{ .Values.webhooks.serviceName }}
+        path: /convert
+      {{- with $caBundle }}
+      caBundle: {{ . }}
+      {{- end }}
+    conversionReviewVersions:
+      - v1
+{{- end }}
+{{- end }}
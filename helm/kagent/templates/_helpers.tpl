@@ -73,3 +73,17 @@ Removes duplicates
 {{- $nsSet := dict }}
 {{- .Values.controller.watchNamespaces | default list | uniq | join "," }}
 {{- end -}}
+
+{{/*
+Name of the service of the admission webhooks
+*/}}
+{{- define "kagent.webhookServiceName" -}}
+{{- printf "%s-webhook" (include "kagent.fullname" .) | trunc 63 | trimSuffix "-" }}
+{{- end }}
+
+{{/*
+Name of the secret holding the serving certificate of the admission webhooks
+*/}}
+{{- define "kagent.webhookCertSecretName" -}}
+{{- printf "%s-webhook-cert" (include "kagent.fullname" .) | trunc 63 | trimSuffix "-" }}
+{{- end }}
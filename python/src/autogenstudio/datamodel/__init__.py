@@ -1,4 +1,17 @@
-from .db import BaseDBModel, Feedback, Gallery, Message, Run, RunStatus, Session, Settings, Team, Tool, ToolServer
+from .db import (
+    Approval,
+    BaseDBModel,
+    Feedback,
+    Gallery,
+    Message,
+    Run,
+    RunStatus,
+    Session,
+    Settings,
+    Team,
+    Tool,
+    ToolServer,
+)
 from .types import (
     EnvironmentVariable,
     GalleryComponents,
@@ -36,4 +49,5 @@ __all__ = [
     "Gallery",
     "ToolServer",
     "Feedback",
+    "Approval",
 ]
@@ -86,6 +86,24 @@ class Feedback(BaseDBModel, table=True):
     message: Optional["Message"] = Relationship(back_populates="feedback")
 
 
+class Approval(BaseDBModel, table=True):
+    """
+    Database model for the approval requests of tool calls, which are kept as the audit trail once decided.
+    """
+
+    __table_args__ = {"sqlite_autoincrement": True}
+
+    approval_id: str = Field(index=True, unique=True, description="The ID of the approval request")
+    agent: str = Field(description="The agent which requested to call the tool")
+    tool: str = Field(description="The name of the tool")
+    arguments: dict = Field(default_factory=dict, sa_column=Column(JSON))
+    status: str = Field(default="pending", description="pending, approved, denied, expired or cancelled")
+    expires_at: Optional[datetime] = Field(default=None, sa_type=DateTime(timezone=True))  # type: ignore[assignment]
+    decided_at: Optional[datetime] = Field(default=None, sa_type=DateTime(timezone=True))  # type: ignore[assignment]
+    decided_by: Optional[str] = Field(default=None, description="The user who approved or denied the call")
+    reason: Optional[str] = Field(default=None, description="The reason given for the decision")
+
+
 class Session(BaseDBModel, table=True):
     __table_args__ = {"sqlite_autoincrement": True}
     team_id: Optional[int] = Field(default=None, sa_column=Column(Integer, ForeignKey("team.id", ondelete="CASCADE")))
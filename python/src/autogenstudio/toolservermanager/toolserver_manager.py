@@ -1,6 +1,7 @@
-from typing import Union
+from typing import Any, Union
 
-from autogen_core import Component, ComponentModel
+from autogen_core import CancellationToken, Component, ComponentModel
+from autogen_core.tools import BaseTool
 
 from kagent.tool_servers import ToolServer
 
@@ -34,3 +35,41 @@ class ToolServerManager:
             return await server.discover_tools()
         except Exception as e:
             raise Exception(f"Failed to discover tools: {e}") from e
+
+    async def discover_resources(self, tool_server_config: Union[dict, ComponentModel]) -> list[dict[str, Any]]:
+        """Discover resources from the given tool server."""
+        try:
+            server = await self._create_tool_server(tool_server_config)
+            return await server.discover_resources()
+        except Exception as e:
+            raise Exception(f"Failed to discover resources: {e}") from e
+
+    async def discover_prompts(self, tool_server_config: Union[dict, ComponentModel]) -> list[dict[str, Any]]:
+        """Discover prompts from the given tool server."""
+        try:
+            server = await self._create_tool_server(tool_server_config)
+            return await server.discover_prompts()
+        except Exception as e:
+            raise Exception(f"Failed to discover prompts: {e}") from e
+
+    async def call_tool(
+        self, tool_server_config: Union[dict, ComponentModel], tool_name: str, arguments: dict[str, Any]
+    ) -> dict[str, Any]:
+        """Call a tool on the given tool server, returning the content of the result."""
+        tools = await self.discover_tools(tool_server_config)
+        tool = next((t for t in tools if isinstance(t, BaseTool) and t.name == tool_name), None)
+        if tool is None:
+            raise ValueError(f"Tool {tool_name} not found")
+
+        try:
+            result = await tool.run_json(arguments, CancellationToken())
+        except Exception as e:
+            # errors returned by the tool are part of the result, so the caller can handle them
+            return {"content": [{"type": "text", "text": str(e)}], "is_error": True}
+
+        # MCP tools return the content of the result, which is kept as is
+        if isinstance(result, list) and all(hasattr(item, "model_dump") for item in result):
+            content = [item.model_dump(mode="json") for item in result]
+        else:
+            content = [{"type": "text", "text": tool.return_value_as_string(result)}]
+        return {"content": content, "is_error": False}
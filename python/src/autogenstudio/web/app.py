@@ -16,6 +16,7 @@ from .config import settings
 from .deps import cleanup_managers, init_auth_manager, init_managers, register_auth_dependencies
 from .initialization import AppInitializer
 from .routes import (
+    approvals,
     feedback,
     gallery,
     invoke,
@@ -180,6 +181,13 @@ api.include_router(
     responses={404: {"description": "Not found"}},
 )
 
+api.include_router(
+    approvals.router,
+    prefix="/approvals",
+    tags=["approvals"],
+    responses={404: {"description": "Not found"}},
+)
+
 # Version endpoint
 
 
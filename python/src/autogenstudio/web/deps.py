@@ -6,12 +6,14 @@ from pathlib import Path
 from typing import Optional
 
 from fastapi import Depends, FastAPI, HTTPException, Request, WebSocket, status
+from kagent.tools.common import get_approval_manager
 
 from ..database import DatabaseManager
 from ..teammanager import TeamManager
 from .auth import AuthConfig, AuthManager, AuthMiddleware
 from .auth.dependencies import get_auth_manager
 from .config import settings
+from .managers.approvals import DatabaseApprovalStore, expire_stale_approvals
 from .managers.connection import WebSocketManager
 from ..sessionmanager import SessionManager
 
@@ -140,6 +142,10 @@ async def init_managers(database_uri: str, config_dir: str | Path, app_root: str
         await _db_manager.import_teams_from_directory(config_dir, settings.DEFAULT_USER_ID, check_exists=True)
         await _db_manager.import_tools_from_directory(config_dir, settings.DEFAULT_USER_ID)
 
+        # Persist the approvals of the tool calls, which also keeps their audit trail
+        expire_stale_approvals(_db_manager)
+        get_approval_manager().set_store(DatabaseApprovalStore(_db_manager))
+
         # Initialize connection manager
         _websocket_manager = WebSocketManager(db_manager=_db_manager)
         logger.info("Connection manager initialized")
@@ -0,0 +1,99 @@
+from datetime import datetime, timezone
+from typing import Optional
+
+from kagent.tools.common import ApprovalRequest, ApprovalStatus, ApprovalStore
+from loguru import logger
+from sqlalchemy import update
+from sqlmodel import Session, select
+
+from ...database import DatabaseManager
+from ...datamodel import Approval
+
+
+def _utc(value: Optional[datetime]) -> Optional[datetime]:
+    # sqlite returns naive datetimes, the ones of the approvals are stored in UTC
+    if value is not None and value.tzinfo is None:
+        return value.replace(tzinfo=timezone.utc)
+    return value
+
+
+def _to_request(approval: Approval) -> ApprovalRequest:
+    return ApprovalRequest(
+        id=approval.approval_id,
+        agent=approval.agent,
+        tool=approval.tool,
+        arguments=approval.arguments,
+        status=approval.status,  # type: ignore[arg-type]
+        requested_at=_utc(approval.created_at),  # type: ignore[arg-type]
+        expires_at=_utc(approval.expires_at),  # type: ignore[arg-type]
+        decided_at=_utc(approval.decided_at),
+        decided_by=approval.decided_by,
+        reason=approval.reason,
+    )
+
+
+class DatabaseApprovalStore(ApprovalStore):
+    """
+    Persists the approval requests in the database, which also keeps them as the audit trail.
+
+    Pending requests are moved to their final status with a conditional update, so a request decided through
+    one process can't be decided again or expired by another one.
+    """
+
+    def __init__(self, db: DatabaseManager) -> None:
+        super().__init__()
+        self._db = db
+
+    def save(self, request: ApprovalRequest) -> None:
+        approval = Approval(
+            approval_id=request.id,
+            agent=request.agent,
+            tool=request.tool,
+            arguments=request.arguments,
+            status=request.status,
+            expires_at=request.expires_at,
+        )
+        result = self._db.upsert(approval)
+        if not result.status:
+            raise RuntimeError(f"Failed to save approval {request.id}: {result.message}")
+
+    def get(self, approval_id: str) -> Optional[ApprovalRequest]:
+        with Session(self._db.engine) as session:
+            approval = session.exec(select(Approval).where(Approval.approval_id == approval_id)).first()
+            return _to_request(approval) if approval is not None else None
+
+    def finish(
+        self,
+        approval_id: str,
+        status: ApprovalStatus,
+        decided_by: Optional[str] = None,
+        reason: Optional[str] = None,
+    ) -> Optional[ApprovalRequest]:
+        now = datetime.now(timezone.utc)
+        with Session(self._db.engine) as session:
+            result = session.execute(
+                update(Approval)
+                .where(Approval.approval_id == approval_id, Approval.status == "pending")  # type: ignore[arg-type]
+                .values(status=status, decided_at=now, decided_by=decided_by, reason=reason, updated_at=now)
+            )
+            session.commit()
+            if result.rowcount == 0:
+                return None
+        return self.get(approval_id)
+
+
+def expire_stale_approvals(db: DatabaseManager) -> None:
+    """
+    Marks the pending approvals which expired as expired, e.g. the ones whose run was stopped with the server.
+    The pending approvals which didn't expire yet are kept, as they may belong to another server sharing the database.
+    """
+    result = db.get(Approval, filters={"status": "pending"})
+    if not result.status:
+        logger.error(f"Failed to list pending approvals: {result.message}")
+        return
+    store = DatabaseApprovalStore(db)
+    now = datetime.now(timezone.utc)
+    for approval in result.data:
+        expires_at = _utc(approval.expires_at)
+        if expires_at is None or expires_at <= now:
+            store.finish(approval.approval_id, "expired")
@@ -0,0 +1,71 @@
+from typing import Optional
+
+from fastapi import APIRouter, Depends, HTTPException
+from kagent.tools.common import get_approval_manager
+from loguru import logger
+from pydantic import BaseModel, Field
+
+from ...database.db_manager import DatabaseManager
+from ...datamodel import Approval
+from ..deps import get_db
+
+router = APIRouter()
+
+
+class ApprovalDecisionRequest(BaseModel):
+    """Model for approving or denying a pending tool call"""
+
+    user_id: str = Field(description="The user who takes the decision")
+    reason: Optional[str] = Field(None, description="The reason for the decision")
+
+
+@router.get("/")
+async def list_approvals(
+    status: Optional[str] = None,
+    db: DatabaseManager = Depends(get_db),
+) -> dict:
+    """
+    List the approval requests, most recent first
+
+    Args:
+        status: Only list the requests with this status, e.g. pending
+        db: The database manager instance
+    """
+    filters = {"status": status} if status else None
+    result = db.get(Approval, filters=filters)
+    if not result.status:
+        raise HTTPException(status_code=500, detail=result.message or "Failed to retrieve approvals.")
+    return {"status": True, "data": result.data}
+
+
+@router.post("/{approval_id}/approve")
+async def approve(
+    approval_id: str,
+    request: ApprovalDecisionRequest,
+    db: DatabaseManager = Depends(get_db),
+) -> dict:
+    """Approve a pending tool call, which then runs"""
+    return _decide(db, approval_id, True, request)
+
+
+@router.post("/{approval_id}/deny")
+async def deny(
+    approval_id: str,
+    request: ApprovalDecisionRequest,
+    db: DatabaseManager = Depends(get_db),
+) -> dict:
+    """Deny a pending tool call, which then fails with the reason"""
+    return _decide(db, approval_id, False, request)
+
+
+def _decide(db: DatabaseManager, approval_id: str, approved: bool, request: ApprovalDecisionRequest) -> dict:
+    decided = get_approval_manager().decide(approval_id, approved, request.user_id, request.reason)
+    if decided is None:
+        raise HTTPException(status_code=404, detail=f"No pending approval with ID {approval_id}")
+
+    logger.info(f"Call of {decided.tool} by {decided.agent} {decided.status} by {request.user_id} ({approval_id})")
+
+    result = db.get(Approval, filters={"approval_id": approval_id})
+    if not result.status or not result.data:
+        raise HTTPException(status_code=500, detail="Failed to retrieve the approval.")
+    return {"status": True, "data": result.data[0]}
@@ -1,6 +1,7 @@
-from typing import Dict
+from typing import Any, Dict
 
 from fastapi import APIRouter, Depends, HTTPException
+from pydantic import BaseModel
 
 from ...datamodel import Tool, ToolServer
 from ...toolservermanager import ToolServerManager
@@ -77,6 +78,62 @@ async def get_server_tools(server_id: int, user_id: str, db=Depends(get_db)) ->
     return {"status": True, "data": tools_response.data}
 
 
+@router.get("/{server_id}/resources")
+async def get_server_resources(server_id: int, user_id: str, db=Depends(get_db)) -> Dict:
+    """Discover the resources exposed by a server"""
+
+    server_response = db.get(ToolServer, filters={"id": server_id, "user_id": user_id})
+    if not server_response.status or not server_response.data:
+        raise HTTPException(status_code=404, detail="Server not found")
+
+    tsm = ToolServerManager()
+    try:
+        resources = await tsm.discover_resources(server_response.data[0].component)
+        return {"status": True, "data": resources}
+    except Exception as e:
+        raise HTTPException(status_code=400, detail=f"Failed to discover resources: {str(e)}") from e
+
+
+@router.get("/{server_id}/prompts")
+async def get_server_prompts(server_id: int, user_id: str, db=Depends(get_db)) -> Dict:
+    """Discover the prompts exposed by a server"""
+
+    server_response = db.get(ToolServer, filters={"id": server_id, "user_id": user_id})
+    if not server_response.status or not server_response.data:
+        raise HTTPException(status_code=404, detail="Server not found")
+
+    tsm = ToolServerManager()
+    try:
+        prompts = await tsm.discover_prompts(server_response.data[0].component)
+        return {"status": True, "data": prompts}
+    except Exception as e:
+        raise HTTPException(status_code=400, detail=f"Failed to discover prompts: {str(e)}") from e
+
+
+class ToolCallRequest(BaseModel):
+    arguments: Dict[str, Any] = {}
+
+
+@router.post("/{server_id}/tools/{tool_name}/call")
+async def call_server_tool(
+    server_id: int, tool_name: str, request: ToolCallRequest, user_id: str, db=Depends(get_db)
+) -> Dict:
+    """Call a tool on a server"""
+
+    server_response = db.get(ToolServer, filters={"id": server_id, "user_id": user_id})
+    if not server_response.status or not server_response.data:
+        raise HTTPException(status_code=404, detail="Server not found")
+
+    tsm = ToolServerManager()
+    try:
+        result = await tsm.call_tool(server_response.data[0].component, tool_name, request.arguments)
+        return {"status": True, "data": result}
+    except ValueError as e:
+        raise HTTPException(status_code=404, detail=str(e)) from e
+    except Exception as e:
+        raise HTTPException(status_code=400, detail=f"Failed to call tool: {str(e)}") from e
+
+
 @router.post("/{server_id}/refresh")
 async def refresh_server_tools(server_id: int, user_id: str, db=Depends(get_db)) -> Dict:
     """Refresh tools for an existing server"""
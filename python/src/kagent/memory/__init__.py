@@ -1,3 +1,4 @@
+from ._mcp_context_memory import McpContextMemory
 from ._pinecone_memory import PineconeMemory
 
-__all__ = ["PineconeMemory"]
+__all__ = ["McpContextMemory", "PineconeMemory"]
@@ -0,0 +1,159 @@
+from typing import Dict, List, Optional, Sequence
+
+from autogen_core import CancellationToken, Component
+from autogen_core.memory import Memory, MemoryContent, MemoryMimeType, MemoryQueryResult, UpdateContextResult
+from autogen_core.model_context import ChatCompletionContext
+from autogen_core.models import SystemMessage
+from autogen_ext.tools.mcp._config import McpServerParams, SseServerParams
+from autogen_ext.tools.mcp._session import create_mcp_server_session
+from loguru import logger
+from mcp import ClientSession
+from mcp.types import TextContent, TextResourceContents
+from pydantic import AnyUrl, BaseModel, Field
+from typing_extensions import Self
+
+from ..tools.mcp import McpSecretKeyRef, McpTlsConfig, TlsSseServerParams, create_tls_sse_session
+
+
+class McpPromptConfig(BaseModel):
+    name: str = Field(..., description="The name of the prompt on the MCP server")
+    arguments: Dict[str, str] = Field(default_factory=dict, description="The arguments to render the prompt with")
+
+
+class McpContextMemoryConfig(BaseModel):
+    server_params: McpServerParams = Field(..., description="The parameters used to connect to the MCP server")
+    resources: List[str] = Field(default_factory=list, description="The URIs of the resources to add to the context")
+    prompts: List[McpPromptConfig] = Field(
+        default_factory=list, description="The prompts to add to the context as system message fragments"
+    )
+    tls: Optional[McpTlsConfig] = Field(
+        default=None, description="The TLS configuration used to connect to an SSE MCP server with a client certificate"
+    )
+    headers_from: Dict[str, McpSecretKeyRef] = Field(
+        default_factory=dict, description="The headers of an SSE MCP server whose values are read from Secrets"
+    )
+
+
+class McpContextMemory(Memory, Component[McpContextMemoryConfig]):
+    """Adds resources and prompts from an MCP server to the model context.
+
+    Prompts are rendered and added as system message fragments, resources are read and added as context.
+    Both are fetched from the server every time the context is updated.
+    """
+
+    component_config_schema = McpContextMemoryConfig
+    component_type = "memory"
+    component_provider_override = "kagent.memory.McpContextMemory"
+
+    def __init__(self, config: McpContextMemoryConfig):
+        self._config = config
+
+    async def update_context(
+        self,
+        model_context: ChatCompletionContext,
+        cancellation_token: CancellationToken | None = None,
+    ) -> UpdateContextResult:
+        """Update the context with the configured prompts and resources."""
+        try:
+            results = await self._load(cancellation_token)
+            prompts = [memory.content for memory in results if memory.metadata.get("type") == "prompt"]
+            resources = [
+                f"Resource {memory.metadata.get('uri')}:\n{memory.content}"
+                for memory in results
+                if memory.metadata.get("type") == "resource"
+            ]
+
+            if prompts:
+                await model_context.add_message(SystemMessage(content="\n\n".join(prompts)))
+            if resources:
+                resource_context = "\nUse the following resources as context for your response:\n" + "\n\n".join(
+                    resources
+                )
+                await model_context.add_message(SystemMessage(content=resource_context))
+
+            return UpdateContextResult(success=True, memories=MemoryQueryResult(results=results))
+        except Exception as e:
+            logger.error(f"Error during MCP context update_context: {e}")
+            return UpdateContextResult(success=False, error=str(e), memories=MemoryQueryResult(results=[]))
+
+    async def query(
+        self,
+        query: str | MemoryContent,
+        cancellation_token: CancellationToken | None = None,
+        **kwargs,
+    ) -> MemoryQueryResult:
+        """Return the configured prompts and resources, regardless of the query."""
+        return MemoryQueryResult(results=await self._load(cancellation_token))
+
+    async def _load(self, cancellation_token: CancellationToken | None = None) -> List[MemoryContent]:
+        results: List[MemoryContent] = []
+        if not self._config.prompts and not self._config.resources:
+            return results
+
+        async with self._create_session() as session:
+            await session.initialize()
+            for prompt in self._config.prompts:
+                if cancellation_token and cancellation_token.is_cancelled:
+                    return results
+                results.append(await self._get_prompt(session, prompt))
+            for uri in self._config.resources:
+                if cancellation_token and cancellation_token.is_cancelled:
+                    return results
+                results.extend(await self._read_resource(session, uri))
+
+        return results
+
+    def _create_session(self):
+        has_credentials = self._config.tls is not None or self._config.headers_from
+        if has_credentials and isinstance(self._config.server_params, SseServerParams):
+            params = TlsSseServerParams(
+                **self._config.server_params.model_dump(), tls=self._config.tls, headers_from=self._config.headers_from
+            )
+            return create_tls_sse_session(params)
+        return create_mcp_server_session(self._config.server_params)
+
+    async def _get_prompt(self, session: ClientSession, prompt: McpPromptConfig) -> MemoryContent:
+        result = await session.get_prompt(prompt.name, arguments=prompt.arguments or None)
+        fragments = [message.content.text for message in result.messages if isinstance(message.content, TextContent)]
+        return MemoryContent(
+            content="\n".join(fragments),
+            mime_type=MemoryMimeType.TEXT,
+            metadata={"type": "prompt", "name": prompt.name},
+        )
+
+    async def _read_resource(self, session: ClientSession, uri: str) -> List[MemoryContent]:
+        result = await session.read_resource(AnyUrl(uri))
+        contents: List[MemoryContent] = []
+        for content in result.contents:
+            # only text resources can be added to the context
+            if not isinstance(content, TextResourceContents):
+                logger.warning(f"Skipping non-text content of resource {uri}")
+                continue
+            contents.append(
+                MemoryContent(
+                    content=content.text,
+                    mime_type=MemoryMimeType.TEXT,
+                    metadata={"type": "resource", "uri": uri},
+                )
+            )
+        return contents
+
+    async def add(
+        self, content: MemoryContent | Sequence[MemoryContent], cancellation_token: CancellationToken | None = None
+    ) -> None:
+        pass
+
+    async def clear(self) -> None:
+        pass
+
+    async def close(self) -> None:
+        pass
+
+    def _to_config(self) -> McpContextMemoryConfig:
+        """Serialize the memory configuration."""
+        return self._config
+
+    @classmethod
+    def _from_config(cls, config: McpContextMemoryConfig) -> Self:
+        """Deserialize the memory configuration."""
+        return cls(config=config)
@@ -0,0 +1,25 @@
+from typing import Any, AsyncContextManager, Callable
+
+from mcp import ClientSession
+
+SessionFactory = Callable[[], AsyncContextManager[ClientSession]]
+
+
+async def discover_mcp_resources(create_session: SessionFactory) -> list[dict[str, Any]]:
+    """List the resources exposed by an MCP server, or none if the server does not support resources."""
+    async with create_session() as session:
+        initialize_result = await session.initialize()
+        if initialize_result.capabilities.resources is None:
+            return []
+        result = await session.list_resources()
+        return [resource.model_dump(mode="json", by_alias=True, exclude_none=True) for resource in result.resources]
+
+
+async def discover_mcp_prompts(create_session: SessionFactory) -> list[dict[str, Any]]:
+    """List the prompts exposed by an MCP server, or none if the server does not support prompts."""
+    async with create_session() as session:
+        initialize_result = await session.initialize()
+        if initialize_result.capabilities.prompts is None:
+            return []
+        result = await session.list_prompts()
+        return [prompt.model_dump(mode="json", by_alias=True, exclude_none=True) for prompt in result.prompts]
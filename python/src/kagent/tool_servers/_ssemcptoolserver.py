@@ -1,13 +1,28 @@
+from contextlib import AbstractAsyncContextManager
+from typing import Any, Optional
+
 from autogen_core import Component
 from autogen_ext.tools.mcp._config import SseServerParams
 from autogen_ext.tools.mcp._factory import mcp_server_tools
+from autogen_ext.tools.mcp._session import create_mcp_server_session
 from loguru import logger
+from mcp import ClientSession
 
+from ..tools.mcp import (
+    McpSecretKeyRef,
+    McpTlsConfig,
+    TlsSseMcpToolAdapter,
+    TlsSseMcpToolAdapterConfig,
+    TlsSseServerParams,
+    create_tls_sse_session,
+)
+from ._mcp_discovery import discover_mcp_prompts, discover_mcp_resources
 from ._tool_server import ToolServer
 
 
 class SseMcpToolServerConfig(SseServerParams):
-    pass
+    tls: Optional[McpTlsConfig] = None
+    headers_from: dict[str, McpSecretKeyRef] = {}
 
 
 class SseMcpToolServer(ToolServer, Component[SseMcpToolServerConfig]):
@@ -18,14 +33,49 @@ class SseMcpToolServer(ToolServer, Component[SseMcpToolServerConfig]):
     def __init__(self, config: SseMcpToolServerConfig):
         self.config = config
 
+    def _server_params(self) -> SseServerParams:
+        if self.config.tls is None and not self.config.headers_from:
+            return SseServerParams(**self.config.model_dump(exclude={"tls", "headers_from", "type"}))
+        return TlsSseServerParams(**self.config.model_dump(exclude={"type"}))
+
+    def _create_session(self) -> AbstractAsyncContextManager[ClientSession]:
+        server_params = self._server_params()
+        if isinstance(server_params, TlsSseServerParams):
+            return create_tls_sse_session(server_params)
+        return create_mcp_server_session(server_params)
+
     async def discover_tools(self) -> list[Component]:
         try:
-            logger.debug(f"Discovering tools from sse server: {self.config}")
-            tools = await mcp_server_tools(self.config)
-            return tools
+            logger.debug(f"Discovering tools from sse server: {self.config.url}")
+            server_params = self._server_params()
+            if not isinstance(server_params, TlsSseServerParams):
+                return await mcp_server_tools(server_params)
+
+            # the autogen mcp tools cannot read credentials from secrets
+            async with create_tls_sse_session(server_params) as session:
+                await session.initialize()
+                result = await session.list_tools()
+            return [
+                TlsSseMcpToolAdapter(TlsSseMcpToolAdapterConfig(server_params=server_params, tool=tool))
+                for tool in result.tools
+            ]
         except Exception as e:
             raise Exception(f"Failed to discover tools: {e}") from e
 
+    async def discover_resources(self) -> list[dict[str, Any]]:
+        try:
+            logger.debug(f"Discovering resources from sse server: {self.config.url}")
+            return await discover_mcp_resources(self._create_session)
+        except Exception as e:
+            raise Exception(f"Failed to discover resources: {e}") from e
+
+    async def discover_prompts(self) -> list[dict[str, Any]]:
+        try:
+            logger.debug(f"Discovering prompts from sse server: {self.config.url}")
+            return await discover_mcp_prompts(self._create_session)
+        except Exception as e:
+            raise Exception(f"Failed to discover prompts: {e}") from e
+
     def _to_config(self) -> SseMcpToolServerConfig:
         return SseMcpToolServerConfig(**self.config.model_dump())
 
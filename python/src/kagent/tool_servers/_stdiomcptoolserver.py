@@ -1,10 +1,12 @@
-from typing import Self
+from typing import Any, Self
 
 from autogen_core import Component
 from autogen_ext.tools.mcp._config import StdioServerParams
 from autogen_ext.tools.mcp._factory import mcp_server_tools
+from autogen_ext.tools.mcp._session import create_mcp_server_session
 from loguru import logger
 
+from ._mcp_discovery import discover_mcp_prompts, discover_mcp_resources
 from ._tool_server import ToolServer
 
 
@@ -28,6 +30,20 @@ class StdioMcpToolServer(ToolServer, Component[StdioMcpToolServerConfig]):
         except Exception as e:
             raise Exception(f"Failed to discover tools: {e}") from e
 
+    async def discover_resources(self) -> list[dict[str, Any]]:
+        try:
+            logger.debug(f"Discovering resources from stdio server: {self.config}")
+            return await discover_mcp_resources(lambda: create_mcp_server_session(self.config))
+        except Exception as e:
+            raise Exception(f"Failed to discover resources: {e}") from e
+
+    async def discover_prompts(self) -> list[dict[str, Any]]:
+        try:
+            logger.debug(f"Discovering prompts from stdio server: {self.config}")
+            return await discover_mcp_prompts(lambda: create_mcp_server_session(self.config))
+        except Exception as e:
+            raise Exception(f"Failed to discover prompts: {e}") from e
+
     def _to_config(self) -> StdioMcpToolServerConfig:
         return StdioMcpToolServerConfig(**self.config.model_dump())
 
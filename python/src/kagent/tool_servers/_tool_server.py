@@ -1,5 +1,5 @@
 from abc import ABC
-from typing import Protocol
+from typing import Any, Protocol
 
 from autogen_core import Component, ComponentBase
 from pydantic import BaseModel
@@ -8,6 +8,10 @@ from pydantic import BaseModel
 class ToolDiscovery(Protocol):
     async def discover_tools(self) -> list[Component]: ...
 
+    async def discover_resources(self) -> list[dict[str, Any]]: ...
+
+    async def discover_prompts(self) -> list[dict[str, Any]]: ...
+
 
 class ToolServer(ABC, ToolDiscovery, ComponentBase[BaseModel]):
     component_type = "tool_server"
@@ -1,14 +1,27 @@
-from typing import Any
+import asyncio
+import contextvars
+import functools
+from typing import Any, Optional
 
 from autogen_core import CancellationToken, Component
 from autogen_core.tools import BaseTool, FunctionTool
-from pydantic import BaseModel
+from pydantic import BaseModel, Field
+
+from .common import kube_token
+
+
+class TypedToolConfig(BaseModel):
+    """Configuration shared by the typed fn tools."""
+
+    kube_token: Optional[str] = Field(
+        None, description="The service account token used to access the cluster instead of the identity of the pod."
+    )
 
 
 def create_typed_fn_tool(fn_tool: FunctionTool, override_provider: str, class_name: str):
     """Creates a concrete typed fn tool class from a function tool."""
 
-    class ToolConfig(BaseModel):
+    class ToolConfig(TypedToolConfig):
         pass
 
     class Tool(BaseTool, Component[ToolConfig]):
@@ -17,8 +30,9 @@ def create_typed_fn_tool(fn_tool: FunctionTool, override_provider: str, class_na
         component_config_schema = ToolConfig
         component_description = fn_tool.description
 
-        def __init__(self):
+        def __init__(self, config: Optional[ToolConfig] = None):
             self.fn_tool = fn_tool
+            self._config = config or ToolConfig()
             super().__init__(
                 name=fn_tool.name,
                 description=fn_tool.description,
@@ -27,14 +41,33 @@ def create_typed_fn_tool(fn_tool: FunctionTool, override_provider: str, class_na
             )
 
         async def run(self, args: ToolConfig, cancellation_token: CancellationToken) -> Any:
-            return await self.fn_tool.run(args, cancellation_token)
+            if self._config.kube_token is None:
+                return await self.fn_tool.run(args, cancellation_token)
+
+            func = fn_tool._func
+            if asyncio.iscoroutinefunction(func):
+                reset_token = kube_token.set(self._config.kube_token)
+                try:
+                    return await self.fn_tool.run(args, cancellation_token)
+                finally:
+                    kube_token.reset(reset_token)
+
+            # the function runs in a thread, which does not inherit the context of the task
+            context = contextvars.copy_context()
+            context.run(kube_token.set, self._config.kube_token)
+            kwargs = {name: getattr(args, name) for name in type(args).model_fields}
+            future = asyncio.get_running_loop().run_in_executor(
+                None, context.run, functools.partial(func, **kwargs)
+            )
+            cancellation_token.link_future(future)
+            return await future
 
         def _to_config(self) -> ToolConfig:
-            return ToolConfig()
+            return ToolConfig(**self._config.model_dump())
 
         @classmethod
         def _from_config(cls, config: ToolConfig):
-            return cls()
+            return cls(config)
 
     # Set the class name dynamically
     Tool.__name__ = class_name
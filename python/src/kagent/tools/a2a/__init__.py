@@ -0,0 +1,3 @@
+from ._remote_agent_tool import RemoteAgentError, RemoteAgentTool, RemoteAgentToolConfig, RemoteAgentToolInput
+
+__all__ = ["RemoteAgentTool", "RemoteAgentToolConfig", "RemoteAgentToolInput", "RemoteAgentError"]
@@ -0,0 +1,113 @@
+import asyncio
+import uuid
+from typing import Any, Optional
+
+import httpx
+from autogen_core import CancellationToken, Component
+from autogen_core.tools import BaseTool
+from pydantic import BaseModel, Field
+
+# The states of an A2A task after which it does not change anymore
+FINAL_TASK_STATES = ["completed", "canceled", "failed"]
+
+
+class RemoteAgentError(Exception):
+    """Raised when the remote agent fails or does not complete the task."""
+
+
+class RemoteAgentToolConfig(BaseModel):
+    """Configuration for the RemoteAgentTool."""
+
+    name: str = Field(..., description="The name of the tool, as seen by the model.")
+    description: str = Field(..., description="The description of the remote agent, including its skills.")
+    url: str = Field(..., description="The URL of the A2A server the tasks are sent to.")
+    headers: dict[str, str] = Field(default_factory=dict, description="The headers sent with every request.")
+    timeout_seconds: float = Field(300, description="How long to wait for the remote agent to complete a task.")
+    poll_interval_seconds: float = Field(2, description="How often a task which is not completed yet is polled.")
+
+
+class RemoteAgentToolInput(BaseModel):
+    task: str = Field(..., description="The task to delegate to the agent.")
+
+
+class RemoteAgentTool(BaseTool[RemoteAgentToolInput, str], Component[RemoteAgentToolConfig]):
+    """
+    RemoteAgentTool delegates a task to an agent running outside of the cluster over the A2A protocol,
+    and returns the text of the artifacts of the task.
+
+    Args:
+        config (RemoteAgentToolConfig): Configuration for the RemoteAgentTool.
+    """
+
+    component_description = "RemoteAgentTool delegates tasks to a remote agent over the A2A protocol."
+    component_type = "tool"
+    component_config_schema = RemoteAgentToolConfig
+    component_provider_override = "kagent.tools.a2a.RemoteAgentTool"
+
+    def __init__(self, config: RemoteAgentToolConfig) -> None:
+        self._config = config
+        super().__init__(
+            args_type=RemoteAgentToolInput,
+            return_type=str,
+            name=config.name,
+            description=config.description,
+        )
+
+    async def run(self, args: RemoteAgentToolInput, cancellation_token: CancellationToken) -> str:
+        task_id = str(uuid.uuid4())
+        message = {"role": "user", "parts": [{"type": "text", "text": args.task}]}
+
+        async with httpx.AsyncClient(headers=self._config.headers, timeout=self._config.timeout_seconds) as client:
+            task = await self._call(client, "tasks/send", {"id": task_id, "message": message}, cancellation_token)
+            deadline = asyncio.get_running_loop().time() + self._config.timeout_seconds
+            while _task_state(task) not in FINAL_TASK_STATES + ["input-required"]:
+                if asyncio.get_running_loop().time() > deadline:
+                    raise RemoteAgentError(f"{self.name} did not complete the task in time")
+                await asyncio.sleep(self._config.poll_interval_seconds)
+                task = await self._call(client, "tasks/get", {"id": task_id}, cancellation_token)
+
+        text = _task_text(task)
+        if _task_state(task) in ["canceled", "failed"]:
+            raise RemoteAgentError(f"{self.name} did not complete the task ({_task_state(task)}): {text}")
+        return text
+
+    async def _call(
+        self, client: httpx.AsyncClient, method: str, params: dict[str, Any], cancellation_token: CancellationToken
+    ) -> dict[str, Any]:
+        request = {"jsonrpc": "2.0", "id": str(uuid.uuid4()), "method": method, "params": params}
+        future = asyncio.ensure_future(client.post(self._config.url, json=request))
+        cancellation_token.link_future(future)
+        response = await future
+        response.raise_for_status()
+
+        body = response.json()
+        if body.get("error"):
+            error = body["error"]
+            raise RemoteAgentError(f"{self.name} returned an error for {method}: {error.get('message', error)}")
+        return body.get("result") or {}
+
+    def _to_config(self) -> RemoteAgentToolConfig:
+        return RemoteAgentToolConfig(**self._config.model_dump())
+
+    @classmethod
+    def _from_config(cls, config: RemoteAgentToolConfig) -> "RemoteAgentTool":
+        return cls(config)
+
+
+def _task_state(task: dict[str, Any]) -> Optional[str]:
+    return (task.get("status") or {}).get("state")
+
+
+def _parts_text(parts: list[dict[str, Any]]) -> list[str]:
+    return [part["text"] for part in parts or [] if part.get("type") == "text" and part.get("text")]
+
+
+def _task_text(task: dict[str, Any]) -> str:
+    """Returns the text of the artifacts of the task, or of its status message if it has no artifacts."""
+    texts = []
+    for artifact in task.get("artifacts") or []:
+        texts.extend(_parts_text(artifact.get("parts")))
+    if not texts:
+        status_message = (task.get("status") or {}).get("message") or {}
+        texts = _parts_text(status_message.get("parts"))
+    return "\n".join(texts)
@@ -1,4 +1,35 @@
+from ._approval_tool import (
+    ApprovalManager,
+    ApprovalRequest,
+    ApprovalStatus,
+    ApprovalStore,
+    ApprovalTool,
+    ApprovalToolConfig,
+    ToolApprovalError,
+    get_approval_manager,
+)
+from ._dry_run_tool import DryRunTool, DryRunToolConfig
 from ._llm_tool import LLMCallError, LLMTool, LLMToolConfig, LLMToolInput
-from ._shell import run_command
+from ._renamed_tool import RenamedTool, RenamedToolConfig
+from ._shell import kube_token, run_command
 
-__all__ = ["LLMTool", "LLMToolConfig", "run_command", "LLMCallError", "LLMToolInput"]
+__all__ = [
+    "LLMTool",
+    "LLMToolConfig",
+    "run_command",
+    "kube_token",
+    "LLMCallError",
+    "LLMToolInput",
+    "RenamedTool",
+    "RenamedToolConfig",
+    "DryRunTool",
+    "DryRunToolConfig",
+    "ApprovalManager",
+    "ApprovalRequest",
+    "ApprovalStatus",
+    "ApprovalStore",
+    "ApprovalTool",
+    "ApprovalToolConfig",
+    "ToolApprovalError",
+    "get_approval_manager",
+]
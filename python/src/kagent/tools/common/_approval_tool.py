@@ -0,0 +1,265 @@
+import asyncio
+import uuid
+from datetime import datetime, timezone
+from typing import Any, Callable, Dict, List, Literal, Optional, Tuple
+
+from autogen_core import CancellationToken, Component, ComponentModel
+from autogen_core.tools import BaseTool, ToolSchema
+from loguru import logger
+from pydantic import BaseModel, Field
+
+ApprovalStatus = Literal["pending", "approved", "denied", "expired", "cancelled"]
+
+
+class ApprovalRequest(BaseModel):
+    """A request for a human to approve a single tool call."""
+
+    id: str = Field(..., description="The ID of the approval request.")
+    agent: str = Field(..., description="The agent which wants to call the tool.")
+    tool: str = Field(..., description="The name of the tool.")
+    arguments: Dict[str, Any] = Field(default_factory=dict, description="The arguments of the tool call.")
+    status: ApprovalStatus = Field("pending", description="The status of the approval request.")
+    requested_at: datetime = Field(default_factory=lambda: datetime.now(timezone.utc))
+    expires_at: datetime = Field(..., description="When the request expires if nobody decides on it.")
+    decided_at: Optional[datetime] = None
+    decided_by: Optional[str] = Field(None, description="The user who approved or denied the request.")
+    reason: Optional[str] = Field(None, description="The reason given for the decision.")
+
+
+class ToolApprovalError(Exception):
+    """Raised when a tool call was not approved."""
+
+
+ApprovalListener = Callable[[ApprovalRequest], None]
+
+
+class ApprovalStore:
+    """
+    ApprovalStore persists the approval requests, so a pending request can be decided through any process
+    sharing the store, not only by the one running the tool call.
+
+    The default store keeps the requests in memory, the autogenstudio server persists them in its database.
+    """
+
+    def __init__(self) -> None:
+        self._requests: Dict[str, ApprovalRequest] = {}
+
+    def save(self, request: ApprovalRequest) -> None:
+        """Save a new request."""
+        self._requests[request.id] = request.model_copy()
+
+    def get(self, approval_id: str) -> Optional[ApprovalRequest]:
+        """Return the current state of a request, or None if there is no such request."""
+        request = self._requests.get(approval_id)
+        return request.model_copy() if request is not None else None
+
+    def finish(
+        self,
+        approval_id: str,
+        status: ApprovalStatus,
+        decided_by: Optional[str] = None,
+        reason: Optional[str] = None,
+    ) -> Optional[ApprovalRequest]:
+        """
+        Move a pending request to its final status.
+        Returns None if there is no such pending request, e.g. if it was already decided.
+        """
+        request = self._requests.get(approval_id)
+        if request is None or request.status != "pending":
+            return None
+        request.status = status
+        request.decided_at = datetime.now(timezone.utc)
+        request.decided_by = decided_by
+        request.reason = reason
+        return request.model_copy()
+
+
+class ApprovalManager:
+    """
+    ApprovalManager keeps track of the tool calls waiting for a human decision.
+
+    The requests are persisted in the store. The decisions taken by this manager wake up the waiting calls
+    immediately, the ones taken through other processes sharing the store are noticed by polling it.
+    Listeners are notified every time a request is created or changes status.
+    """
+
+    def __init__(self, store: Optional[ApprovalStore] = None, poll_interval_seconds: float = 1) -> None:
+        self._store = store or ApprovalStore()
+        self._poll_interval_seconds = poll_interval_seconds
+        self._waiting: Dict[str, Tuple[ApprovalRequest, asyncio.Event]] = {}
+        self._listeners: List[ApprovalListener] = []
+
+    def set_store(self, store: ApprovalStore) -> None:
+        """Replace the store, before any request is made."""
+        self._store = store
+
+    def add_listener(self, listener: ApprovalListener) -> None:
+        self._listeners.append(listener)
+
+    def list_pending(self) -> List[ApprovalRequest]:
+        """List the pending requests of the tool calls run by this process."""
+        return [request for request, _ in self._waiting.values()]
+
+    async def request_approval(
+        self,
+        agent: str,
+        tool: str,
+        arguments: Dict[str, Any],
+        timeout_seconds: float,
+        cancellation_token: Optional[CancellationToken] = None,
+    ) -> ApprovalRequest:
+        """Create an approval request and wait until it is decided, expires or is cancelled."""
+        now = datetime.now(timezone.utc)
+        request = ApprovalRequest(
+            id=uuid.uuid4().hex,
+            agent=agent,
+            tool=tool,
+            arguments=arguments,
+            requested_at=now,
+            expires_at=datetime.fromtimestamp(now.timestamp() + timeout_seconds, timezone.utc),
+        )
+        decided = asyncio.Event()
+        self._store.save(request)
+        self._waiting[request.id] = (request, decided)
+        self._notify(request)
+
+        wait = asyncio.ensure_future(self._wait_for_decision(request.id, decided, timeout_seconds))
+        if cancellation_token is not None:
+            cancellation_token.link_future(wait)
+        try:
+            final = await wait
+        except asyncio.CancelledError:
+            self._finish(request.id, "cancelled")
+            raise
+        finally:
+            self._waiting.pop(request.id, None)
+
+        return final
+
+    async def _wait_for_decision(
+        self, approval_id: str, decided: asyncio.Event, timeout_seconds: float
+    ) -> ApprovalRequest:
+        loop = asyncio.get_running_loop()
+        deadline = loop.time() + timeout_seconds
+        while True:
+            current = self._store.get(approval_id)
+            if current is None:
+                raise ToolApprovalError(f"The approval request {approval_id} was removed")
+            if current.status != "pending":
+                return current
+            remaining = deadline - loop.time()
+            if remaining <= 0:
+                expired = self._finish(approval_id, "expired")
+                if expired is not None:
+                    return expired
+                # decided in the meantime, the decision stands
+                continue
+            try:
+                await asyncio.wait_for(decided.wait(), timeout=min(remaining, self._poll_interval_seconds))
+            except asyncio.TimeoutError:
+                pass
+
+    def decide(
+        self, approval_id: str, approved: bool, user: str, reason: Optional[str] = None
+    ) -> Optional[ApprovalRequest]:
+        """Approve or deny a pending request. Returns None if there is no such pending request."""
+        return self._finish(approval_id, "approved" if approved else "denied", user, reason)
+
+    def _finish(
+        self,
+        approval_id: str,
+        status: ApprovalStatus,
+        decided_by: Optional[str] = None,
+        reason: Optional[str] = None,
+    ) -> Optional[ApprovalRequest]:
+        request = self._store.finish(approval_id, status, decided_by, reason)
+        if request is None:
+            return None
+        waiting = self._waiting.get(approval_id)
+        if waiting is not None:
+            waiting[1].set()
+        self._notify(request)
+        return request
+
+    def _notify(self, request: ApprovalRequest) -> None:
+        for listener in self._listeners:
+            try:
+                listener(request)
+            except Exception as e:
+                logger.error(f"Error notifying approval listener for request {request.id}: {e}")
+
+
+_approval_manager = ApprovalManager()
+
+
+def get_approval_manager() -> ApprovalManager:
+    """Returns the approval manager shared by all the tools of the process."""
+    return _approval_manager
+
+
+class ApprovalToolConfig(BaseModel):
+    """Configuration for the ApprovalTool."""
+
+    tool: ComponentModel = Field(..., description="The tool to wrap.")
+    agent: str = Field(..., description="The agent the tool belongs to, shown to the approver.")
+    timeout_seconds: float = Field(600, description="How long to wait for a decision before failing the call.")
+
+
+class ApprovalTool(BaseTool[BaseModel, Any], Component[ApprovalToolConfig]):
+    """
+    ApprovalTool pauses every call of the wrapped tool until a human approves it.
+
+    Denied and expired calls fail without running the wrapped tool, so the model sees the error.
+
+    Args:
+        config (ApprovalToolConfig): Configuration for the ApprovalTool.
+    """
+
+    component_description = "ApprovalTool requires a human approval before running a wrapped tool."
+    component_type = "tool"
+    component_config_schema = ApprovalToolConfig
+    component_provider_override = "kagent.tools.common.ApprovalTool"
+
+    def __init__(self, config: ApprovalToolConfig) -> None:
+        self._config = config
+        self._tool: BaseTool[BaseModel, Any] = BaseTool.load_component(config.tool)
+
+        super().__init__(
+            args_type=self._tool.args_type(),
+            return_type=self._tool.return_type(),
+            name=self._tool.name,
+            description=self._tool.description,
+        )
+
+    @property
+    def schema(self) -> ToolSchema:
+        return self._tool.schema
+
+    async def run(self, args: BaseModel, cancellation_token: CancellationToken) -> Any:
+        request = await get_approval_manager().request_approval(
+            agent=self._config.agent,
+            tool=self.name,
+            arguments=args.model_dump(exclude_unset=True),
+            timeout_seconds=self._config.timeout_seconds,
+            cancellation_token=cancellation_token,
+        )
+
+        if request.status == "expired":
+            raise ToolApprovalError(f"The call to {self.name} was not approved within {self._config.timeout_seconds}s")
+        if request.status != "approved":
+            message = f"The call to {self.name} was denied by {request.decided_by}"
+            if request.reason:
+                message += f": {request.reason}"
+            raise ToolApprovalError(message)
+
+        return await self._tool.run(args, cancellation_token)
+
+    def return_value_as_string(self, value: Any) -> str:
+        return self._tool.return_value_as_string(value)
+
+    def _to_config(self) -> ApprovalToolConfig:
+        return ApprovalToolConfig(**self._config.model_dump())
+
+    @classmethod
+    def _from_config(cls, config: ApprovalToolConfig) -> "ApprovalTool":
+        return cls(config)
@@ -0,0 +1,58 @@
+import json
+from typing import Any
+
+from autogen_core import CancellationToken, Component, ComponentModel
+from autogen_core.tools import BaseTool, ToolSchema
+from pydantic import BaseModel, Field
+
+
+class DryRunToolConfig(BaseModel):
+    """Configuration for the DryRunTool."""
+
+    tool: ComponentModel = Field(..., description="The tool to wrap.")
+
+
+class DryRunTool(BaseTool[BaseModel, str], Component[DryRunToolConfig]):
+    """
+    DryRunTool exposes a tool to the model with its name, description and arguments,
+    but reports the calls to the model instead of running them.
+
+    This is used for the mutating tools of agents in dryRun mode.
+
+    Args:
+        config (DryRunToolConfig): Configuration for the DryRunTool.
+    """
+
+    component_description = "DryRunTool reports the calls of a wrapped tool instead of running them."
+    component_type = "tool"
+    component_config_schema = DryRunToolConfig
+    component_provider_override = "kagent.tools.common.DryRunTool"
+
+    def __init__(self, config: DryRunToolConfig) -> None:
+        self._config = config
+        self._tool: BaseTool[BaseModel, Any] = BaseTool.load_component(config.tool)
+
+        super().__init__(
+            args_type=self._tool.args_type(),
+            return_type=str,
+            name=self._tool.name,
+            description=self._tool.description,
+        )
+
+    @property
+    def schema(self) -> ToolSchema:
+        return self._tool.schema
+
+    async def run(self, args: BaseModel, cancellation_token: CancellationToken) -> str:
+        arguments = json.dumps(args.model_dump(exclude_unset=True), default=str)
+        return (
+            f"Dry run: {self.name} was not executed because the agent runs in dry-run mode. "
+            f"It would have been called with the arguments {arguments}."
+        )
+
+    def _to_config(self) -> DryRunToolConfig:
+        return DryRunToolConfig(**self._config.model_dump())
+
+    @classmethod
+    def _from_config(cls, config: DryRunToolConfig) -> "DryRunTool":
+        return cls(config)
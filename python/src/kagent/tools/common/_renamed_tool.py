@@ -0,0 +1,63 @@
+from typing import Any, Optional
+
+from autogen_core import CancellationToken, Component, ComponentModel
+from autogen_core.tools import BaseTool, ToolSchema
+from pydantic import BaseModel, Field
+
+
+class RenamedToolConfig(BaseModel):
+    """Configuration for the RenamedTool."""
+
+    name: str = Field(..., description="The name of the tool, as seen by the model.")
+    description: Optional[str] = Field(
+        None, description="The description of the tool. If not provided, the wrapped tool's description is used."
+    )
+    tool: ComponentModel = Field(..., description="The tool to wrap.")
+
+
+class RenamedTool(BaseTool[BaseModel, Any], Component[RenamedToolConfig]):
+    """
+    RenamedTool exposes a tool to the model under a different name and description,
+    while still invoking the wrapped tool with its original name.
+
+    This is used to prefix the names of MCP tools, which call the server using the name they were discovered with.
+
+    Args:
+        config (RenamedToolConfig): Configuration for the RenamedTool.
+    """
+
+    component_description = "RenamedTool exposes a wrapped tool under a different name and description."
+    component_type = "tool"
+    component_config_schema = RenamedToolConfig
+    component_provider_override = "kagent.tools.common.RenamedTool"
+
+    def __init__(self, config: RenamedToolConfig) -> None:
+        self._config = config
+        self._tool: BaseTool[BaseModel, Any] = BaseTool.load_component(config.tool)
+
+        super().__init__(
+            args_type=self._tool.args_type(),
+            return_type=self._tool.return_type(),
+            name=config.name,
+            description=config.description or self._tool.description,
+        )
+
+    @property
+    def schema(self) -> ToolSchema:
+        schema = self._tool.schema.copy()
+        schema["name"] = self.name
+        schema["description"] = self.description
+        return schema
+
+    async def run(self, args: BaseModel, cancellation_token: CancellationToken) -> Any:
+        return await self._tool.run(args, cancellation_token)
+
+    def return_value_as_string(self, value: Any) -> str:
+        return self._tool.return_value_as_string(value)
+
+    def _to_config(self) -> RenamedToolConfig:
+        return RenamedToolConfig(**self._config.model_dump())
+
+    @classmethod
+    def _from_config(cls, config: RenamedToolConfig) -> "RenamedTool":
+        return cls(config)
@@ -1,11 +1,54 @@
+import contextvars
+import json
+import os
 import subprocess
+import tempfile
+from typing import Optional
+
+# The service account token of the agent running the command, if it has one
+kube_token: contextvars.ContextVar[Optional[str]] = contextvars.ContextVar("kube_token", default=None)
+
+IN_CLUSTER_CA_FILE = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+
+
+def _kubeconfig(token: str) -> dict:
+    """Returns a kubeconfig authenticating to the API server of the cluster the process runs in with the token."""
+    host = os.environ.get("KUBERNETES_SERVICE_HOST", "kubernetes.default.svc")
+    port = os.environ.get("KUBERNETES_SERVICE_PORT", "443")
+    return {
+        "apiVersion": "v1",
+        "kind": "Config",
+        "clusters": [
+            {
+                "name": "in-cluster",
+                "cluster": {"server": f"https://{host}:{port}", "certificate-authority": IN_CLUSTER_CA_FILE},
+            }
+        ],
+        "users": [{"name": "agent", "user": {"token": token}}],
+        "contexts": [{"name": "agent", "context": {"cluster": "in-cluster", "user": "agent"}}],
+        "current-context": "agent",
+    }
 
 
 # Function that runs the command in the shell
 def run_command(command: str, args: list[str]) -> str:
-    """Run the given command and return the output."""
+    """Run the given command and return the output.
+
+    If a service account token is set for the current agent, the command uses it to access the cluster
+    instead of the identity of the pod."""
+    token = kube_token.get()
+    if token is None:
+        return _run(command, args, None)
+
+    with tempfile.NamedTemporaryFile(mode="w", suffix=".kubeconfig") as kubeconfig:
+        json.dump(_kubeconfig(token), kubeconfig)
+        kubeconfig.flush()
+        return _run(command, args, {**os.environ, "KUBECONFIG": kubeconfig.name})
+
+
+def _run(command: str, args: list[str], env: Optional[dict[str, str]]) -> str:
     try:
-        output = subprocess.check_output([command] + args, stderr=subprocess.STDOUT)
+        output = subprocess.check_output([command] + args, stderr=subprocess.STDOUT, env=env)
         return output.decode("utf-8")
     except subprocess.CalledProcessError as e:
         return f"Error running {command} command: {e.output.decode('utf-8')}"
@@ -0,0 +1,14 @@
+from ._secrets import McpSecretKeyRef
+from ._sse_client import create_tls_sse_session
+from ._tls import McpTlsConfig, TlsSseServerParams, create_ssl_context
+from ._tls_tool_adapter import TlsSseMcpToolAdapter, TlsSseMcpToolAdapterConfig
+
+__all__ = [
+    "McpSecretKeyRef",
+    "McpTlsConfig",
+    "TlsSseServerParams",
+    "TlsSseMcpToolAdapter",
+    "TlsSseMcpToolAdapterConfig",
+    "create_ssl_context",
+    "create_tls_sse_session",
+]
@@ -0,0 +1,53 @@
+import base64
+import os
+
+import httpx
+from pydantic import BaseModel, Field
+
+# the credentials of the service account the server runs with, mounted in every pod
+SERVICE_ACCOUNT_DIR = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+
+class McpSecretKeyRef(BaseModel):
+    """A key of a Secret, which is read when connecting to an MCP server."""
+
+    namespace: str = Field(..., description="The namespace of the Secret")
+    name: str = Field(..., description="The name of the Secret")
+    key: str = Field(..., description="The key of the value in the Secret")
+
+
+async def read_secret(namespace: str, name: str) -> dict[str, bytes]:
+    """Read the data of a Secret from the API server of the cluster the server runs in."""
+    host = os.environ.get("KUBERNETES_SERVICE_HOST")
+    port = os.environ.get("KUBERNETES_SERVICE_PORT", "443")
+    if not host:
+        raise RuntimeError(f"Cannot read secret {namespace}/{name} outside of a Kubernetes cluster")
+    if ":" in host:
+        host = f"[{host}]"
+
+    with open(os.path.join(SERVICE_ACCOUNT_DIR, "token")) as f:
+        token = f.read().strip()
+
+    async with httpx.AsyncClient(verify=os.path.join(SERVICE_ACCOUNT_DIR, "ca.crt")) as client:
+        response = await client.get(
+            f"https://{host}:{port}/api/v1/namespaces/{namespace}/secrets/{name}",
+            headers={"Authorization": f"Bearer {token}"},
+        )
+        if response.status_code != httpx.codes.OK:
+            raise RuntimeError(f"Failed to read secret {namespace}/{name}: {response.status_code} {response.text}")
+        data = response.json().get("data") or {}
+
+    return {key: base64.b64decode(value) for key, value in data.items()}
+
+
+async def read_secret_key(ref: McpSecretKeyRef) -> str:
+    """Read the value of a key of a Secret."""
+    data = await read_secret(ref.namespace, ref.name)
+    if ref.key not in data:
+        raise KeyError(f"Key {ref.key} not found in secret {ref.namespace}/{ref.name}")
+    return data[ref.key].decode()
+
+
+async def resolve_headers(headers_from: dict[str, McpSecretKeyRef]) -> dict[str, str]:
+    """Read the values of the headers from their Secrets."""
+    return {name: await read_secret_key(ref) for name, ref in headers_from.items()}
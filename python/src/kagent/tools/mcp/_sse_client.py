@@ -0,0 +1,112 @@
+import logging
+import ssl
+from contextlib import asynccontextmanager
+from typing import Any, AsyncGenerator
+from urllib.parse import urljoin, urlparse
+
+import anyio
+import httpx
+from anyio.abc import TaskStatus
+from httpx_sse import aconnect_sse
+from mcp import ClientSession, types
+
+from ._secrets import resolve_headers
+from ._tls import TlsSseServerParams, create_ssl_context
+
+logger = logging.getLogger(__name__)
+
+
+@asynccontextmanager
+async def tls_sse_client(
+    url: str,
+    verify: ssl.SSLContext | bool = True,
+    headers: dict[str, Any] | None = None,
+    timeout: float = 5,
+    sse_read_timeout: float = 60 * 5,
+):
+    """
+    Client transport for SSE which verifies the server with the given SSL context.
+
+    This mirrors mcp.client.sse.sse_client, which does not allow configuring the underlying HTTP client.
+    """
+    read_stream_writer, read_stream = anyio.create_memory_object_stream(0)
+    write_stream, write_stream_reader = anyio.create_memory_object_stream(0)
+
+    async with anyio.create_task_group() as tg:
+        try:
+            async with httpx.AsyncClient(headers=headers, verify=verify) as client:
+                async with aconnect_sse(
+                    client,
+                    "GET",
+                    url,
+                    timeout=httpx.Timeout(timeout, read=sse_read_timeout),
+                ) as event_source:
+                    event_source.response.raise_for_status()
+
+                    async def sse_reader(task_status: TaskStatus[str] = anyio.TASK_STATUS_IGNORED):
+                        try:
+                            async for sse in event_source.aiter_sse():
+                                match sse.event:
+                                    case "endpoint":
+                                        endpoint_url = urljoin(url, sse.data)
+                                        url_parsed = urlparse(url)
+                                        endpoint_parsed = urlparse(endpoint_url)
+                                        if (
+                                            url_parsed.netloc != endpoint_parsed.netloc
+                                            or url_parsed.scheme != endpoint_parsed.scheme
+                                        ):
+                                            raise ValueError(f"Endpoint origin does not match connection origin: {endpoint_url}")
+                                        task_status.started(endpoint_url)
+                                    case "message":
+                                        try:
+                                            message = types.JSONRPCMessage.model_validate_json(sse.data)
+                                        except Exception as exc:
+                                            await read_stream_writer.send(exc)
+                                            continue
+                                        await read_stream_writer.send(message)
+                                    case _:
+                                        logger.warning(f"Unknown SSE event: {sse.event}")
+                        except Exception as exc:
+                            await read_stream_writer.send(exc)
+                        finally:
+                            await read_stream_writer.aclose()
+
+                    async def post_writer(endpoint_url: str):
+                        try:
+                            async with write_stream_reader:
+                                async for message in write_stream_reader:
+                                    response = await client.post(
+                                        endpoint_url,
+                                        json=message.model_dump(by_alias=True, mode="json", exclude_none=True),
+                                    )
+                                    response.raise_for_status()
+                        finally:
+                            await write_stream.aclose()
+
+                    endpoint_url = await tg.start(sse_reader)
+                    tg.start_soon(post_writer, endpoint_url)
+
+                    try:
+                        yield read_stream, write_stream
+                    finally:
+                        tg.cancel_scope.cancel()
+        finally:
+            await read_stream_writer.aclose()
+            await write_stream.aclose()
+
+
+@asynccontextmanager
+async def create_tls_sse_session(server_params: TlsSseServerParams) -> AsyncGenerator[ClientSession, None]:
+    """Create a session with an MCP server over SSE, reading its credentials from Secrets."""
+    headers = dict(server_params.headers or {})
+    headers.update(await resolve_headers(server_params.headers_from))
+    verify = await create_ssl_context(server_params.tls) if server_params.tls is not None else True
+    async with tls_sse_client(
+        url=server_params.url,
+        verify=verify,
+        headers=headers,
+        timeout=server_params.timeout,
+        sse_read_timeout=server_params.sse_read_timeout,
+    ) as (read, write):
+        async with ClientSession(read_stream=read, write_stream=write) as session:
+            yield session
@@ -0,0 +1,59 @@
+import os
+import ssl
+import tempfile
+from typing import Optional
+
+from autogen_ext.tools.mcp._config import SseServerParams
+from pydantic import BaseModel, Field
+
+from ._secrets import McpSecretKeyRef, read_secret
+
+
+class McpTlsConfig(BaseModel):
+    """TLS configuration used to connect to an MCP server with a client certificate.
+
+    The certificates are read from a Secret when connecting, so that the private key isn't part of the configuration.
+    """
+
+    secret_namespace: str = Field(..., description="The namespace of the Secret holding the PEM encoded certificates")
+    secret_name: str = Field(..., description="The name of the Secret holding the PEM encoded certificates")
+    cert_key: str = Field(default="tls.crt", description="The key of the client certificate in the Secret")
+    key_key: str = Field(
+        default="tls.key", description="The key of the private key of the client certificate in the Secret"
+    )
+    ca_key: Optional[str] = Field(
+        default=None,
+        description="The key of the CA certificate used to verify the server. Defaults to the system CAs.",
+    )
+
+
+class TlsSseServerParams(SseServerParams):
+    """Parameters for connecting to an MCP server over SSE with credentials read from Secrets."""
+
+    tls: Optional[McpTlsConfig] = Field(default=None, description="The TLS configuration")
+    headers_from: dict[str, McpSecretKeyRef] = Field(
+        default_factory=dict, description="The headers whose values are read from Secrets"
+    )
+
+
+async def create_ssl_context(config: McpTlsConfig) -> ssl.SSLContext:
+    """Create an SSL context presenting the client certificate and trusting the CA certificate, if set."""
+    data = await read_secret(config.secret_namespace, config.secret_name)
+    for key in (config.cert_key, config.key_key):
+        if key not in data:
+            raise KeyError(f"Key {key} not found in secret {config.secret_namespace}/{config.secret_name}")
+
+    ca_cert = data.get(config.ca_key) if config.ca_key else None
+    context = ssl.create_default_context(cadata=ca_cert.decode()) if ca_cert else ssl.create_default_context()
+
+    # the ssl module can only load certificate chains from files
+    with tempfile.TemporaryDirectory() as tmp_dir:
+        cert_path = os.path.join(tmp_dir, "tls.crt")
+        key_path = os.path.join(tmp_dir, "tls.key")
+        for path, content in ((cert_path, data[config.cert_key]), (key_path, data[config.key_key])):
+            fd = os.open(path, os.O_WRONLY | os.O_CREAT, 0o600)
+            with os.fdopen(fd, "wb") as f:
+                f.write(content)
+        context.load_cert_chain(cert_path, key_path)
+
+    return context
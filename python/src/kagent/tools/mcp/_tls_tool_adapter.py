@@ -0,0 +1,75 @@
+import asyncio
+from typing import Any, List
+
+from autogen_core import CancellationToken, Component
+from autogen_core.tools import BaseTool
+from autogen_core.utils import schema_to_pydantic_model
+from mcp.types import EmbeddedResource, ImageContent, TextContent, Tool
+from pydantic import BaseModel, Field
+from typing_extensions import Self
+
+from ._sse_client import create_tls_sse_session
+from ._tls import TlsSseServerParams
+
+
+class TlsSseMcpToolAdapterConfig(BaseModel):
+    """Configuration for the TlsSseMcpToolAdapter."""
+
+    server_params: TlsSseServerParams = Field(..., description="The parameters used to connect to the MCP server")
+    tool: Tool = Field(..., description="The MCP tool to call")
+
+
+class TlsSseMcpToolAdapter(BaseTool[BaseModel, Any], Component[TlsSseMcpToolAdapterConfig]):
+    """
+    TlsSseMcpToolAdapter calls a tool on an MCP server over SSE with credentials read from Secrets.
+
+    Args:
+        config (TlsSseMcpToolAdapterConfig): Configuration for the TlsSseMcpToolAdapter.
+    """
+
+    component_description = "TlsSseMcpToolAdapter calls a tool on an MCP server over SSE with credentials from Secrets."
+    component_type = "tool"
+    component_config_schema = TlsSseMcpToolAdapterConfig
+    component_provider_override = "kagent.tools.mcp.TlsSseMcpToolAdapter"
+
+    def __init__(self, config: TlsSseMcpToolAdapterConfig) -> None:
+        self._config = config
+
+        super().__init__(
+            args_type=schema_to_pydantic_model(config.tool.inputSchema),
+            return_type=list,
+            name=config.tool.name,
+            description=config.tool.description or "",
+        )
+
+    async def run(self, args: BaseModel, cancellation_token: CancellationToken) -> List[Any]:
+        kwargs = args.model_dump(exclude_unset=True)
+        async with create_tls_sse_session(self._config.server_params) as session:
+            await session.initialize()
+            result_future = asyncio.ensure_future(session.call_tool(self._config.tool.name, kwargs))
+            cancellation_token.link_future(result_future)
+            result = await result_future
+
+        if result.isError:
+            raise Exception(f"MCP tool execution failed: {self.return_value_as_string(result.content)}")
+        return result.content
+
+    def return_value_as_string(self, value: Any) -> str:
+        parts: List[str] = []
+        for item in value:
+            if isinstance(item, TextContent):
+                parts.append(item.text)
+            elif isinstance(item, ImageContent):
+                parts.append("[Image]")
+            elif isinstance(item, EmbeddedResource):
+                parts.append(item.resource.model_dump_json())
+            else:
+                parts.append(str(item))
+        return "\n".join(parts)
+
+    def _to_config(self) -> TlsSseMcpToolAdapterConfig:
+        return TlsSseMcpToolAdapterConfig(**self._config.model_dump())
+
+    @classmethod
+    def _from_config(cls, config: TlsSseMcpToolAdapterConfig) -> Self:
+        return cls(config)
@@ -0,0 +1,346 @@
+"""
+Generates the catalog of builtin tools used by the controller to validate Agents.
+
+The catalog is generated from the sources of the tools rather than by importing them,
+so it can be regenerated without installing the dependencies of every tool.
+"""
+
+import argparse
+import ast
+import json
+import logging
+import re
+from pathlib import Path
+from typing import Any, Optional
+
+logging.basicConfig(level=logging.INFO)
+
+TOOLS_DIR = Path(__file__).parent.parent
+# the packages exporting builtin tools, as in tool_gen
+TOOL_DIRS = ["istio", "k8s", "prometheus", "docs", "helm", "argo", "grafana", "datetime", "cilium"]
+DEFAULT_OUTPUT = TOOLS_DIR.parents[3] / "go" / "controller" / "internal" / "builtintools" / "catalog.json"
+
+# The controller adds these fields to the config of the tools, so they are never set on Agents
+MODEL_CLIENT_TOOLS = ["kagent.tools.prometheus.GeneratePromQLTool", "kagent.tools.k8s.GenerateResourceTool"]
+OPENAI_API_KEY_TOOLS = ["kagent.tools.docs.QueryTool"]
+# Names agents referenced builtin tools with before the catalog, which are kept as aliases so they still resolve.
+# The <package>-<tool-name> names of the function tools, e.g. k8s-get-pod-logs, are added for all of them.
+LEGACY_ALIASES = {
+    "k8s-get-pod": "kagent.tools.k8s.GetResources",
+}
+# The typed fn tools of these packages access the cluster, so they run with the service account of the agent
+KUBE_TOOL_DIRS = ["k8s", "helm", "istio", "argo", "cilium"]
+
+# Tools whose class name starts with one of these verbs change the state of the systems they access,
+# so they are removed from read-only agents and not executed by dry-run agents
+MUTATING_VERBS = [
+    "Annotate",
+    "Apply",
+    "Clean",
+    "Connect",
+    "Create",
+    "Delete",
+    "Disconnect",
+    "Execute",
+    "Flush",
+    "Install",
+    "Manage",
+    "Patch",
+    "Pause",
+    "Promote",
+    "Remove",
+    "Rollout",
+    "Scale",
+    "Set",
+    "Toggle",
+    "Uninstall",
+    "Update",
+    "Upgrade",
+]
+# Mutating tools whose name does not start with a mutating verb, e.g. the grafana tools which mutate depending on
+# their action argument
+MUTATING_TOOLS = [
+    "kagent.tools.k8s.LabelResource",
+    "kagent.tools.helm.RepoAdd",
+    "kagent.tools.helm.RepoUpdate",
+    "kagent.tools.grafana.AlertManagementTool",
+    "kagent.tools.grafana.AnnotationManagementTool",
+    "kagent.tools.grafana.DashboardManagementTool",
+    "kagent.tools.grafana.DataSourceManagementTool",
+    "kagent.tools.grafana.FolderManagementTool",
+    "kagent.tools.grafana.MiscManagementTool",
+    "kagent.tools.grafana.OrgManagementTool",
+    "kagent.tools.grafana.TeamManagementTool",
+    "kagent.tools.grafana.UserManagementTool",
+]
+
+JSON_SCHEMA_TYPES = {
+    "str": "string",
+    "int": "integer",
+    "float": "number",
+    "bool": "boolean",
+    "dict": "object",
+    "Dict": "object",
+    "ComponentModel": "object",
+    "list": "array",
+    "List": "array",
+}
+
+
+def _string_value(node: Optional[ast.expr], constants: dict[str, str]) -> Optional[str]:
+    if isinstance(node, ast.Constant) and isinstance(node.value, str):
+        return node.value
+    if isinstance(node, ast.Name):
+        return constants.get(node.id)
+    if isinstance(node, ast.JoinedStr):
+        # keep the literal parts of f-strings, which only interpolate lists of products
+        return "".join(v.value for v in node.values if isinstance(v, ast.Constant)).strip()
+    return None
+
+
+def _keyword(call: ast.Call, name: str) -> Optional[ast.expr]:
+    return next((k.value for k in call.keywords if k.arg == name), None)
+
+
+def _call_name(node: ast.expr) -> str:
+    if isinstance(node, ast.Call):
+        func = node.func
+        if isinstance(func, ast.Name):
+            return func.id
+        if isinstance(func, ast.Attribute):
+            return func.attr
+    return ""
+
+
+def _annotation_type(annotation: ast.expr) -> str:
+    if isinstance(annotation, ast.Subscript):
+        container = annotation.value.id if isinstance(annotation.value, ast.Name) else ""
+        if container == "Optional":
+            return _annotation_type(annotation.slice)
+        return JSON_SCHEMA_TYPES.get(container, "")
+    if isinstance(annotation, ast.Name):
+        return JSON_SCHEMA_TYPES.get(annotation.id, "")
+    return ""
+
+
+class _Module:
+    def __init__(self, path: Path) -> None:
+        self.tree = ast.parse(path.read_text())
+        self.constants: dict[str, str] = {}
+        self.function_tools: dict[str, tuple[Optional[str], Optional[str]]] = {}
+        self.typed_tools: dict[str, tuple[str, str]] = {}
+        self.classes: dict[str, ast.ClassDef] = {}
+
+        for node in self.tree.body:
+            if isinstance(node, ast.ClassDef):
+                self.classes[node.name] = node
+            if not isinstance(node, ast.Assign):
+                continue
+            value = node.value
+            if isinstance(node.targets[0], ast.Name):
+                target = node.targets[0].id
+                if isinstance(value, ast.Constant) and isinstance(value.value, str):
+                    self.constants[target] = value.value
+                elif _call_name(value) == "FunctionTool":
+                    assert isinstance(value, ast.Call)
+                    description = _keyword(value, "description")
+                    if description is None and len(value.args) > 1:
+                        description = value.args[1]
+                    # the name of a function tool defaults to the name of its function
+                    name = _string_value(_keyword(value, "name"), self.constants)
+                    if name is None and value.args and isinstance(value.args[0], ast.Name):
+                        name = value.args[0].id
+                    self.function_tools[target] = (_string_value(description, self.constants), name)
+            elif isinstance(node.targets[0], ast.Tuple) and _call_name(value) == "create_typed_fn_tool":
+                assert isinstance(value, ast.Call)
+                class_name = node.targets[0].elts[0]
+                fn_tool, provider = value.args[0], value.args[1]
+                if isinstance(class_name, ast.Name) and isinstance(fn_tool, ast.Name):
+                    self.typed_tools[class_name.id] = (fn_tool.id, _string_value(provider, self.constants) or "")
+
+
+class _Sources:
+    def __init__(self) -> None:
+        self.modules = {
+            path: _Module(path) for path in TOOLS_DIR.rglob("*.py") if "utils" not in path.relative_to(TOOLS_DIR).parts
+        }
+
+    def find_class(self, name: str, preferred: _Module) -> tuple[Optional[_Module], Optional[ast.ClassDef]]:
+        if name in preferred.classes:
+            return preferred, preferred.classes[name]
+        for module in self.modules.values():
+            if name in module.classes:
+                return module, module.classes[name]
+        return None, None
+
+    def class_attribute(self, module: _Module, cls: ast.ClassDef, attribute: str) -> Optional[ast.expr]:
+        for node in cls.body:
+            if isinstance(node, ast.Assign) and any(
+                isinstance(t, ast.Name) and t.id == attribute for t in node.targets
+            ):
+                return node.value
+        for base in cls.bases:
+            if isinstance(base, ast.Name):
+                base_module, base_cls = self.find_class(base.id, module)
+                if base_cls is not None and base_cls is not cls:
+                    value = self.class_attribute(base_module, base_cls, attribute)
+                    if value is not None:
+                        return value
+        return None
+
+    def config_schema(self, module: _Module, config_name: Optional[str]) -> dict[str, Any]:
+        properties: dict[str, Any] = {}
+        config_module, config_cls = self.find_class(config_name, module) if config_name else (None, None)
+        while config_cls is not None:
+            for node in config_cls.body:
+                if not (isinstance(node, ast.AnnAssign) and isinstance(node.target, ast.Name)):
+                    continue
+                field: dict[str, Any] = {}
+                field_type = _annotation_type(node.annotation)
+                if field_type:
+                    field["type"] = field_type
+                if isinstance(node.value, ast.Call):
+                    description = _string_value(_keyword(node.value, "description"), config_module.constants)
+                    if description:
+                        field["description"] = description
+                properties.setdefault(node.target.id, field)
+            base = next((b.id for b in config_cls.bases if isinstance(b, ast.Name) and b.id != "BaseModel"), None)
+            config_module, config_cls = self.find_class(base, config_module) if base else (None, None)
+
+        return {"type": "object", "properties": properties, "additionalProperties": False}
+
+
+def _class_description(sources: _Sources, module: _Module, cls: ast.ClassDef) -> str:
+    description = _string_value(sources.class_attribute(module, cls, "component_description"), module.constants)
+    if description:
+        return description
+    # the prometheus and grafana tools pass their description to the base class
+    for node in ast.walk(cls):
+        if isinstance(node, ast.Call) and _call_name(node) == "__init__":
+            description = _string_value(_keyword(node, "description"), module.constants)
+            if description:
+                return description
+    description = _string_value(sources.class_attribute(module, cls, "_description"), module.constants)
+    return description or (ast.get_docstring(cls) or "").split("\n")[0]
+
+
+def _class_tool_name(sources: _Sources, module: _Module, cls: ast.ClassDef) -> str:
+    """Returns the name the model sees for a tool class, which is passed to the constructor of BaseTool"""
+    for node in ast.walk(cls):
+        if not (isinstance(node, ast.Call) and _call_name(node) == "__init__"):
+            continue
+        name = _keyword(node, "name")
+        if name is None and len(node.args) > 2:
+            name = node.args[2]
+        if name is None:
+            continue
+        # the prometheus and grafana tools are named after their class
+        if isinstance(name, ast.Attribute) and name.attr == "__name__":
+            return cls.name
+        value = _string_value(name, module.constants)
+        if value:
+            return value
+    for base in cls.bases:
+        if isinstance(base, ast.Name):
+            base_module, base_cls = sources.find_class(base.id, module)
+            if base_cls is not None and base_cls is not cls:
+                name = _class_tool_name(sources, base_module, base_cls)
+                if name:
+                    # the base class names its subclasses
+                    return cls.name if name == base_cls.name else name
+    return ""
+
+
+def _exported_names(package: Path) -> list[str]:
+    tree = ast.parse((package / "__init__.py").read_text())
+    for node in tree.body:
+        if isinstance(node, ast.Assign) and any(isinstance(t, ast.Name) and t.id == "__all__" for t in node.targets):
+            return [elt.value for elt in node.value.elts if isinstance(elt, ast.Constant)]  # type: ignore[attr-defined]
+    return []
+
+
+def _legacy_alias(tool_dir: str, tool_name: str) -> str:
+    return f"{tool_dir}-{tool_name.strip('_').replace('_', '-')}"
+
+
+def _is_mutating(name: str) -> bool:
+    class_name = name.rsplit(".", 1)[-1]
+    return name in MUTATING_TOOLS or re.match(f"({'|'.join(MUTATING_VERBS)})([A-Z]|$)", class_name) is not None
+
+
+def generate_catalog() -> list[dict[str, Any]]:
+    sources = _Sources()
+    catalog = []
+
+    for tool_dir in TOOL_DIRS:
+        package = TOOLS_DIR / tool_dir
+        exported = set(_exported_names(package))
+        for path, module in sorted(sources.modules.items()):
+            if package not in path.parents:
+                continue
+            for class_name, (fn_tool, provider) in module.typed_tools.items():
+                if class_name not in exported:
+                    continue
+                description, tool_name = module.function_tools.get(fn_tool, (None, None))
+                # autogen imports the provider, so the name is the one exported by the package
+                name = f"kagent.tools.{tool_dir}.{class_name}"
+                aliases = [f"{tool_dir}.{tool_name}", _legacy_alias(tool_dir, tool_name)] if tool_name else []
+                if provider != name:
+                    aliases.append(provider)
+                tool = {
+                    "name": name,
+                    "aliases": aliases,
+                    "toolName": tool_name or "",
+                    "description": description or "",
+                    "configSchema": sources.config_schema(module, "TypedToolConfig"),
+                }
+                if tool_dir in KUBE_TOOL_DIRS:
+                    tool["needsKubeToken"] = True
+                catalog.append(tool)
+            for class_name, cls in module.classes.items():
+                if class_name not in exported or class_name.endswith(("Config", "Input")):
+                    continue
+                config = sources.class_attribute(module, cls, "component_config_schema")
+                if not isinstance(config, ast.Name):
+                    continue
+                provider = _string_value(sources.class_attribute(module, cls, "component_provider_override"), {})
+                catalog.append(
+                    {
+                        "name": provider or f"kagent.tools.{tool_dir}.{class_name}",
+                        "aliases": [],
+                        "toolName": _class_tool_name(sources, module, cls),
+                        "description": _class_description(sources, module, cls),
+                        "configSchema": sources.config_schema(module, config.id),
+                    }
+                )
+
+    for alias, name in LEGACY_ALIASES.items():
+        tool = next(tool for tool in catalog if tool["name"] == name)
+        tool["aliases"].append(alias)
+
+    for tool in catalog:
+        tool["description"] = re.sub(r"\s+", " ", tool["description"]).strip()
+        if tool["name"] in MODEL_CLIENT_TOOLS:
+            tool["needsModelClient"] = True
+        if tool["name"] in OPENAI_API_KEY_TOOLS:
+            tool["needsOpenAIKey"] = True
+        if _is_mutating(tool["name"]):
+            tool["mutating"] = True
+
+    return sorted(catalog, key=lambda tool: tool["name"])
+
+
+def main(args=None) -> None:
+    parser = argparse.ArgumentParser(description="Generate the catalog of builtin tools")
+    parser.add_argument("--output", "-o", type=str, default=str(DEFAULT_OUTPUT), help="Path of the catalog file")
+    parsed_args = parser.parse_args(args)
+
+    catalog = generate_catalog()
+    with open(parsed_args.output, "w") as f:
+        json.dump(catalog, f, indent=2)
+        f.write("\n")
+    logging.info(f"Generated the catalog of {len(catalog)} builtin tools in {parsed_args.output}")
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,89 @@
+import asyncio
+
+import pytest
+from autogen_core import CancellationToken
+
+from kagent.tools.common import ApprovalManager, ApprovalRequest, ApprovalStore
+
+
+async def _wait_for_pending(manager: ApprovalManager) -> ApprovalRequest:
+    while not manager.list_pending():
+        await asyncio.sleep(0)
+    return manager.list_pending()[0]
+
+
+async def test_approve():
+    manager = ApprovalManager()
+    audit: list[str] = []
+    manager.add_listener(lambda request: audit.append(request.status))
+
+    task = asyncio.create_task(manager.request_approval("test/agent", "delete_pod", {"name": "pod"}, 10))
+    pending = await _wait_for_pending(manager)
+    assert pending.tool == "delete_pod"
+
+    decided = manager.decide(pending.id, True, "alice", "looks good")
+    assert decided is not None
+
+    request = await task
+    assert request.status == "approved"
+    assert request.decided_by == "alice"
+    assert request.reason == "looks good"
+    assert manager.list_pending() == []
+    assert audit == ["pending", "approved"]
+
+
+async def test_deny():
+    manager = ApprovalManager()
+
+    task = asyncio.create_task(manager.request_approval("test/agent", "delete_pod", {}, 10))
+    pending = await _wait_for_pending(manager)
+    manager.decide(pending.id, False, "bob")
+
+    request = await task
+    assert request.status == "denied"
+    assert manager.decide(pending.id, True, "alice") is None
+
+
+async def test_timeout():
+    manager = ApprovalManager()
+
+    request = await manager.request_approval("test/agent", "delete_pod", {}, 0.01)
+    assert request.status == "expired"
+    assert request.decided_by is None
+    assert manager.list_pending() == []
+
+
+async def test_cancel():
+    manager = ApprovalManager()
+    audit: list[str] = []
+    manager.add_listener(lambda request: audit.append(request.status))
+    cancellation_token = CancellationToken()
+
+    task = asyncio.create_task(manager.request_approval("test/agent", "delete_pod", {}, 10, cancellation_token))
+    await _wait_for_pending(manager)
+    cancellation_token.cancel()
+
+    with pytest.raises(asyncio.CancelledError):
+        await task
+    assert audit == ["pending", "cancelled"]
+
+
+async def test_decide_through_another_manager():
+    # managers sharing a store stand for the processes sharing the database
+    store = ApprovalStore()
+    manager = ApprovalManager(store, poll_interval_seconds=0.01)
+    other = ApprovalManager(store, poll_interval_seconds=0.01)
+
+    task = asyncio.create_task(manager.request_approval("test/agent", "delete_pod", {}, 10))
+    pending = await _wait_for_pending(manager)
+    assert other.list_pending() == []
+
+    decided = other.decide(pending.id, True, "alice")
+    assert decided is not None
+    assert decided.status == "approved"
+
+    request = await task
+    assert request.status == "approved"
+    assert request.decided_by == "alice"
+    assert store.get(pending.id).status == "approved"
+    assert manager.decide(pending.id, False, "bob") is None
@@ -0,0 +1,29 @@
+from autogen_core import CancellationToken
+from autogen_core.tools import FunctionTool
+
+from kagent.tools.common import DryRunTool, DryRunToolConfig
+
+
+def delete_pod(name: str, namespace: str) -> str:
+    raise RuntimeError("the wrapped tool must not run")
+
+
+async def test_dry_run_reports_the_call():
+    wrapped = FunctionTool(delete_pod, description="Delete a pod", name="delete_pod")
+    tool = DryRunTool(DryRunToolConfig(tool=wrapped.dump_component()))
+
+    assert tool.name == "delete_pod"
+    assert tool.description == "Delete a pod"
+    assert tool.schema == wrapped.schema
+
+    result = await tool.run_json({"name": "web", "namespace": "default"}, CancellationToken())
+    assert "was not executed" in result
+    assert '"name": "web"' in result
+
+
+def test_dump_and_load():
+    wrapped = FunctionTool(delete_pod, description="Delete a pod", name="delete_pod")
+    tool = DryRunTool(DryRunToolConfig(tool=wrapped.dump_component()))
+
+    loaded = DryRunTool.load_component(tool.dump_component())
+    assert loaded.name == "delete_pod"
@@ -0,0 +1,82 @@
+import json
+
+import httpx
+import pytest
+from autogen_core import CancellationToken
+
+from kagent.tools.a2a import RemoteAgentError, RemoteAgentTool, RemoteAgentToolConfig, _remote_agent_tool
+
+
+def _mock_server(monkeypatch, handler):
+    requests = []
+
+    def transport_handler(request: httpx.Request) -> httpx.Response:
+        body = json.loads(request.content)
+        requests.append((request, body))
+        return httpx.Response(200, json={"jsonrpc": "2.0", "id": body["id"], "result": handler(body)})
+
+    client = httpx.AsyncClient
+
+    def client_with_transport(**kwargs):
+        return client(transport=httpx.MockTransport(transport_handler), **kwargs)
+
+    monkeypatch.setattr(_remote_agent_tool.httpx, "AsyncClient", client_with_transport)
+    return requests
+
+
+def _tool() -> RemoteAgentTool:
+    return RemoteAgentTool(
+        RemoteAgentToolConfig(
+            name="billing_agent",
+            description="Answers billing questions",
+            url="https://agents.example.com/a2a",
+            headers={"Authorization": "Bearer token"},
+            poll_interval_seconds=0,
+        )
+    )
+
+
+async def test_returns_the_artifacts_of_the_task(monkeypatch):
+    requests = _mock_server(
+        monkeypatch,
+        lambda body: {
+            "id": body["params"]["id"],
+            "status": {"state": "completed"},
+            "artifacts": [{"parts": [{"type": "text", "text": "The invoice is paid."}]}],
+        },
+    )
+
+    result = await _tool().run_json({"task": "Is the invoice paid?"}, CancellationToken())
+
+    assert result == "The invoice is paid."
+    request, body = requests[0]
+    assert request.headers["Authorization"] == "Bearer token"
+    assert body["method"] == "tasks/send"
+    assert body["params"]["message"]["parts"][0]["text"] == "Is the invoice paid?"
+
+
+async def test_polls_the_task_until_it_completes(monkeypatch):
+    states = iter(["working", "working", "completed"])
+    message = {"role": "agent", "parts": [{"type": "text", "text": "done"}]}
+    requests = _mock_server(
+        monkeypatch,
+        lambda body: {"id": body["params"]["id"], "status": {"state": next(states), "message": message}},
+    )
+
+    result = await _tool().run_json({"task": "Close the ticket"}, CancellationToken())
+
+    assert result == "done"
+    assert [body["method"] for _, body in requests] == ["tasks/send", "tasks/get", "tasks/get"]
+
+
+async def test_fails_when_the_task_fails(monkeypatch):
+    _mock_server(
+        monkeypatch,
+        lambda body: {
+            "id": body["params"]["id"],
+            "status": {"state": "failed", "message": {"role": "agent", "parts": [{"type": "text", "text": "boom"}]}},
+        },
+    )
+
+    with pytest.raises(RemoteAgentError, match="boom"):
+        await _tool().run_json({"task": "Refund the order"}, CancellationToken())
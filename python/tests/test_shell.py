@@ -0,0 +1,20 @@
+import json
+import os
+
+from kagent.tools.common import kube_token, run_command
+
+
+def test_run_command_with_kube_token():
+    reset_token = kube_token.set("agent-token")
+    try:
+        kubeconfig = json.loads(run_command("sh", ["-c", 'cat "$KUBECONFIG"']))
+    finally:
+        kube_token.reset(reset_token)
+
+    assert kubeconfig["users"][0]["user"]["token"] == "agent-token"
+    assert kubeconfig["current-context"] == "agent"
+
+
+def test_run_command_without_kube_token():
+    output = run_command("sh", ["-c", 'echo "${KUBECONFIG:-unset}"']).strip()
+    assert output == os.environ.get("KUBECONFIG", "unset")